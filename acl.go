@@ -0,0 +1,304 @@
+package godis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//ACLRules the rules of ACL SETUSER, they're applied in the order they're added
+type ACLRules struct {
+	rules []string
+}
+
+//NewACLRules create the rules of ACL SETUSER, SETUSER without any rule creates a user with no privileges
+func NewACLRules() *ACLRules {
+	return &ACLRules{rules: make([]string, 0)}
+}
+
+//Rule add the raw rule, like ">password" or "+@read"
+func (a *ACLRules) Rule(rules ...string) *ACLRules {
+	a.rules = append(a.rules, rules...)
+	return a
+}
+
+//On enable the user
+func (a *ACLRules) On() *ACLRules {
+	return a.Rule("on")
+}
+
+//Off disable the user, the already authenticated connections still work
+func (a *ACLRules) Off() *ACLRules {
+	return a.Rule("off")
+}
+
+//Reset remove all the passwords, keys, channels and commands of the user, and disable it
+func (a *ACLRules) Reset() *ACLRules {
+	return a.Rule("reset")
+}
+
+//AddPasswords add the passwords to the user
+func (a *ACLRules) AddPasswords(passwords ...string) *ACLRules {
+	return a.prefixed(">", passwords)
+}
+
+//RemovePasswords remove the passwords from the user
+func (a *ACLRules) RemovePasswords(passwords ...string) *ACLRules {
+	return a.prefixed("<", passwords)
+}
+
+//AddHashedPasswords add the SHA-256 hex digests of the passwords to the user
+func (a *ACLRules) AddHashedPasswords(hashes ...string) *ACLRules {
+	return a.prefixed("#", hashes)
+}
+
+//RemoveHashedPasswords remove the SHA-256 hex digests of the passwords from the user
+func (a *ACLRules) RemoveHashedPasswords(hashes ...string) *ACLRules {
+	return a.prefixed("!", hashes)
+}
+
+//NoPass allow any password for the user, and remove all its passwords
+func (a *ACLRules) NoPass() *ACLRules {
+	return a.Rule("nopass")
+}
+
+//ResetPass remove all the passwords of the user, and the user can't log in until a password is added
+func (a *ACLRules) ResetPass() *ACLRules {
+	return a.Rule("resetpass")
+}
+
+//Keys allow the user to read and write the keys matching the patterns
+func (a *ACLRules) Keys(patterns ...string) *ACLRules {
+	return a.prefixed("~", patterns)
+}
+
+//ReadKeys allow the user to read the keys matching the patterns (redis 7.0+)
+func (a *ACLRules) ReadKeys(patterns ...string) *ACLRules {
+	return a.prefixed("%R~", patterns)
+}
+
+//WriteKeys allow the user to write the keys matching the patterns (redis 7.0+)
+func (a *ACLRules) WriteKeys(patterns ...string) *ACLRules {
+	return a.prefixed("%W~", patterns)
+}
+
+//AllKeys allow the user to access all the keys
+func (a *ACLRules) AllKeys() *ACLRules {
+	return a.Rule("allkeys")
+}
+
+//ResetKeys remove all the key patterns of the user
+func (a *ACLRules) ResetKeys() *ACLRules {
+	return a.Rule("resetkeys")
+}
+
+//Channels allow the user to access the Pub/Sub channels matching the patterns (redis 6.2+)
+func (a *ACLRules) Channels(patterns ...string) *ACLRules {
+	return a.prefixed("&", patterns)
+}
+
+//AllChannels allow the user to access all the Pub/Sub channels (redis 6.2+)
+func (a *ACLRules) AllChannels() *ACLRules {
+	return a.Rule("allchannels")
+}
+
+//ResetChannels remove all the channel patterns of the user (redis 6.2+)
+func (a *ACLRules) ResetChannels() *ACLRules {
+	return a.Rule("resetchannels")
+}
+
+//AllowCommands allow the user to call the commands, a subcommand is written like "config|get"
+func (a *ACLRules) AllowCommands(commands ...string) *ACLRules {
+	return a.prefixed("+", commands)
+}
+
+//DenyCommands deny the user to call the commands, see AllowCommands()
+func (a *ACLRules) DenyCommands(commands ...string) *ACLRules {
+	return a.prefixed("-", commands)
+}
+
+//AllowCategories allow the user to call the commands of the categories, like read, write, dangerous
+func (a *ACLRules) AllowCategories(categories ...string) *ACLRules {
+	return a.prefixed("+@", categories)
+}
+
+//DenyCategories deny the user to call the commands of the categories, see AllowCategories()
+func (a *ACLRules) DenyCategories(categories ...string) *ACLRules {
+	return a.prefixed("-@", categories)
+}
+
+//AllCommands allow the user to call all the commands
+func (a *ACLRules) AllCommands() *ACLRules {
+	return a.Rule("allcommands")
+}
+
+//NoCommands deny the user to call any command
+func (a *ACLRules) NoCommands() *ACLRules {
+	return a.Rule("nocommands")
+}
+
+//Selector add a selector of the rules, the command is allowed if the root rules or any selector allows it (redis 7.0+)
+func (a *ACLRules) Selector(rules *ACLRules) *ACLRules {
+	return a.Rule("(" + strings.Join(rules.rules, " ") + ")")
+}
+
+//ClearSelectors remove all the selectors of the user (redis 7.0+)
+func (a *ACLRules) ClearSelectors() *ACLRules {
+	return a.Rule("clearselectors")
+}
+
+func (a *ACLRules) prefixed(prefix string, values []string) *ACLRules {
+	for _, value := range values {
+		a.rules = append(a.rules, prefix+value)
+	}
+	return a
+}
+
+//getParams SETUSER and the username followed by the rules
+func (a *ACLRules) getParams(username string) [][]byte {
+	params := []string{keywordSetUser.name, username}
+	if a != nil {
+		params = append(params, a.rules...)
+	}
+	return StrArrToByteArrArr(params)
+}
+
+//ACLSelector a selector of an ACL user
+type ACLSelector struct {
+	Commands string
+	Keys     string
+	Channels string
+}
+
+//ACLUser the reply of ACL GETUSER, the keys and the channels are the patterns joined by spaces
+type ACLUser struct {
+	Flags     []string
+	Passwords []string //the SHA-256 hex digests of the passwords
+	Commands  string
+	Keys      string
+	Channels  string
+	Selectors []*ACLSelector
+}
+
+//ACLLogEntry an entry of ACL LOG
+type ACLLogEntry struct {
+	Count      int64
+	Reason     string //command, key, channel or auth
+	Context    string //toplevel, multi, lua or module
+	Object     string
+	Username   string
+	AgeSeconds float64
+	ClientInfo string
+	//EntryID, TimestampCreated and TimestampLastUpdated are set since redis 7.2, the timestamps are in milliseconds
+	EntryID              int64
+	TimestampCreated     int64
+	TimestampLastUpdated int64
+}
+
+//aclPatterns the patterns of keys or channels, redis 6.x replies an array and redis 7.0+ replies a string
+func aclPatterns(reply interface{}) string {
+	arr, ok := reply.([]interface{})
+	if !ok {
+		return replyToString(reply)
+	}
+	patterns := make([]string, 0, len(arr))
+	for _, item := range arr {
+		patterns = append(patterns, replyToString(item))
+	}
+	return strings.Join(patterns, " ")
+}
+
+//ObjArrToACLUserReply convert object array reply of ACL GETUSER to ACLUser reply, nil means the user doesn't exist
+func ObjArrToACLUserReply(reply []interface{}, err error) (*ACLUser, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) == 0 {
+		return nil, nil
+	}
+	if len(reply)%2 != 0 {
+		return nil, newDataError("malformed ACL GETUSER reply")
+	}
+	user := &ACLUser{Flags: make([]string, 0), Passwords: make([]string, 0), Selectors: make([]*ACLSelector, 0)}
+	for i := 0; i < len(reply); i += 2 {
+		value := reply[i+1]
+		switch replyToString(reply[i]) {
+		case "flags":
+			flags, _ := value.([]interface{})
+			for _, flag := range flags {
+				user.Flags = append(user.Flags, replyToString(flag))
+			}
+		case "passwords":
+			passwords, _ := value.([]interface{})
+			for _, password := range passwords {
+				user.Passwords = append(user.Passwords, replyToString(password))
+			}
+		case "commands":
+			user.Commands = replyToString(value)
+		case "keys":
+			user.Keys = aclPatterns(value)
+		case "channels":
+			user.Channels = aclPatterns(value)
+		case "selectors":
+			selectors, _ := value.([]interface{})
+			for _, item := range selectors {
+				fields, _ := item.([]interface{})
+				selector := &ACLSelector{}
+				for j := 0; j+1 < len(fields); j += 2 {
+					switch replyToString(fields[j]) {
+					case "commands":
+						selector.Commands = replyToString(fields[j+1])
+					case "keys":
+						selector.Keys = aclPatterns(fields[j+1])
+					case "channels":
+						selector.Channels = aclPatterns(fields[j+1])
+					}
+				}
+				user.Selectors = append(user.Selectors, selector)
+			}
+		}
+	}
+	return user, nil
+}
+
+//ObjArrToACLLogEntryArrReply convert object array reply of ACL LOG to ACLLogEntry array reply
+func ObjArrToACLLogEntryArrReply(reply []interface{}, err error) ([]*ACLLogEntry, error) {
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*ACLLogEntry, 0, len(reply))
+	for _, item := range reply {
+		fields, ok := item.([]interface{})
+		if !ok || len(fields)%2 != 0 {
+			return nil, newDataError(fmt.Sprintf("malformed ACL LOG entry: %v", item))
+		}
+		entry := &ACLLogEntry{}
+		for i := 0; i < len(fields); i += 2 {
+			value := fields[i+1]
+			switch replyToString(fields[i]) {
+			case "count":
+				entry.Count, _ = value.(int64)
+			case "reason":
+				entry.Reason = replyToString(value)
+			case "context":
+				entry.Context = replyToString(value)
+			case "object":
+				entry.Object = replyToString(value)
+			case "username":
+				entry.Username = replyToString(value)
+			case "age-seconds":
+				entry.AgeSeconds, _ = strconv.ParseFloat(replyToString(value), 64)
+			case "client-info":
+				entry.ClientInfo = replyToString(value)
+			case "entry-id":
+				entry.EntryID, _ = value.(int64)
+			case "timestamp-created":
+				entry.TimestampCreated, _ = value.(int64)
+			case "timestamp-last-updated":
+				entry.TimestampLastUpdated, _ = value.(int64)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
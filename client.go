@@ -2,6 +2,7 @@ package godis
 
 import (
 	"strconv"
+	"time"
 )
 
 //Client send command to redis, and receive data from redis
@@ -11,6 +12,7 @@ type client struct {
 	Db        int
 	isInMulti bool
 	isInWatch bool
+	readOnly  bool
 }
 
 //NewClient
@@ -24,6 +26,7 @@ func newClient(option *Option) *client {
 		Db:        db,
 		isInMulti: false,
 		isInWatch: false,
+		readOnly:  option.readOnly,
 	}
 	client.connection = newConnection(option.Host, option.Port, option.ConnectionTimeout, option.SoTimeout)
 	return client
@@ -68,6 +71,16 @@ func (c *client) connect() error {
 			return err
 		}
 	}
+	if c.readOnly {
+		err = c.readonly()
+		if err != nil {
+			return err
+		}
+		_, err = c.getStatusCodeReply()
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -382,6 +395,9 @@ func (c *client) sRandMember(key string) error {
 
 func (c *client) zAdd(key string, score float64, member string, params ...*ZAddParams) error {
 	newArr := make([][]byte, 0)
+	if len(params) > 0 && params[0].Contains("INCR") {
+		return newDataError("INCR of ZAddParams is only accepted by ZIncrBy")
+	}
 	if len(params) == 0 {
 		return c.sendCommand(cmdZAdd, []byte(key), Float64ToByteArr(score), []byte(member))
 	}
@@ -392,6 +408,9 @@ func (c *client) zAdd(key string, score float64, member string, params ...*ZAddP
 
 func (c *client) ZAddByMap(key string, scoreMembers map[string]float64, params ...*ZAddParams) error {
 	newArr := make([][]byte, 0)
+	if len(params) > 0 && params[0].Contains("INCR") {
+		return newDataError("INCR of ZAddParams is only accepted by ZIncrBy")
+	}
 	if len(params) == 0 {
 		newArr = append(newArr, []byte(key))
 		for k, v := range scoreMembers {
@@ -415,8 +434,17 @@ func (c *client) zRem(key string, members ...string) error {
 	return c.sendCommand(cmdZRem, StrStrArrToByteArrArr(key, members)...)
 }
 
-func (c *client) zIncrBy(key string, score float64, member string) error {
-	return c.sendCommand(cmdZIncrBy, []byte(key), Float64ToByteArr(score), []byte(member))
+func (c *client) zIncrBy(key string, score float64, member string, params ...*ZAddParams) error {
+	if len(params) == 0 {
+		return c.sendCommand(cmdZIncrBy, []byte(key), Float64ToByteArr(score), []byte(member))
+	}
+	//ZINCRBY takes no options, ZADD with INCR does the same with the options
+	arr := params[0].getByteParams([]byte(key))
+	if !params[0].Contains("INCR") {
+		arr = append(arr, []byte("INCR"))
+	}
+	arr = append(arr, Float64ToByteArr(score), []byte(member))
+	return c.sendCommand(cmdZAdd, arr...)
 }
 
 func (c *client) zRank(key, member string) error {
@@ -448,7 +476,12 @@ func (c *client) zScore(key, member string) error {
 }
 
 func (c *client) watch(keys ...string) error {
-	return c.sendCommand(cmdWatch, StrArrToByteArrArr(keys)...)
+	err := c.sendCommand(cmdWatch, StrArrToByteArrArr(keys)...)
+	if err != nil {
+		return err
+	}
+	c.isInWatch = true
+	return nil
 }
 
 func (c *client) sort(key string, sortingParameters ...*SortParams) error {
@@ -635,6 +668,18 @@ func (c *client) subscribe(channels ...string) error {
 	return c.sendCommand(cmdSubscribe, StrArrToByteArrArr(channels)...)
 }
 
+func (c *client) spublish(channel, message string) error {
+	return c.sendCommand(cmdSPublish, []byte(channel), []byte(message))
+}
+
+func (c *client) ssubscribe(channels ...string) error {
+	return c.sendCommand(cmdSSubscribe, StrArrToByteArrArr(channels)...)
+}
+
+func (c *client) sunsubscribe(channels ...string) error {
+	return c.sendCommand(cmdSUnSubscribe, StrArrToByteArrArr(channels)...)
+}
+
 func (c *client) pubsub(subcommand string, args ...string) error {
 	return c.sendCommand(cmdPubSub, StrStrArrToByteArrArr(subcommand, args)...)
 }
@@ -674,6 +719,10 @@ func (c *client) scriptLoad(script string) error {
 	return c.sendCommand(cmdScript, keywordLoad.getRaw(), []byte(script))
 }
 
+func (c *client) scriptFlush() error {
+	return c.sendCommand(cmdScript, keywordFlush.getRaw())
+}
+
 func (c *client) sentinel(args ...string) error {
 	return c.sendCommand(cmdSentinel, StrArrToByteArrArr(args)...)
 }
@@ -682,8 +731,28 @@ func (c *client) dump(key string) error {
 	return c.sendCommand(cmdDump, []byte(key))
 }
 
-func (c *client) restore(key string, ttl int, serializedValue []byte) error {
-	return c.sendCommand(cmdRestore, []byte(key), IntToByteArr(ttl), serializedValue)
+func (c *client) restore(key string, ttl int64, serializedValue []byte, args *RestoreArgs, absTTL bool) error {
+	return c.sendCommand(cmdRestore, args.getParams(key, ttl, serializedValue, absTTL)...)
+}
+
+func (c *client) migrate(host string, port int, destinationDB int, timeout int, params *MigrateParams, keys ...string) error {
+	if len(keys) == 0 {
+		return newDataError("at least one key is required by MIGRATE")
+	}
+	arr := make([][]byte, 0)
+	arr = append(arr, []byte(host), IntToByteArr(port))
+	if len(keys) == 1 {
+		arr = append(arr, []byte(keys[0]))
+	} else {
+		arr = append(arr, []byte(""))
+	}
+	arr = append(arr, IntToByteArr(destinationDB), IntToByteArr(timeout))
+	arr = append(arr, params.getParams()...)
+	if len(keys) > 1 {
+		arr = append(arr, keywordKeys.getRaw())
+		arr = append(arr, StrArrToByteArrArr(keys)...)
+	}
+	return c.sendCommand(cmdMigrate, arr...)
 }
 
 func (c *client) incrByFloat(key string, increment float64) error {
@@ -714,10 +783,6 @@ func (c *client) time() error {
 	return c.sendCommand(cmdTime)
 }
 
-func (c *client) migrate(host string, port int, key string, destinationDb int, timeout int) error {
-	return c.sendCommand(cmdMigrate, []byte(host), IntToByteArr(port), []byte(key), IntToByteArr(destinationDb), IntToByteArr(timeout))
-}
-
 func (c *client) hincrByFloat(key, field string, increment float64) error {
 	return c.sendCommand(cmdHIncrByFloat, []byte(key), []byte(field), Float64ToByteArr(increment))
 }
@@ -906,7 +971,12 @@ func (c *client) zscan(key, cursor string, params ...*ScanParams) error {
 }
 
 func (c *client) unwatch() error {
-	return c.sendCommand(cmdUnwatch)
+	err := c.sendCommand(cmdUnwatch)
+	if err != nil {
+		return err
+	}
+	c.isInWatch = false
+	return nil
 }
 
 func (c *client) blpopTimout(timeout int, keys ...string) error {
@@ -931,29 +1001,23 @@ func (c *client) pfadd(key string, elements ...string) error {
 	return c.sendCommand(cmdPfAdd, StrStrArrToByteArrArr(key, elements)...)
 }
 
-func (c *client) georadius(key string, longitude, latitude, radius float64, unit *GeoUnit, param ...*GeoRadiusParams) error {
+func (c *client) georadius(cmd protocolCommand, key string, longitude, latitude, radius float64, unit *GeoUnit, store *GeoRadiusStoreArgs, param ...*GeoRadiusParams) error {
 	arr := make([][]byte, 0)
 	arr = append(arr, []byte(key))
 	arr = append(arr, Float64ToByteArr(longitude))
 	arr = append(arr, Float64ToByteArr(latitude))
 	arr = append(arr, Float64ToByteArr(radius))
 	arr = append(arr, unit.getRaw())
-	if len(param) == 0 {
-		return c.sendCommand(cmdGeoRadius, arr...)
-	}
-	return c.sendCommand(cmdGeoRadius, param[0].getParams(arr)...)
+	return c.sendCommand(cmd, geoRadiusParams(arr, store, param)...)
 }
 
-func (c *client) georadiusByMember(key, member string, radius float64, unit *GeoUnit, param ...*GeoRadiusParams) error {
+func (c *client) georadiusByMember(cmd protocolCommand, key, member string, radius float64, unit *GeoUnit, store *GeoRadiusStoreArgs, param ...*GeoRadiusParams) error {
 	arr := make([][]byte, 0)
 	arr = append(arr, []byte(key))
 	arr = append(arr, []byte(member))
 	arr = append(arr, Float64ToByteArr(radius))
 	arr = append(arr, unit.getRaw())
-	if len(param) == 0 {
-		return c.sendCommand(cmdGeoRadiusByMember, arr...)
-	}
-	return c.sendCommand(cmdGeoRadiusByMember, param[0].getParams(arr)...)
+	return c.sendCommand(cmd, geoRadiusParams(arr, store, param)...)
 }
 
 func (c *client) bitfield(key string, arguments ...string) error {
@@ -1183,3 +1247,425 @@ func (c *client) exec() error {
 	c.isInWatch = false
 	return nil
 }
+
+func (c *client) xadd(key string, args *XAddArgs) error {
+	params, err := args.getParams(key)
+	if err != nil {
+		return err
+	}
+	return c.sendCommand(cmdXAdd, params...)
+}
+
+func (c *client) xlen(key string) error {
+	return c.sendCommand(cmdXLen, []byte(key))
+}
+
+func (c *client) xdel(key string, ids ...string) error {
+	return c.sendCommand(cmdXDel, StrStrArrToByteArrArr(key, ids)...)
+}
+
+func (c *client) xtrim(key string, args *XTrimArgs) error {
+	params, err := args.getParams(key)
+	if err != nil {
+		return err
+	}
+	return c.sendCommand(cmdXTrim, params...)
+}
+
+func (c *client) xrange(key, start, end string, count int64) error {
+	arr := [][]byte{[]byte(key), []byte(start), []byte(end)}
+	if count > 0 {
+		arr = append(arr, keywordCount.getRaw(), Int64ToByteArr(count))
+	}
+	return c.sendCommand(cmdXRange, arr...)
+}
+
+func (c *client) xrevrange(key, end, start string, count int64) error {
+	arr := [][]byte{[]byte(key), []byte(end), []byte(start)}
+	if count > 0 {
+		arr = append(arr, keywordCount.getRaw(), Int64ToByteArr(count))
+	}
+	return c.sendCommand(cmdXRevRange, arr...)
+}
+
+func (c *client) xread(args *XReadArgs) error {
+	params, err := args.getParams()
+	if err != nil {
+		return err
+	}
+	return c.sendCommand(cmdXRead, params...)
+}
+
+func (c *client) xinfoStream(key string) error {
+	return c.sendCommand(cmdXInfo, keywordStream.getRaw(), []byte(key))
+}
+
+func (c *client) xgroupCreate(key, group, id string, mkStream bool) error {
+	arr := [][]byte{keywordCreate.getRaw(), []byte(key), []byte(group), []byte(id)}
+	if mkStream {
+		arr = append(arr, keywordMkStream.getRaw())
+	}
+	return c.sendCommand(cmdXGroup, arr...)
+}
+
+func (c *client) xgroupDestroy(key, group string) error {
+	return c.sendCommand(cmdXGroup, keywordDestroy.getRaw(), []byte(key), []byte(group))
+}
+
+func (c *client) xgroupSetID(key, group, id string) error {
+	return c.sendCommand(cmdXGroup, keywordSetID.getRaw(), []byte(key), []byte(group), []byte(id))
+}
+
+func (c *client) xgroupCreateConsumer(key, group, consumer string) error {
+	return c.sendCommand(cmdXGroup, keywordCreateConsumer.getRaw(), []byte(key), []byte(group), []byte(consumer))
+}
+
+func (c *client) xgroupDelConsumer(key, group, consumer string) error {
+	return c.sendCommand(cmdXGroup, keywordDelConsumer.getRaw(), []byte(key), []byte(group), []byte(consumer))
+}
+
+func (c *client) xreadGroup(args *XReadGroupArgs) error {
+	params, err := args.getParams()
+	if err != nil {
+		return err
+	}
+	return c.sendCommand(cmdXReadGroup, params...)
+}
+
+func (c *client) xack(key, group string, ids ...string) error {
+	arr := [][]byte{[]byte(key), []byte(group)}
+	arr = append(arr, StrArrToByteArrArr(ids)...)
+	return c.sendCommand(cmdXAck, arr...)
+}
+
+func (c *client) xpending(key, group string) error {
+	return c.sendCommand(cmdXPending, []byte(key), []byte(group))
+}
+
+func (c *client) xpendingRange(key, group string, args *XPendingArgs) error {
+	return c.sendCommand(cmdXPending, args.getParams(key, group)...)
+}
+
+func (c *client) xclaim(key string, args *XClaimArgs) error {
+	params, err := args.getParams(key)
+	if err != nil {
+		return err
+	}
+	return c.sendCommand(cmdXClaim, params...)
+}
+
+func (c *client) xautoclaim(key string, args *XAutoClaimArgs) error {
+	params, err := args.getParams(key)
+	if err != nil {
+		return err
+	}
+	return c.sendCommand(cmdXAutoClaim, params...)
+}
+
+func (c *client) zpop(cmd protocolCommand, key string, count int64) error {
+	if count > 0 {
+		return c.sendCommand(cmd, []byte(key), Int64ToByteArr(count))
+	}
+	return c.sendCommand(cmd, []byte(key))
+}
+
+func (c *client) bzpop(cmd protocolCommand, timeout time.Duration, keys ...string) error {
+	arr := StrArrToByteArrArr(keys)
+	arr = append(arr, Float64ToByteArr(timeout.Seconds()))
+	return c.sendCommand(cmd, arr...)
+}
+
+func (c *client) zmpop(option *SortedSetOption, count int64, keys ...string) error {
+	return c.sendCommand(cmdZMPop, zmpopParams(option, count, keys)...)
+}
+
+func (c *client) bzmpop(timeout time.Duration, option *SortedSetOption, count int64, keys ...string) error {
+	arr := [][]byte{Float64ToByteArr(timeout.Seconds())}
+	arr = append(arr, zmpopParams(option, count, keys)...)
+	return c.sendCommand(cmdBZMPop, arr...)
+}
+
+func zmpopParams(option *SortedSetOption, count int64, keys []string) [][]byte {
+	arr := append(numKeysParams(keys), option.getRaw())
+	if count > 0 {
+		arr = append(arr, keywordCount.getRaw(), Int64ToByteArr(count))
+	}
+	return arr
+}
+
+func (c *client) zrandmember(key string, count int64, withScores bool) error {
+	if withScores {
+		return c.sendCommand(cmdZRandMember, []byte(key), Int64ToByteArr(count), keywordWithScores.getRaw())
+	}
+	return c.sendCommand(cmdZRandMember, []byte(key), Int64ToByteArr(count))
+}
+
+func (c *client) zmscore(key string, members ...string) error {
+	return c.sendCommand(cmdZMScore, StrStrArrToByteArrArr(key, members)...)
+}
+
+func (c *client) zrangeByParams(key string, params *ZRangeParams, withScores bool) error {
+	if params == nil {
+		return newDataError("range params are required by ZRANGE")
+	}
+	arr := params.getParams(key)
+	if withScores {
+		arr = append(arr, keywordWithScores.getRaw())
+	}
+	return c.sendCommand(cmdZRange, arr...)
+}
+
+func (c *client) zrangestore(destKey, srcKey string, params *ZRangeParams) error {
+	if params == nil {
+		return newDataError("range params are required by ZRANGESTORE")
+	}
+	return c.sendCommand(cmdZRangeStore, params.getParams(destKey, srcKey)...)
+}
+
+func (c *client) zdiff(withScores bool, keys ...string) error {
+	arr := numKeysParams(keys)
+	if withScores {
+		arr = append(arr, keywordWithScores.getRaw())
+	}
+	return c.sendCommand(cmdZDiff, arr...)
+}
+
+func (c *client) zdiffstore(destKey string, keys ...string) error {
+	arr := [][]byte{[]byte(destKey)}
+	arr = append(arr, numKeysParams(keys)...)
+	return c.sendCommand(cmdZDiffStore, arr...)
+}
+
+//zsetOperation ZINTER or ZUNION with the optional weights and aggregate
+func (c *client) zsetOperation(cmd protocolCommand, params *ZParams, withScores bool, keys ...string) error {
+	arr := numKeysParams(keys)
+	if params != nil {
+		arr = append(arr, params.getParams()...)
+	}
+	if withScores {
+		arr = append(arr, keywordWithScores.getRaw())
+	}
+	return c.sendCommand(cmd, arr...)
+}
+
+func (c *client) setWithArgs(key, value string, args *SetArgs) error {
+	return c.sendCommand(cmdSet, args.getParams(key, value)...)
+}
+
+func (c *client) getdel(key string) error {
+	return c.sendCommand(cmdGetDel, []byte(key))
+}
+
+func (c *client) getex(key string, args *GetExArgs) error {
+	return c.sendCommand(cmdGetEx, args.getParams(key)...)
+}
+
+func (c *client) lcs(key1, key2 string) error {
+	return c.sendCommand(cmdLcs, []byte(key1), []byte(key2))
+}
+
+func (c *client) lcsLen(key1, key2 string) error {
+	return c.sendCommand(cmdLcs, []byte(key1), []byte(key2), keywordLen.getRaw())
+}
+
+func (c *client) lcsIdx(key1, key2 string, args *LCSIdxArgs) error {
+	return c.sendCommand(cmdLcs, args.getParams(key1, key2)...)
+}
+
+func (c *client) lpos(key, element string, count int64, args *LPosArgs) error {
+	return c.sendCommand(cmdLPos, args.getParams(key, element, count)...)
+}
+
+func (c *client) lmove(srcKey, destKey string, from, to *ListDirection) error {
+	return c.sendCommand(cmdLMove, []byte(srcKey), []byte(destKey), from.getRaw(), to.getRaw())
+}
+
+func (c *client) blmove(srcKey, destKey string, from, to *ListDirection, timeout time.Duration) error {
+	return c.sendCommand(cmdBLMove, []byte(srcKey), []byte(destKey), from.getRaw(), to.getRaw(),
+		Float64ToByteArr(timeout.Seconds()))
+}
+
+func (c *client) lmpop(direction *ListDirection, count int64, keys ...string) error {
+	return c.sendCommand(cmdLMPop, lmpopParams(direction, count, keys)...)
+}
+
+func (c *client) blmpop(timeout time.Duration, direction *ListDirection, count int64, keys ...string) error {
+	arr := [][]byte{Float64ToByteArr(timeout.Seconds())}
+	arr = append(arr, lmpopParams(direction, count, keys)...)
+	return c.sendCommand(cmdBLMPop, arr...)
+}
+
+func (c *client) popCount(cmd protocolCommand, key string, count int64) error {
+	return c.sendCommand(cmd, []byte(key), Int64ToByteArr(count))
+}
+
+func (c *client) hsetByMap(key string, hash map[string]string) error {
+	return c.sendCommand(cmdHSet, StrStrArrToByteArrArr(key, hashFieldValueParams(hash))...)
+}
+
+func (c *client) hstrlen(key, field string) error {
+	return c.sendCommand(cmdHStrLen, []byte(key), []byte(field))
+}
+
+func (c *client) hrandfield(key string, count int64, withValues bool) error {
+	arr := [][]byte{[]byte(key), Int64ToByteArr(count)}
+	if withValues {
+		arr = append(arr, keywordWithValues.getRaw())
+	}
+	return c.sendCommand(cmdHRandField, arr...)
+}
+
+func (c *client) hexpire(cmd protocolCommand, key string, expiration int64, condition *ExpireCondition, fields ...string) error {
+	return c.sendCommand(cmd, hashExpireParams(key, expiration, condition, fields)...)
+}
+
+func (c *client) hfields(cmd protocolCommand, key string, fields ...string) error {
+	arr := [][]byte{[]byte(key)}
+	arr = append(arr, hashFieldsParams(fields)...)
+	return c.sendCommand(cmd, arr...)
+}
+
+func (c *client) hgetex(key string, args *GetExArgs, fields ...string) error {
+	arr := args.getParams(key)
+	arr = append(arr, hashFieldsParams(fields)...)
+	return c.sendCommand(cmdHGetEx, arr...)
+}
+
+func (c *client) hsetex(key string, args *HSetExArgs, hash map[string]string) error {
+	return c.sendCommand(cmdHSetEx, args.getParams(key, hash)...)
+}
+
+func (c *client) geosearch(key string, query *GeoSearchQuery) error {
+	arr, err := query.getParams(true, key)
+	if err != nil {
+		return err
+	}
+	return c.sendCommand(cmdGeoSearch, arr...)
+}
+
+func (c *client) geosearchstore(destKey, srcKey string, query *GeoSearchQuery, storeDist bool) error {
+	arr, err := query.getParams(false, destKey, srcKey)
+	if err != nil {
+		return err
+	}
+	if storeDist {
+		arr = append(arr, keywordStoreDist.getRaw())
+	}
+	return c.sendCommand(cmdGeoSearchStore, arr...)
+}
+
+func (c *client) copy(srcKey, destKey string, args *CopyArgs) error {
+	return c.sendCommand(cmdCopy, args.getParams(srcKey, destKey)...)
+}
+
+func (c *client) touch(keys ...string) error {
+	return c.sendCommand(cmdTouch, StrArrToByteArrArr(keys)...)
+}
+
+func (c *client) unlink(keys ...string) error {
+	return c.sendCommand(cmdUnlink, StrArrToByteArrArr(keys)...)
+}
+
+func (c *client) expireTime(cmd protocolCommand, key string) error {
+	return c.sendCommand(cmd, []byte(key))
+}
+
+func (c *client) expireWithCondition(cmd protocolCommand, key string, expiration int64, condition *ExpireCondition) error {
+	return c.sendCommand(cmd, expireParams(key, expiration, condition)...)
+}
+
+func (c *client) functionLoad(code string, replace bool) error {
+	if replace {
+		return c.sendCommand(cmdFunction, keywordLoad.getRaw(), keywordReplace.getRaw(), []byte(code))
+	}
+	return c.sendCommand(cmdFunction, keywordLoad.getRaw(), []byte(code))
+}
+
+func (c *client) functionDelete(libraryName string) error {
+	return c.sendCommand(cmdFunction, keywordDelete.getRaw(), []byte(libraryName))
+}
+
+func (c *client) functionFlush(mode *FlushMode) error {
+	if mode == nil {
+		return c.sendCommand(cmdFunction, keywordFlush.getRaw())
+	}
+	return c.sendCommand(cmdFunction, keywordFlush.getRaw(), mode.getRaw())
+}
+
+func (c *client) functionList(libraryNamePattern string, withCode bool) error {
+	arr := [][]byte{keywordList.getRaw()}
+	if libraryNamePattern != "" {
+		arr = append(arr, keywordLibraryName.getRaw(), []byte(libraryNamePattern))
+	}
+	if withCode {
+		arr = append(arr, keywordWithCode.getRaw())
+	}
+	return c.sendCommand(cmdFunction, arr...)
+}
+
+func (c *client) functionDump() error {
+	return c.sendCommand(cmdFunction, keywordDump.getRaw())
+}
+
+func (c *client) functionRestore(serializedValue []byte, policy *FunctionRestorePolicy) error {
+	if policy == nil {
+		return c.sendCommand(cmdFunction, keywordRestore.getRaw(), serializedValue)
+	}
+	return c.sendCommand(cmdFunction, keywordRestore.getRaw(), serializedValue, policy.getRaw())
+}
+
+func (c *client) functionKill() error {
+	return c.sendCommand(cmdFunction, keywordKill.getRaw())
+}
+
+func (c *client) functionStats() error {
+	return c.sendCommand(cmdFunction, keywordStats.getRaw())
+}
+
+func (c *client) fcall(cmd protocolCommand, function string, keys, args []string) error {
+	return c.sendCommand(cmd, fcallParams(function, keys, args)...)
+}
+
+func (c *client) aclSetUser(username string, rules *ACLRules) error {
+	return c.sendCommand(cmdACL, rules.getParams(username)...)
+}
+
+func (c *client) aclGetUser(username string) error {
+	return c.sendCommand(cmdACL, keywordGetUser.getRaw(), []byte(username))
+}
+
+func (c *client) aclDelUser(usernames ...string) error {
+	return c.sendCommand(cmdACL, StrStrArrToByteArrArr(keywordDelUser.name, usernames)...)
+}
+
+func (c *client) aclCat(category string) error {
+	if category == "" {
+		return c.sendCommand(cmdACL, keywordCat.getRaw())
+	}
+	return c.sendCommand(cmdACL, keywordCat.getRaw(), []byte(category))
+}
+
+func (c *client) aclLog(count int) error {
+	if count <= 0 {
+		return c.sendCommand(cmdACL, keywordLog.getRaw())
+	}
+	return c.sendCommand(cmdACL, keywordLog.getRaw(), IntToByteArr(count))
+}
+
+func (c *client) aclGenPass(bits int) error {
+	if bits <= 0 {
+		return c.sendCommand(cmdACL, keywordGenPass.getRaw())
+	}
+	return c.sendCommand(cmdACL, keywordGenPass.getRaw(), IntToByteArr(bits))
+}
+
+func (c *client) aclDryRun(username, command string, args ...string) error {
+	arr := [][]byte{keywordDryRun.getRaw(), []byte(username), []byte(command)}
+	arr = append(arr, StrArrToByteArrArr(args)...)
+	return c.sendCommand(cmdACL, arr...)
+}
+
+func (c *client) acl(subcommand *keyword, args ...[]byte) error {
+	arr := [][]byte{subcommand.getRaw()}
+	return c.sendCommand(cmdACL, append(arr, args...)...)
+}
@@ -6,35 +6,70 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const (
-	masterNodeIndex = 2
+	masterNodeIndex        = 2
+	clusterSlotCount       = 16384
+	clusterRetryBackoff    = 100 * time.Millisecond
+	clusterMaxRetryBackoff = 2 * time.Second
 )
 
+//clusterSlotTable the pool of the master of every slot, nil means the slot is not assigned,
+// and the read only pools of the replicas of every master.
+// a published table is never modified, the writers copy it and swap the new table atomically
+type clusterSlotTable struct {
+	masters  [clusterSlotCount]*Pool
+	replicas map[*Pool][]*Pool //master pool -> read only pools of its replicas
+}
+
 type redisClusterInfoCache struct {
-	nodes sync.Map
-	slots sync.Map
+	nodes        sync.Map
+	replicaNodes sync.Map     //replica host:port -> read only pool
+	slots        atomic.Value //*clusterSlotTable
 
 	rwLock        sync.RWMutex
 	rLock         sync.Mutex
 	wLock         sync.Mutex
 	rediscovering bool
 	poolConfig    *PoolConfig
+	replicas      map[string]string
+
+	listenerLock sync.Mutex
+	listeners    []*clusterListener
 
 	connectionTimeout time.Duration
 	soTimeout         time.Duration
 	password          string
+	addressMapper     AddressMapper
 }
 
 func newRedisClusterInfoCache(connectionTimeout, soTimeout time.Duration, password string, poolConfig *PoolConfig) *redisClusterInfoCache {
-	return &redisClusterInfoCache{
+	cache := &redisClusterInfoCache{
 		poolConfig:        poolConfig,
+		replicas:          make(map[string]string),
 		connectionTimeout: connectionTimeout,
 		soTimeout:         soTimeout,
 		password:          password,
 	}
+	cache.storeSlots(&clusterSlotTable{})
+	return cache
+}
+
+func (r *redisClusterInfoCache) loadSlots() *clusterSlotTable {
+	return r.slots.Load().(*clusterSlotTable)
+}
+
+func (r *redisClusterInfoCache) storeSlots(table *clusterSlotTable) {
+	r.slots.Store(table)
+}
+
+//copySlots copy the current table for modification, the copy is published by storeSlots
+func (r *redisClusterInfoCache) copySlots() *clusterSlotTable {
+	table := *r.loadSlots()
+	return &table
 }
 
 func (r *redisClusterInfoCache) discoverClusterNodesAndSlots(redis *Redis) error {
@@ -45,37 +80,28 @@ func (r *redisClusterInfoCache) discoverClusterNodesAndSlots(redis *Redis) error
 	if err != nil {
 		return err
 	}
-	for _, s := range slots {
-		slotInfo := s.([]interface{})
-		size := len(slotInfo)
-		if size <= masterNodeIndex {
-			continue
-		}
-		slotNums := r.getAssignedSlotArray(slotInfo)
-		for i := masterNodeIndex; i < size; i++ {
-			hostInfos := slotInfo[i].([]interface{})
-			if len(hostInfos) <= 0 {
-				continue
-			}
-			host, port := r.generateHostAndPort(hostInfos)
-			r.setupNodeIfNotExist(false, host, port)
-			if i == masterNodeIndex {
-				r.assignSlotsToNode(false, slotNums, host, port)
-			}
-		}
+	view, err := parseClusterView(slots, r.addressMapper)
+	if err != nil {
+		return err
 	}
+	r.applyClusterView(view, false)
 	return nil
 }
 
 func (r *redisClusterInfoCache) renewClusterSlots(redis *Redis) error {
 	r.wLock.Lock()
 	if r.rediscovering {
+		r.wLock.Unlock()
 		return nil
 	}
-	defer func() {
-		r.rediscovering = false
-		r.wLock.Unlock()
-	}()
+	events, err := r.renewClusterSlotsFromNodes(redis)
+	r.rediscovering = false
+	r.wLock.Unlock()
+	r.emit(events)
+	return err
+}
+
+func (r *redisClusterInfoCache) renewClusterSlotsFromNodes(redis *Redis) ([]*ClusterEvent, error) {
 	if redis != nil {
 		return r.discoverClusterSlots(redis)
 	}
@@ -84,40 +110,26 @@ func (r *redisClusterInfoCache) renewClusterSlots(redis *Redis) error {
 		if err != nil {
 			continue
 		}
-		err = r.discoverClusterSlots(newRedis)
+		events, err := r.discoverClusterSlots(newRedis)
 		if err != nil {
+			_ = newRedis.Close()
 			continue
 		}
-		err = newRedis.Close()
-		return err
+		return events, newRedis.Close()
 	}
-	return nil
+	return nil, nil
 }
 
-func (r *redisClusterInfoCache) discoverClusterSlots(redis *Redis) error {
+func (r *redisClusterInfoCache) discoverClusterSlots(redis *Redis) ([]*ClusterEvent, error) {
 	slots, err := redis.ClusterSlots()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	r.slots.Range(func(key, value interface{}) bool {
-		r.slots.Delete(key)
-		return true
-	})
-	for _, s := range slots {
-		slotInfo := s.([]interface{})
-		size := len(slotInfo)
-		if size <= masterNodeIndex {
-			continue
-		}
-		slotNums := r.getAssignedSlotArray(slotInfo)
-		hostInfos := slotInfo[masterNodeIndex].([]interface{})
-		if len(hostInfos) == 0 {
-			continue
-		}
-		host, port := r.generateHostAndPort(hostInfos)
-		r.assignSlotsToNode(true, slotNums, host, port)
+	view, err := parseClusterView(slots, r.addressMapper)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return r.applyClusterView(view, false), nil
 }
 
 func (r *redisClusterInfoCache) reset(lock bool) {
@@ -131,22 +143,12 @@ func (r *redisClusterInfoCache) reset(lock bool) {
 		r.nodes.Delete(key)
 		return true
 	})
-	r.slots.Range(func(key, value interface{}) bool {
-		r.slots.Delete(key)
+	r.replicaNodes.Range(func(key, value interface{}) bool {
+		value.(*Pool).Destroy()
+		r.replicaNodes.Delete(key)
 		return true
 	})
-}
-
-func (r *redisClusterInfoCache) getAssignedSlotArray(slotInfo []interface{}) []int {
-	slotNums := make([]int, 0)
-	for slot := slotInfo[0].(int64); slot <= slotInfo[1].(int64); slot++ {
-		slotNums = append(slotNums, int(slot))
-	}
-	return slotNums
-}
-
-func (r *redisClusterInfoCache) generateHostAndPort(hostInfos []interface{}) (string, int) {
-	return string(hostInfos[0].([]byte)), int(hostInfos[1].(int64))
+	r.storeSlots(&clusterSlotTable{})
 }
 
 func (r *redisClusterInfoCache) setupNodeIfNotExist(lock bool, host string, port int) *Pool {
@@ -166,16 +168,35 @@ func (r *redisClusterInfoCache) setupNodeIfNotExist(lock bool, host string, port
 	return nodePool
 }
 
+//setupReplicaIfNotExist get the read only pool of the replica, the connections of which are in READONLY mode
+func (r *redisClusterInfoCache) setupReplicaIfNotExist(host string, port int) *Pool {
+	nodeKey := host + ":" + strconv.Itoa(port)
+	if existingPool, ok := r.replicaNodes.Load(nodeKey); ok {
+		return existingPool.(*Pool)
+	}
+	replicaPool := NewPool(r.poolConfig, &Option{
+		Host:              host,
+		Port:              port,
+		ConnectionTimeout: r.connectionTimeout,
+		SoTimeout:         r.soTimeout,
+		Password:          r.password,
+		readOnly:          true,
+	})
+	r.replicaNodes.Store(nodeKey, replicaPool)
+	return replicaPool
+}
+
 func (r *redisClusterInfoCache) assignSlotToNode(slot int, host string, port int) {
-	targetPool := r.setupNodeIfNotExist(false, host, port)
-	r.slots.Store(slot, targetPool)
+	r.assignSlotsToNode(false, []int{slot}, host, port)
 }
 
 func (r *redisClusterInfoCache) assignSlotsToNode(lock bool, slots []int, host string, port int) {
 	targetPool := r.setupNodeIfNotExist(false, host, port)
+	table := r.copySlots()
 	for _, slot := range slots {
-		r.slots.Store(slot, targetPool)
+		table.masters[slot] = targetPool
 	}
+	r.storeSlots(table)
 }
 
 func (r *redisClusterInfoCache) getShuffledNodesPool() []*Pool {
@@ -218,19 +239,80 @@ func (r *redisClusterInfoCache) getNodes() map[string]*Pool {
 	return ret
 }
 
+func (r *redisClusterInfoCache) getMasterNodes() map[string]*Pool {
+	masters := make(map[*Pool]bool)
+	for _, pool := range r.loadSlots().masters {
+		if pool != nil {
+			masters[pool] = true
+		}
+	}
+	ret := make(map[string]*Pool)
+	r.nodes.Range(func(key, value interface{}) bool {
+		if value != nil && masters[value.(*Pool)] {
+			ret[key.(string)] = value.(*Pool)
+		}
+		return true
+	})
+	return ret
+}
+
+// getSlotOwners return host:port of the master of every slot, empty string means the slot is not assigned
+func (r *redisClusterInfoCache) getSlotOwners() []string {
+	nodeKeys := make(map[*Pool]string)
+	r.nodes.Range(func(key, value interface{}) bool {
+		if value != nil {
+			nodeKeys[value.(*Pool)] = key.(string)
+		}
+		return true
+	})
+	owners := make([]string, clusterSlotCount)
+	for slot, pool := range r.loadSlots().masters {
+		if pool != nil {
+			owners[slot] = nodeKeys[pool]
+		}
+	}
+	return owners
+}
+
+//getReplicas return replica host:port -> master host:port
+func (r *redisClusterInfoCache) getReplicas() map[string]string {
+	r.rwLock.RLock()
+	defer r.rwLock.RUnlock()
+	ret := make(map[string]string, len(r.replicas))
+	for replica, master := range r.replicas {
+		ret[replica] = master
+	}
+	return ret
+}
+
+//getSlotReplicaPools return the read only pools of the replicas of the master serving the slot,
+// the returned slice is shared by the slot table and must not be modified
+func (r *redisClusterInfoCache) getSlotReplicaPools(slot int) []*Pool {
+	if slot < 0 || slot >= clusterSlotCount {
+		return nil
+	}
+	table := r.loadSlots()
+	if table.masters[slot] == nil {
+		return nil
+	}
+	return table.replicas[table.masters[slot]]
+}
+
 func (r *redisClusterInfoCache) getSlotPool(slot int) *Pool {
-	if value, ok := r.slots.Load(slot); ok {
-		return value.(*Pool)
+	if slot < 0 || slot >= clusterSlotCount {
+		return nil
 	}
-	return nil
+	return r.loadSlots().masters[slot]
 }
 
 type redisClusterConnectionHandler struct {
-	cache *redisClusterInfoCache
+	cache     *redisClusterInfoCache
+	refresher *clusterRefresher
 }
 
-func newRedisClusterConnectionHandler(nodes []string, connectionTimeout, soTimeout time.Duration, password string, poolConfig *PoolConfig) *redisClusterConnectionHandler {
+func newRedisClusterConnectionHandler(nodes []string, connectionTimeout, soTimeout time.Duration, password string, poolConfig *PoolConfig, addressMapper AddressMapper) *redisClusterConnectionHandler {
 	cache := newRedisClusterInfoCache(connectionTimeout, soTimeout, password, poolConfig)
+	cache.addressMapper = addressMapper
 	for _, node := range nodes {
 		arr := strings.Split(node, ":")
 		port, err := strconv.Atoi(arr[1])
@@ -289,7 +371,21 @@ func (r *redisClusterConnectionHandler) getConnectionFromSlot(slot int) (*Redis,
 	return r.getConnection()
 }
 
+//getReplicaConnectionFromSlot get connection of a random replica of the master serving the slot,
+// nil if the master has no replica
+func (r *redisClusterConnectionHandler) getReplicaConnectionFromSlot(slot int) (*Redis, error) {
+	pools := r.cache.getSlotReplicaPools(slot)
+	if len(pools) == 0 {
+		return nil, nil
+	}
+	return pools[rand.Intn(len(pools))].GetResource()
+}
+
+//getConnectionFromNode get connection of the node reported by the cluster, like the target of MOVED and ASK
 func (r *redisClusterConnectionHandler) getConnectionFromNode(host string, port int) (*Redis, error) {
+	if r.cache.addressMapper != nil {
+		host, port = r.cache.addressMapper(host, port)
+	}
 	return r.cache.setupNodeIfNotExist(true, host, port).GetResource()
 }
 
@@ -297,6 +393,23 @@ func (r *redisClusterConnectionHandler) getNodes() map[string]*Pool {
 	return r.cache.getNodes()
 }
 
+func (r *redisClusterConnectionHandler) getNode(nodeKey string) *Pool {
+	return r.cache.getNode(nodeKey)
+}
+
+func (r *redisClusterConnectionHandler) getSlotOwners() []string {
+	return r.cache.getSlotOwners()
+}
+
+func (r *redisClusterConnectionHandler) getMasterNodes() map[string]*Pool {
+	masters := r.cache.getMasterNodes()
+	if len(masters) > 0 {
+		return masters
+	}
+	r.renewSlotCache()
+	return r.cache.getMasterNodes()
+}
+
 func (r *redisClusterConnectionHandler) renewSlotCache(redis ...*Redis) {
 	if len(redis) == 0 {
 		_ = r.cache.renewClusterSlots(nil)
@@ -307,6 +420,19 @@ func (r *redisClusterConnectionHandler) renewSlotCache(redis ...*Redis) {
 	}
 }
 
+func (r *redisClusterConnectionHandler) startRefresh(interval time.Duration) {
+	r.refresher = newClusterRefresher(r.cache, interval)
+}
+
+func (r *redisClusterConnectionHandler) close() {
+	if r.refresher != nil {
+		r.refresher.close()
+	}
+	r.cache.wLock.Lock()
+	defer r.cache.wLock.Unlock()
+	r.cache.reset(false)
+}
+
 type redisClusterHashTagUtil struct {
 }
 
@@ -339,13 +465,15 @@ func (r *redisClusterHashTagUtil) extractHashTag(key string, returnKeyOnAbsence
 
 type redisClusterCommand struct {
 	maxAttempts       int
+	maxRedirects      int
+	redirections      int
 	connectionHandler *redisClusterConnectionHandler
 
 	execute func(redis *Redis) (interface{}, error)
 }
 
-func newRedisClusterCommand(maxAttempts int, connectionHandler *redisClusterConnectionHandler) *redisClusterCommand {
-	return &redisClusterCommand{maxAttempts: maxAttempts, connectionHandler: connectionHandler}
+func newRedisClusterCommand(maxAttempts, maxRedirects int, connectionHandler *redisClusterConnectionHandler) *redisClusterCommand {
+	return &redisClusterCommand{maxAttempts: maxAttempts, maxRedirects: maxRedirects, connectionHandler: connectionHandler}
 }
 
 func (r *redisClusterCommand) run(key string) (interface{}, error) {
@@ -355,9 +483,44 @@ func (r *redisClusterCommand) run(key string) (interface{}, error) {
 	return r.runWithRetries([]byte(key), r.maxAttempts, false, nil)
 }
 
+// runReadOnly send the read-only command to a replica of the master serving the key, the connections of the replicas are in READONLY mode.
+// it falls back to run when the master has no replica, the replica is unreachable, or the replica replies MOVED or CLUSTERDOWN,
+// the other errors of the command are returned directly
+func (r *redisClusterCommand) runReadOnly(key string) (interface{}, error) {
+	if key == "" {
+		return nil, newClusterOperationError("no way to dispatch this command to Redis cluster")
+	}
+	connection, err := r.connectionHandler.getReplicaConnectionFromSlot(int(newCRC16().getStringSlot(key)))
+	if err != nil || connection == nil {
+		return r.run(key)
+	}
+	result, err := r.execute(connection)
+	_ = r.releaseConnection(connection)
+	switch err.(type) {
+	case *ConnectError, *MovedDataError, *ClusterError:
+		return r.run(key)
+	}
+	return result, err
+}
+
 func (r *redisClusterCommand) runBatch(keyCount int, keys ...string) (interface{}, error) {
+	if err := r.checkSameSlot(keyCount, keys); err != nil {
+		return nil, err
+	}
+	return r.runWithRetries([]byte(keys[0]), r.maxAttempts, false, nil)
+}
+
+// runReadOnlyBatch the batch version of runReadOnly, the first keyCount keys must be in the same slot
+func (r *redisClusterCommand) runReadOnlyBatch(keyCount int, keys ...string) (interface{}, error) {
+	if err := r.checkSameSlot(keyCount, keys); err != nil {
+		return nil, err
+	}
+	return r.runReadOnly(keys[0])
+}
+
+func (r *redisClusterCommand) checkSameSlot(keyCount int, keys []string) error {
 	if len(keys) == 0 {
-		return nil, newClusterOperationError("no way to dispatch this command to Redis cluster")
+		return newClusterOperationError("no way to dispatch this command to Redis cluster")
 	}
 	if len(keys) > 1 {
 		crc16 := newCRC16()
@@ -365,11 +528,11 @@ func (r *redisClusterCommand) runBatch(keyCount int, keys ...string) (interface{
 		for i := 1; i < keyCount; i++ {
 			nextSlot := crc16.getStringSlot(keys[i])
 			if nextSlot != slot {
-				return nil, newClusterOperationError("no way to dispatch this command to Redis cluster,because keys have different slots")
+				return newClusterOperationError("no way to dispatch this command to Redis cluster,because keys have different slots")
 			}
 		}
 	}
-	return r.runWithRetries([]byte(keys[0]), r.maxAttempts, false, nil)
+	return nil
 }
 
 func (r *redisClusterCommand) runWithAnyNode() (interface{}, error) {
@@ -377,12 +540,68 @@ func (r *redisClusterCommand) runWithAnyNode() (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	result, err := r.execute(connection)
+	defer r.releaseConnection(connection)
+	return r.execute(connection)
+}
+
+// runWithNodes send the command to the nodes chosen by mode concurrently,
+// the replies are returned in a map keyed by host:port of the node.
+// when some nodes failed, the replies of the others are still returned along with a *ClusterFanOutError
+func (r *redisClusterCommand) runWithNodes(mode *FanOutMode) (map[string]interface{}, error) {
+	if mode == FanOutAnyNode {
+		connection, err := r.connectionHandler.getConnection()
+		if err != nil {
+			return nil, err
+		}
+		defer r.releaseConnection(connection)
+		nodeKey := connection.client.host() + ":" + strconv.Itoa(connection.client.port())
+		result, err := r.execute(connection)
+		if err != nil {
+			return nil, newClusterFanOutError([]*ClusterNodeError{newClusterNodeError(nodeKey, err)})
+		}
+		return map[string]interface{}{nodeKey: result}, nil
+	}
+	var pools map[string]*Pool
+	if mode == FanOutAllNodes {
+		pools = r.connectionHandler.getNodes()
+	} else {
+		pools = r.connectionHandler.getMasterNodes()
+	}
+	if len(pools) == 0 {
+		return nil, newNoReachableClusterNodeError("no reachable node in cluster")
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	results := make(map[string]interface{}, len(pools))
+	nodeErrors := make([]*ClusterNodeError, 0)
+	for nodeKey, pool := range pools {
+		wg.Add(1)
+		go func(nodeKey string, pool *Pool) {
+			defer wg.Done()
+			result, err := r.runWithPool(pool)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				nodeErrors = append(nodeErrors, newClusterNodeError(nodeKey, err))
+				return
+			}
+			results[nodeKey] = result
+		}(nodeKey, pool)
+	}
+	wg.Wait()
+	if len(nodeErrors) > 0 {
+		return results, newClusterFanOutError(nodeErrors)
+	}
+	return results, nil
+}
+
+func (r *redisClusterCommand) runWithPool(pool *Pool) (interface{}, error) {
+	connection, err := pool.GetResource()
 	if err != nil {
 		return nil, err
 	}
-	_ = r.releaseConnection(connection)
-	return result, nil
+	defer r.releaseConnection(connection)
+	return r.execute(connection)
 }
 
 func (r *redisClusterCommand) releaseConnection(redis *Redis) error {
@@ -405,43 +624,79 @@ func (r *redisClusterCommand) runWithRetries(key []byte, attempts int, tryRandom
 	} else {
 		if tryRandomNode {
 			connection, err = r.connectionHandler.getConnection()
-			if err != nil {
-				return nil, err
-			}
 		} else {
 			connection, err = r.connectionHandler.getConnectionFromSlot(int(newCRC16().getByteSlot(key)))
-			if err != nil {
+		}
+		if err != nil {
+			//the pool may be closed as its node was removed by a refresh, retry with the refreshed slots
+			if _, ok := err.(*ConnectError); !ok {
 				return nil, err
 			}
+			if attempts <= 1 {
+				r.connectionHandler.renewSlotCache()
+			}
+			return r.runWithRetries(key, attempts-1, tryRandomNode, nil)
 		}
 	}
 	result, err := r.execute(connection)
-	defer r.releaseConnection(connection)
 	if err == nil {
+		_ = r.releaseConnection(connection)
 		return result, nil
 	}
 	// 根据各种error，进行重试或者重新分配slot的逻辑
 	// 判断 NoReachableClusterNodeException，直接返回错误
 	// 判断 ConnectionException，重试，当attempt<=1时，重新分配slot
-	// 判断 RedirectionException，如果是MovedDataException，则重新分配slot，如果是AskDataException，则设置ctx，如果是其他错误，直接返回错误，继续重试
+	// 判断 RedirectionException，如果是MovedDataException，则重新分配slot，如果是AskDataException，则直接向目标节点发送ASKING，不更新slot
+	// 判断 TryAgainException和ClusterDownException，等待一段时间后重试
+	// 重定向次数由maxRedirects限制，不占用maxAttempts
+	switch err.(type) {
+	case *MovedDataError:
+		r.connectionHandler.renewSlotCache(connection)
+	}
+	_ = r.releaseConnection(connection)
 	switch err.(type) {
 	case *NoReachableClusterNodeError:
 		return nil, err
 	case *ConnectError:
-		_ = r.releaseConnection(connection)
 		if attempts <= 1 {
 			r.connectionHandler.renewSlotCache()
-			//return nil, err
 		}
 		return r.runWithRetries(key, attempts-1, tryRandomNode, redirect)
-	case *MovedDataError:
-		r.connectionHandler.renewSlotCache(connection)
-		_ = r.releaseConnection(connection)
-		return r.runWithRetries(key, attempts-1, false, err)
+	case *MovedDataError, *AskDataError:
+		r.redirections++
+		if r.redirections > r.maxRedirects {
+			return nil, newClusterMaxAttemptsError("too many cluster redirections")
+		}
+		return r.runWithRetries(key, attempts, false, err)
+	case *TryAgainError:
+		r.backoff(attempts)
+		return r.runWithRetries(key, attempts-1, false, nil)
+	case *ClusterError:
+		r.backoff(attempts)
+		r.connectionHandler.renewSlotCache()
+		return r.runWithRetries(key, attempts-1, false, nil)
 	}
 	return nil, err
 }
 
+//backoff sleep before retrying TRYAGAIN and CLUSTERDOWN,the sleep time doubles on every retry
+func (r *redisClusterCommand) backoff(attempts int) {
+	clusterBackoff(r.maxAttempts - attempts)
+}
+
+//clusterBackoff sleep before the retry, the sleep time doubles on every retry and is limited to clusterMaxRetryBackoff
+func clusterBackoff(retries int) {
+	time.Sleep(clusterBackoffDuration(retries))
+}
+
+func clusterBackoffDuration(retries int) time.Duration {
+	sleep := clusterRetryBackoff << uint(retries)
+	if sleep <= 0 || sleep > clusterMaxRetryBackoff {
+		sleep = clusterMaxRetryBackoff
+	}
+	return sleep
+}
+
 func (r *redisClusterCommand) processRedirect(redirect error) (*Redis, error) {
 	switch redirect.(type) {
 	case *MovedDataError:
@@ -459,6 +714,7 @@ func (r *redisClusterCommand) processRedirect(redirect error) (*Redis, error) {
 		}
 		_, err = connection.Asking()
 		if err != nil {
+			_ = r.releaseConnection(connection)
 			return nil, err
 		}
 		return connection, nil
@@ -472,13 +728,47 @@ type ClusterOption struct {
 	ConnectionTimeout time.Duration //redis connect timeout
 	SoTimeout         time.Duration //redis read timeout
 	MaxAttempts       int           //when operation or socket is not alright,then program will attempt retry
+	MaxRedirects      int           //max MOVED and ASK redirections of one command, counted separately from MaxAttempts
+	RefreshInterval   time.Duration //refresh the slot cache in background periodically, 0 means the cache is only refreshed by MOVED and connection errors
 	Password          string        //cluster redis password
 	PoolConfig        *PoolConfig   //redis connection pool config
+	AddressMapper     AddressMapper //map the node addresses announced by the cluster, used when the cluster is behind NAT or in containers
+}
+
+//AddressMapper map the address announced by the cluster to the address reachable from the client,
+// it's applied to the nodes found by topology discovery and to the targets of MOVED and ASK
+type AddressMapper func(host string, port int) (string, int)
+
+//NewStaticAddressMapper map the addresses by a static table of host:port -> host:port,
+// the addresses not in the table are unchanged, an error is returned when an address of the table is invalid
+func NewStaticAddressMapper(addresses map[string]string) (AddressMapper, error) {
+	type nodeAddr struct {
+		host string
+		port int
+	}
+	table := make(map[string]nodeAddr, len(addresses))
+	for from, to := range addresses {
+		if _, _, ok := splitNodeKey(from); !ok {
+			return nil, newDataError("invalid address to map, host:port is expected: " + from)
+		}
+		host, port, ok := splitNodeKey(to)
+		if !ok {
+			return nil, newDataError("invalid mapped address, host:port is expected: " + to)
+		}
+		table[from] = nodeAddr{host: host, port: port}
+	}
+	return func(host string, port int) (string, int) {
+		if mapped, ok := table[host+":"+strconv.Itoa(port)]; ok {
+			return mapped.host, mapped.port
+		}
+		return host, port
+	}, nil
 }
 
 //RedisCluster redis cluster tool
 type RedisCluster struct {
 	MaxAttempts       int
+	MaxRedirects      int
 	connectionHandler *redisClusterConnectionHandler
 }
 
@@ -487,6 +777,9 @@ func NewRedisCluster(option *ClusterOption) *RedisCluster {
 	if option.MaxAttempts <= 0 {
 		option.MaxAttempts = 5
 	}
+	if option.MaxRedirects <= 0 {
+		option.MaxRedirects = 5
+	}
 	conTimeout := option.ConnectionTimeout
 	if option.ConnectionTimeout == 0 {
 		conTimeout = 5 * time.Second
@@ -495,17 +788,28 @@ func NewRedisCluster(option *ClusterOption) *RedisCluster {
 	if option.SoTimeout == 0 {
 		soTimeout = 5 * time.Second
 	}
+	connectionHandler := newRedisClusterConnectionHandler(option.Nodes, conTimeout, soTimeout, option.Password, option.PoolConfig, option.AddressMapper)
+	if option.RefreshInterval > 0 {
+		connectionHandler.startRefresh(option.RefreshInterval)
+	}
 	return &RedisCluster{
 		MaxAttempts:       option.MaxAttempts,
-		connectionHandler: newRedisClusterConnectionHandler(option.Nodes, conTimeout, soTimeout, option.Password, option.PoolConfig),
+		MaxRedirects:      option.MaxRedirects,
+		connectionHandler: connectionHandler,
 	}
 }
 
+//Close stop the background refresh and destroy the pools of all the cluster nodes,
+// the cluster can't be used anymore after Close
+func (r *RedisCluster) Close() {
+	r.connectionHandler.close()
+}
+
 //<editor-fold desc="rediscommands">
 
 //Set set key/value,without timeout
 func (r *RedisCluster) Set(key, value string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Set(key, value)
 	}
@@ -513,8 +817,10 @@ func (r *RedisCluster) Set(key, value string) (string, error) {
 }
 
 //SetWithParamsAndTime see redis command
+//
+//Deprecated: use SetWithArgs
 func (r *RedisCluster) SetWithParamsAndTime(key, value, nxxx, expx string, time int64) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SetWithParamsAndTime(key, value, nxxx, expx, time)
 	}
@@ -522,8 +828,10 @@ func (r *RedisCluster) SetWithParamsAndTime(key, value, nxxx, expx string, time
 }
 
 //SetWithParams see redis command
+//
+//Deprecated: use SetWithArgs
 func (r *RedisCluster) SetWithParams(key, value, nxxx string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SetWithParams(key, value, nxxx)
 	}
@@ -532,7 +840,7 @@ func (r *RedisCluster) SetWithParams(key, value, nxxx string) (string, error) {
 
 //Get see redis command
 func (r *RedisCluster) Get(key string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Get(key)
 	}
@@ -541,7 +849,7 @@ func (r *RedisCluster) Get(key string) (string, error) {
 
 //Persist see redis command
 func (r *RedisCluster) Persist(key string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Persist(key)
 	}
@@ -550,7 +858,7 @@ func (r *RedisCluster) Persist(key string) (int64, error) {
 
 //Type see redis command
 func (r *RedisCluster) Type(key string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Type(key)
 	}
@@ -559,7 +867,7 @@ func (r *RedisCluster) Type(key string) (string, error) {
 
 //Expire see redis command
 func (r *RedisCluster) Expire(key string, seconds int) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Expire(key, seconds)
 	}
@@ -568,7 +876,7 @@ func (r *RedisCluster) Expire(key string, seconds int) (int64, error) {
 
 //PExpire see redis command
 func (r *RedisCluster) PExpire(key string, milliseconds int64) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.PExpire(key, milliseconds)
 	}
@@ -577,7 +885,7 @@ func (r *RedisCluster) PExpire(key string, milliseconds int64) (int64, error) {
 
 //ExpireAt see redis command
 func (r *RedisCluster) ExpireAt(key string, unixtime int64) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ExpireAt(key, unixtime)
 	}
@@ -586,7 +894,7 @@ func (r *RedisCluster) ExpireAt(key string, unixtime int64) (int64, error) {
 
 //PExpireAt see redis command
 func (r *RedisCluster) PExpireAt(key string, millisecondsTimestamp int64) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.PExpireAt(key, millisecondsTimestamp)
 	}
@@ -595,7 +903,7 @@ func (r *RedisCluster) PExpireAt(key string, millisecondsTimestamp int64) (int64
 
 //TTL see redis command
 func (r *RedisCluster) TTL(key string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.TTL(key)
 	}
@@ -604,7 +912,7 @@ func (r *RedisCluster) TTL(key string) (int64, error) {
 
 //PTTL see redis command
 func (r *RedisCluster) PTTL(key string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.PTTL(key)
 	}
@@ -613,7 +921,7 @@ func (r *RedisCluster) PTTL(key string) (int64, error) {
 
 //SetBitWithBool see redis command
 func (r *RedisCluster) SetBitWithBool(key string, offset int64, value bool) (bool, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SetBitWithBool(key, offset, value)
 	}
@@ -622,7 +930,7 @@ func (r *RedisCluster) SetBitWithBool(key string, offset int64, value bool) (boo
 
 //SetBit see redis command
 func (r *RedisCluster) SetBit(key string, offset int64, value string) (bool, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SetBit(key, offset, value)
 	}
@@ -631,7 +939,7 @@ func (r *RedisCluster) SetBit(key string, offset int64, value string) (bool, err
 
 //GetBit see redis command
 func (r *RedisCluster) GetBit(key string, offset int64) (bool, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.GetBit(key, offset)
 	}
@@ -640,7 +948,7 @@ func (r *RedisCluster) GetBit(key string, offset int64) (bool, error) {
 
 //SetRange see redis command
 func (r *RedisCluster) SetRange(key string, offset int64, value string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SetRange(key, offset, value)
 	}
@@ -649,7 +957,7 @@ func (r *RedisCluster) SetRange(key string, offset int64, value string) (int64,
 
 //GetRange see redis command
 func (r *RedisCluster) GetRange(key string, startOffset, endOffset int64) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.GetRange(key, startOffset, endOffset)
 	}
@@ -658,7 +966,7 @@ func (r *RedisCluster) GetRange(key string, startOffset, endOffset int64) (strin
 
 //GetSet see redis command
 func (r *RedisCluster) GetSet(key, value string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.GetSet(key, value)
 	}
@@ -667,7 +975,7 @@ func (r *RedisCluster) GetSet(key, value string) (string, error) {
 
 //SetNx see redis command
 func (r *RedisCluster) SetNx(key, value string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SetNx(key, value)
 	}
@@ -676,7 +984,7 @@ func (r *RedisCluster) SetNx(key, value string) (int64, error) {
 
 //SetEx see redis command
 func (r *RedisCluster) SetEx(key string, seconds int, value string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SetEx(key, seconds, value)
 	}
@@ -685,7 +993,7 @@ func (r *RedisCluster) SetEx(key string, seconds int, value string) (string, err
 
 //PSetEx see redis command
 func (r *RedisCluster) PSetEx(key string, milliseconds int64, value string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.PSetEx(key, milliseconds, value)
 	}
@@ -694,7 +1002,7 @@ func (r *RedisCluster) PSetEx(key string, milliseconds int64, value string) (str
 
 //DecrBy see redis command
 func (r *RedisCluster) DecrBy(key string, decrement int64) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.DecrBy(key, decrement)
 	}
@@ -703,7 +1011,7 @@ func (r *RedisCluster) DecrBy(key string, decrement int64) (int64, error) {
 
 //Decr see redis command
 func (r *RedisCluster) Decr(key string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Decr(key)
 	}
@@ -712,7 +1020,7 @@ func (r *RedisCluster) Decr(key string) (int64, error) {
 
 //IncrBy see redis command
 func (r *RedisCluster) IncrBy(key string, increment int64) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.IncrBy(key, increment)
 	}
@@ -721,7 +1029,7 @@ func (r *RedisCluster) IncrBy(key string, increment int64) (int64, error) {
 
 //IncrByFloat see redis command
 func (r *RedisCluster) IncrByFloat(key string, increment float64) (float64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.IncrByFloat(key, increment)
 	}
@@ -730,7 +1038,7 @@ func (r *RedisCluster) IncrByFloat(key string, increment float64) (float64, erro
 
 //Incr see redis command
 func (r *RedisCluster) Incr(key string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Incr(key)
 	}
@@ -739,7 +1047,7 @@ func (r *RedisCluster) Incr(key string) (int64, error) {
 
 //Append see redis command
 func (r *RedisCluster) Append(key, value string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Append(key, value)
 	}
@@ -748,7 +1056,7 @@ func (r *RedisCluster) Append(key, value string) (int64, error) {
 
 //SubStr see redis command
 func (r *RedisCluster) SubStr(key string, start, end int) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SubStr(key, start, end)
 	}
@@ -757,7 +1065,7 @@ func (r *RedisCluster) SubStr(key string, start, end int) (string, error) {
 
 //HSet see redis command
 func (r *RedisCluster) HSet(key, field string, value string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HSet(key, field, value)
 	}
@@ -766,7 +1074,7 @@ func (r *RedisCluster) HSet(key, field string, value string) (int64, error) {
 
 //HGet see redis command
 func (r *RedisCluster) HGet(key, field string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HGet(key, field)
 	}
@@ -775,7 +1083,7 @@ func (r *RedisCluster) HGet(key, field string) (string, error) {
 
 //HSetNx see redis command
 func (r *RedisCluster) HSetNx(key, field, value string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HSetNx(key, field, value)
 	}
@@ -783,8 +1091,10 @@ func (r *RedisCluster) HSetNx(key, field, value string) (int64, error) {
 }
 
 //HMSet see redis command
+//
+//Deprecated: use HSetByMap
 func (r *RedisCluster) HMSet(key string, hash map[string]string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HMSet(key, hash)
 	}
@@ -793,7 +1103,7 @@ func (r *RedisCluster) HMSet(key string, hash map[string]string) (string, error)
 
 //HMGet see redis command
 func (r *RedisCluster) HMGet(key string, fields ...string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HMGet(key, fields...)
 	}
@@ -802,7 +1112,7 @@ func (r *RedisCluster) HMGet(key string, fields ...string) ([]string, error) {
 
 //HIncrBy see redis command
 func (r *RedisCluster) HIncrBy(key, field string, value int64) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HIncrBy(key, field, value)
 	}
@@ -811,7 +1121,7 @@ func (r *RedisCluster) HIncrBy(key, field string, value int64) (int64, error) {
 
 //HIncrByFloat see redis command
 func (r *RedisCluster) HIncrByFloat(key, field string, value float64) (float64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HIncrByFloat(key, field, value)
 	}
@@ -820,7 +1130,7 @@ func (r *RedisCluster) HIncrByFloat(key, field string, value float64) (float64,
 
 //HExists see redis command
 func (r *RedisCluster) HExists(key, field string) (bool, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HExists(key, field)
 	}
@@ -829,7 +1139,7 @@ func (r *RedisCluster) HExists(key, field string) (bool, error) {
 
 //HDel see redis command
 func (r *RedisCluster) HDel(key string, fields ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HDel(key, fields...)
 	}
@@ -838,7 +1148,7 @@ func (r *RedisCluster) HDel(key string, fields ...string) (int64, error) {
 
 //HLen see redis command
 func (r *RedisCluster) HLen(key string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HLen(key)
 	}
@@ -847,7 +1157,7 @@ func (r *RedisCluster) HLen(key string) (int64, error) {
 
 //HKeys see redis command
 func (r *RedisCluster) HKeys(key string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HKeys(key)
 	}
@@ -856,7 +1166,7 @@ func (r *RedisCluster) HKeys(key string) ([]string, error) {
 
 //HVals see redis command
 func (r *RedisCluster) HVals(key string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HVals(key)
 	}
@@ -865,7 +1175,7 @@ func (r *RedisCluster) HVals(key string) ([]string, error) {
 
 //HGetAll see redis command
 func (r *RedisCluster) HGetAll(key string) (map[string]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HGetAll(key)
 	}
@@ -874,7 +1184,7 @@ func (r *RedisCluster) HGetAll(key string) (map[string]string, error) {
 
 //RPush see redis command
 func (r *RedisCluster) RPush(key string, strings ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.RPush(key, strings...)
 	}
@@ -883,7 +1193,7 @@ func (r *RedisCluster) RPush(key string, strings ...string) (int64, error) {
 
 //LPush see redis command
 func (r *RedisCluster) LPush(key string, strings ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.LPush(key, strings...)
 	}
@@ -892,7 +1202,7 @@ func (r *RedisCluster) LPush(key string, strings ...string) (int64, error) {
 
 //LLen see redis command
 func (r *RedisCluster) LLen(key string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.LLen(key)
 	}
@@ -901,7 +1211,7 @@ func (r *RedisCluster) LLen(key string) (int64, error) {
 
 //LRange see redis command
 func (r *RedisCluster) LRange(key string, start, stop int64) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.LRange(key, start, stop)
 	}
@@ -910,7 +1220,7 @@ func (r *RedisCluster) LRange(key string, start, stop int64) ([]string, error) {
 
 //LTrim see redis command
 func (r *RedisCluster) LTrim(key string, start, stop int64) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.LTrim(key, start, stop)
 	}
@@ -919,7 +1229,7 @@ func (r *RedisCluster) LTrim(key string, start, stop int64) (string, error) {
 
 //LIndex see redis command
 func (r *RedisCluster) LIndex(key string, index int64) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.LIndex(key, index)
 	}
@@ -928,7 +1238,7 @@ func (r *RedisCluster) LIndex(key string, index int64) (string, error) {
 
 //LSet see redis command
 func (r *RedisCluster) LSet(key string, index int64, value string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.LSet(key, index, value)
 	}
@@ -937,7 +1247,7 @@ func (r *RedisCluster) LSet(key string, index int64, value string) (string, erro
 
 //LRem see redis command
 func (r *RedisCluster) LRem(key string, count int64, value string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.LRem(key, count, value)
 	}
@@ -946,7 +1256,7 @@ func (r *RedisCluster) LRem(key string, count int64, value string) (int64, error
 
 //LPop see redis command
 func (r *RedisCluster) LPop(key string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.LPop(key)
 	}
@@ -955,7 +1265,7 @@ func (r *RedisCluster) LPop(key string) (string, error) {
 
 //RPop see redis command
 func (r *RedisCluster) RPop(key string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.RPop(key)
 	}
@@ -964,7 +1274,7 @@ func (r *RedisCluster) RPop(key string) (string, error) {
 
 //SAdd see redis command
 func (r *RedisCluster) SAdd(key string, members ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SAdd(key, members...)
 	}
@@ -973,7 +1283,7 @@ func (r *RedisCluster) SAdd(key string, members ...string) (int64, error) {
 
 //SMembers see redis command
 func (r *RedisCluster) SMembers(key string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SMembers(key)
 	}
@@ -982,7 +1292,7 @@ func (r *RedisCluster) SMembers(key string) ([]string, error) {
 
 //SRem see redis command
 func (r *RedisCluster) SRem(key string, members ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SRem(key, members...)
 	}
@@ -991,7 +1301,7 @@ func (r *RedisCluster) SRem(key string, members ...string) (int64, error) {
 
 //SPop see redis command
 func (r *RedisCluster) SPop(key string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SPop(key)
 	}
@@ -1000,7 +1310,7 @@ func (r *RedisCluster) SPop(key string) (string, error) {
 
 //SPopBatch  see comment in redis.go
 func (r *RedisCluster) SPopBatch(key string, count int64) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SPopBatch(key, count)
 	}
@@ -1009,7 +1319,7 @@ func (r *RedisCluster) SPopBatch(key string, count int64) ([]string, error) {
 
 //SCard  see comment in redis.go
 func (r *RedisCluster) SCard(key string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SCard(key)
 	}
@@ -1018,7 +1328,7 @@ func (r *RedisCluster) SCard(key string) (int64, error) {
 
 //SIsMember  see comment in redis.go
 func (r *RedisCluster) SIsMember(key string, member string) (bool, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SIsMember(key, member)
 	}
@@ -1027,7 +1337,7 @@ func (r *RedisCluster) SIsMember(key string, member string) (bool, error) {
 
 //SRandMember  see comment in redis.go
 func (r *RedisCluster) SRandMember(key string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SRandMember(key)
 	}
@@ -1036,7 +1346,7 @@ func (r *RedisCluster) SRandMember(key string) (string, error) {
 
 //SRandMemberBatch  see comment in redis.go
 func (r *RedisCluster) SRandMemberBatch(key string, count int) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SRandMemberBatch(key, count)
 	}
@@ -1045,7 +1355,7 @@ func (r *RedisCluster) SRandMemberBatch(key string, count int) ([]string, error)
 
 //StrLen  see comment in redis.go
 func (r *RedisCluster) StrLen(key string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.StrLen(key)
 	}
@@ -1054,7 +1364,7 @@ func (r *RedisCluster) StrLen(key string) (int64, error) {
 
 //ZAdd  see comment in redis.go
 func (r *RedisCluster) ZAdd(key string, score float64, member string, params ...*ZAddParams) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZAdd(key, score, member, params...)
 	}
@@ -1063,7 +1373,7 @@ func (r *RedisCluster) ZAdd(key string, score float64, member string, params ...
 
 //ZAddByMap  see comment in redis.go
 func (r *RedisCluster) ZAddByMap(key string, scoreMembers map[string]float64, params ...*ZAddParams) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZAddByMap(key, scoreMembers, params...)
 	}
@@ -1072,7 +1382,7 @@ func (r *RedisCluster) ZAddByMap(key string, scoreMembers map[string]float64, pa
 
 //ZRange  see comment in redis.go
 func (r *RedisCluster) ZRange(key string, start, end int64) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRange(key, start, end)
 	}
@@ -1081,7 +1391,7 @@ func (r *RedisCluster) ZRange(key string, start, end int64) ([]string, error) {
 
 //ZRem  see comment in redis.go
 func (r *RedisCluster) ZRem(key string, member ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRem(key, member...)
 	}
@@ -1090,7 +1400,7 @@ func (r *RedisCluster) ZRem(key string, member ...string) (int64, error) {
 
 //ZIncrBy  see comment in redis.go
 func (r *RedisCluster) ZIncrBy(key string, score float64, member string, params ...*ZAddParams) (float64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZIncrBy(key, score, member, params...)
 	}
@@ -1099,7 +1409,7 @@ func (r *RedisCluster) ZIncrBy(key string, score float64, member string, params
 
 //ZRank  see comment in redis.go
 func (r *RedisCluster) ZRank(key, member string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRank(key, member)
 	}
@@ -1108,7 +1418,7 @@ func (r *RedisCluster) ZRank(key, member string) (int64, error) {
 
 //ZRevRank  see comment in redis.go
 func (r *RedisCluster) ZRevRank(key, member string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRevRank(key, member)
 	}
@@ -1117,7 +1427,7 @@ func (r *RedisCluster) ZRevRank(key, member string) (int64, error) {
 
 //ZRevRange  see comment in redis.go
 func (r *RedisCluster) ZRevRange(key string, start, end int64) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRevRange(key, start, end)
 	}
@@ -1126,7 +1436,7 @@ func (r *RedisCluster) ZRevRange(key string, start, end int64) ([]string, error)
 
 //ZRangeWithScores  see comment in redis.go
 func (r *RedisCluster) ZRangeWithScores(key string, start, end int64) ([]Tuple, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRangeWithScores(key, start, end)
 	}
@@ -1135,7 +1445,7 @@ func (r *RedisCluster) ZRangeWithScores(key string, start, end int64) ([]Tuple,
 
 //ZRevRangeWithScores  see comment in redis.go
 func (r *RedisCluster) ZRevRangeWithScores(key string, start, end int64) ([]Tuple, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRevRangeWithScores(key, start, end)
 	}
@@ -1144,7 +1454,7 @@ func (r *RedisCluster) ZRevRangeWithScores(key string, start, end int64) ([]Tupl
 
 //ZCard  see comment in redis.go
 func (r *RedisCluster) ZCard(key string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZCard(key)
 	}
@@ -1153,7 +1463,7 @@ func (r *RedisCluster) ZCard(key string) (int64, error) {
 
 //ZScore  see comment in redis.go
 func (r *RedisCluster) ZScore(key, member string) (float64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZScore(key, member)
 	}
@@ -1162,7 +1472,7 @@ func (r *RedisCluster) ZScore(key, member string) (float64, error) {
 
 //Sort  see comment in redis.go
 func (r *RedisCluster) Sort(key string, params ...*SortParams) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Sort(key, params...)
 	}
@@ -1171,7 +1481,7 @@ func (r *RedisCluster) Sort(key string, params ...*SortParams) ([]string, error)
 
 //ZCount  see comment in redis.go
 func (r *RedisCluster) ZCount(key string, min, max float64) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZCount(key, min, max)
 	}
@@ -1180,7 +1490,7 @@ func (r *RedisCluster) ZCount(key string, min, max float64) (int64, error) {
 
 //ZRangeByScore  see comment in redis.go
 func (r *RedisCluster) ZRangeByScore(key string, min, max float64) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRangeByScore(key, min, max)
 	}
@@ -1189,7 +1499,7 @@ func (r *RedisCluster) ZRangeByScore(key string, min, max float64) ([]string, er
 
 //ZRevRangeByScore  see comment in redis.go
 func (r *RedisCluster) ZRevRangeByScore(key string, max, min float64) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRevRangeByScore(key, max, min)
 	}
@@ -1197,8 +1507,10 @@ func (r *RedisCluster) ZRevRangeByScore(key string, max, min float64) ([]string,
 }
 
 //ZRangeByScoreBatch  see comment in redis.go
+//
+//Deprecated: use ZRangeByParams with NewZRangeByScoreParams(min, max).Limit(offset, count)
 func (r *RedisCluster) ZRangeByScoreBatch(key string, min, max float64, offset int, count int) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRangeByScoreBatch(key, min, max, offset, count)
 	}
@@ -1207,7 +1519,7 @@ func (r *RedisCluster) ZRangeByScoreBatch(key string, min, max float64, offset i
 
 //ZRangeByScoreWithScores  see comment in redis.go
 func (r *RedisCluster) ZRangeByScoreWithScores(key string, min, max float64) ([]Tuple, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRangeByScoreWithScores(key, min, max)
 	}
@@ -1216,7 +1528,7 @@ func (r *RedisCluster) ZRangeByScoreWithScores(key string, min, max float64) ([]
 
 //ZRevRangeByScoreWithScores  see comment in redis.go
 func (r *RedisCluster) ZRevRangeByScoreWithScores(key string, max, min float64) ([]Tuple, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRevRangeByScoreWithScores(key, max, min)
 	}
@@ -1224,8 +1536,10 @@ func (r *RedisCluster) ZRevRangeByScoreWithScores(key string, max, min float64)
 }
 
 //ZRangeByScoreWithScoresBatch  see comment in redis.go
+//
+//Deprecated: use ZRangeWithScoresByParams with NewZRangeByScoreParams(min, max).Limit(offset, count)
 func (r *RedisCluster) ZRangeByScoreWithScoresBatch(key string, min, max float64, offset, count int) ([]Tuple, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRangeByScoreWithScoresBatch(key, min, max, offset, count)
 	}
@@ -1233,8 +1547,10 @@ func (r *RedisCluster) ZRangeByScoreWithScoresBatch(key string, min, max float64
 }
 
 //ZRevRangeByScoreWithScoresBatch  see comment in redis.go
+//
+//Deprecated: use ZRangeWithScoresByParams with NewZRangeByScoreParams(max, min).Rev().Limit(offset, count)
 func (r *RedisCluster) ZRevRangeByScoreWithScoresBatch(key string, max, min float64, offset, count int) ([]Tuple, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRevRangeByScoreWithScoresBatch(key, max, min, offset, count)
 	}
@@ -1243,7 +1559,7 @@ func (r *RedisCluster) ZRevRangeByScoreWithScoresBatch(key string, max, min floa
 
 //ZRemRangeByRank  see comment in redis.go
 func (r *RedisCluster) ZRemRangeByRank(key string, start, end int64) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRemRangeByRank(key, start, end)
 	}
@@ -1252,7 +1568,7 @@ func (r *RedisCluster) ZRemRangeByRank(key string, start, end int64) (int64, err
 
 //ZRemRangeByScore  see comment in redis.go
 func (r *RedisCluster) ZRemRangeByScore(key string, min, max float64) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRemRangeByScore(key, min, max)
 	}
@@ -1261,7 +1577,7 @@ func (r *RedisCluster) ZRemRangeByScore(key string, min, max float64) (int64, er
 
 //ZLexCount  see comment in redis.go
 func (r *RedisCluster) ZLexCount(key, min, max string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZLexCount(key, min, max)
 	}
@@ -1270,7 +1586,7 @@ func (r *RedisCluster) ZLexCount(key, min, max string) (int64, error) {
 
 //ZRangeByLex  see comment in redis.go
 func (r *RedisCluster) ZRangeByLex(key, min, max string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRangeByLex(key, min, max)
 	}
@@ -1278,8 +1594,10 @@ func (r *RedisCluster) ZRangeByLex(key, min, max string) ([]string, error) {
 }
 
 //ZRangeByLexBatch  see comment in redis.go
+//
+//Deprecated: use ZRangeByParams with NewZRangeByLexParams(min, max).Limit(offset, count)
 func (r *RedisCluster) ZRangeByLexBatch(key, min, max string, offset, count int) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRangeByLexBatch(key, min, max, offset, count)
 	}
@@ -1288,7 +1606,7 @@ func (r *RedisCluster) ZRangeByLexBatch(key, min, max string, offset, count int)
 
 //ZRevRangeByLex  see comment in redis.go
 func (r *RedisCluster) ZRevRangeByLex(key, max, min string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRevRangeByLex(key, max, min)
 	}
@@ -1296,8 +1614,10 @@ func (r *RedisCluster) ZRevRangeByLex(key, max, min string) ([]string, error) {
 }
 
 //ZRevRangeByLexBatch  see comment in redis.go
+//
+//Deprecated: use ZRangeByParams with NewZRangeByLexParams(max, min).Rev().Limit(offset, count)
 func (r *RedisCluster) ZRevRangeByLexBatch(key, max, min string, offset, count int) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRevRangeByLexBatch(key, max, min, offset, count)
 	}
@@ -1306,7 +1626,7 @@ func (r *RedisCluster) ZRevRangeByLexBatch(key, max, min string, offset, count i
 
 //ZRemRangeByLex  see comment in redis.go
 func (r *RedisCluster) ZRemRangeByLex(key, min, max string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZRemRangeByLex(key, min, max)
 	}
@@ -1315,7 +1635,7 @@ func (r *RedisCluster) ZRemRangeByLex(key, min, max string) (int64, error) {
 
 //LInsert  see comment in redis.go
 func (r *RedisCluster) LInsert(key string, where *ListOption, pivot, value string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.LInsert(key, where, pivot, value)
 	}
@@ -1324,7 +1644,7 @@ func (r *RedisCluster) LInsert(key string, where *ListOption, pivot, value strin
 
 //LPushX  see comment in redis.go
 func (r *RedisCluster) LPushX(key string, strs ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.LPushX(key, strs...)
 	}
@@ -1333,7 +1653,7 @@ func (r *RedisCluster) LPushX(key string, strs ...string) (int64, error) {
 
 //RPushX  see comment in redis.go
 func (r *RedisCluster) RPushX(key string, strs ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.RPushX(key, strs...)
 	}
@@ -1342,7 +1662,7 @@ func (r *RedisCluster) RPushX(key string, strs ...string) (int64, error) {
 
 //Echo  see comment in redis.go
 func (r *RedisCluster) Echo(str string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Echo(str)
 	}
@@ -1351,7 +1671,7 @@ func (r *RedisCluster) Echo(str string) (string, error) {
 
 //BitCount  see comment in redis.go
 func (r *RedisCluster) BitCount(key string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.BitCount(key)
 	}
@@ -1360,7 +1680,7 @@ func (r *RedisCluster) BitCount(key string) (int64, error) {
 
 //BitCountRange  see comment in redis.go
 func (r *RedisCluster) BitCountRange(key string, start int64, end int64) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.BitCountRange(key, start, end)
 	}
@@ -1369,7 +1689,7 @@ func (r *RedisCluster) BitCountRange(key string, start int64, end int64) (int64,
 
 //BitPos  see comment in redis.go
 func (r *RedisCluster) BitPos(key string, value bool, params ...*BitPosParams) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.BitPos(key, value, params...)
 	}
@@ -1378,7 +1698,7 @@ func (r *RedisCluster) BitPos(key string, value bool, params ...*BitPosParams) (
 
 //HScan  see comment in redis.go
 func (r *RedisCluster) HScan(key, cursor string, params ...*ScanParams) (*ScanResult, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.HScan(key, cursor, params...)
 	}
@@ -1387,7 +1707,7 @@ func (r *RedisCluster) HScan(key, cursor string, params ...*ScanParams) (*ScanRe
 
 //SScan  see comment in redis.go
 func (r *RedisCluster) SScan(key, cursor string, params ...*ScanParams) (*ScanResult, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SScan(key, cursor, params...)
 	}
@@ -1396,7 +1716,7 @@ func (r *RedisCluster) SScan(key, cursor string, params ...*ScanParams) (*ScanRe
 
 //ZScan  see comment in redis.go
 func (r *RedisCluster) ZScan(key, cursor string, params ...*ScanParams) (*ScanResult, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZScan(key, cursor, params...)
 	}
@@ -1405,7 +1725,7 @@ func (r *RedisCluster) ZScan(key, cursor string, params ...*ScanParams) (*ScanRe
 
 //PfAdd  see comment in redis.go
 func (r *RedisCluster) PfAdd(key string, elements ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.PfAdd(key, elements...)
 	}
@@ -1414,7 +1734,7 @@ func (r *RedisCluster) PfAdd(key string, elements ...string) (int64, error) {
 
 //GeoAdd  see comment in redis.go
 func (r *RedisCluster) GeoAdd(key string, longitude, latitude float64, member string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.GeoAdd(key, longitude, latitude, member)
 	}
@@ -1423,7 +1743,7 @@ func (r *RedisCluster) GeoAdd(key string, longitude, latitude float64, member st
 
 //GeoAddByMap  see comment in redis.go
 func (r *RedisCluster) GeoAddByMap(key string, memberCoordinateMap map[string]GeoCoordinate) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.GeoAddByMap(key, memberCoordinateMap)
 	}
@@ -1432,7 +1752,7 @@ func (r *RedisCluster) GeoAddByMap(key string, memberCoordinateMap map[string]Ge
 
 //GeoDist  see comment in redis.go
 func (r *RedisCluster) GeoDist(key string, member1, member2 string, unit ...*GeoUnit) (float64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.GeoDist(key, member1, member2, unit...)
 	}
@@ -1441,7 +1761,7 @@ func (r *RedisCluster) GeoDist(key string, member1, member2 string, unit ...*Geo
 
 //GeoHash  see comment in redis.go
 func (r *RedisCluster) GeoHash(key string, members ...string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.GeoHash(key, members...)
 	}
@@ -1450,7 +1770,7 @@ func (r *RedisCluster) GeoHash(key string, members ...string) ([]string, error)
 
 //GeoPos  see comment in redis.go
 func (r *RedisCluster) GeoPos(key string, members ...string) ([]*GeoCoordinate, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.GeoPos(key, members...)
 	}
@@ -1458,8 +1778,10 @@ func (r *RedisCluster) GeoPos(key string, members ...string) ([]*GeoCoordinate,
 }
 
 //GeoRadius  see comment in redis.go
+//
+//Deprecated: use GeoSearch
 func (r *RedisCluster) GeoRadius(key string, longitude, latitude, radius float64, unit *GeoUnit, param ...*GeoRadiusParams) ([]GeoRadiusResponse, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.GeoRadius(key, longitude, latitude, radius, unit, param...)
 	}
@@ -1467,8 +1789,10 @@ func (r *RedisCluster) GeoRadius(key string, longitude, latitude, radius float64
 }
 
 //GeoRadiusByMember  see comment in redis.go
+//
+//Deprecated: use GeoSearch
 func (r *RedisCluster) GeoRadiusByMember(key string, member string, radius float64, unit *GeoUnit, param ...*GeoRadiusParams) ([]GeoRadiusResponse, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.GeoRadiusByMember(key, member, radius, unit, param...)
 	}
@@ -1477,7 +1801,7 @@ func (r *RedisCluster) GeoRadiusByMember(key string, member string, radius float
 
 //BitField  see comment in redis.go
 func (r *RedisCluster) BitField(key string, arguments ...string) ([]int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.BitField(key, arguments...)
 	}
@@ -1491,7 +1815,7 @@ func (r *RedisCluster) BitField(key string, arguments ...string) ([]int64, error
 //Del delete one or more keys
 // return the number of deleted keys
 func (r *RedisCluster) Del(keys ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		//defer redis.Close()
 		return redis.Del(keys...)
@@ -1501,7 +1825,7 @@ func (r *RedisCluster) Del(keys ...string) (int64, error) {
 
 //Exists  see comment in redis.go
 func (r *RedisCluster) Exists(keys ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Exists(keys...)
 	}
@@ -1510,7 +1834,7 @@ func (r *RedisCluster) Exists(keys ...string) (int64, error) {
 
 //BLPopTimeout  see comment in redis.go
 func (r *RedisCluster) BLPopTimeout(timeout int, keys ...string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.BLPopTimeout(timeout, keys...)
 	}
@@ -1519,7 +1843,7 @@ func (r *RedisCluster) BLPopTimeout(timeout int, keys ...string) ([]string, erro
 
 //BRPopTimeout  see comment in redis.go
 func (r *RedisCluster) BRPopTimeout(timeout int, keys ...string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.BRPopTimeout(timeout, keys...)
 	}
@@ -1528,7 +1852,7 @@ func (r *RedisCluster) BRPopTimeout(timeout int, keys ...string) ([]string, erro
 
 //BLPop  see comment in redis.go
 func (r *RedisCluster) BLPop(args ...string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.BLPop(args...)
 	}
@@ -1537,7 +1861,7 @@ func (r *RedisCluster) BLPop(args ...string) ([]string, error) {
 
 //BRPop  see comment in redis.go
 func (r *RedisCluster) BRPop(args ...string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.BRPop(args...)
 	}
@@ -1546,7 +1870,7 @@ func (r *RedisCluster) BRPop(args ...string) ([]string, error) {
 
 //MGet  see comment in redis.go
 func (r *RedisCluster) MGet(keys ...string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.MGet(keys...)
 	}
@@ -1559,7 +1883,7 @@ func (r *RedisCluster) MSet(kvs ...string) (string, error) {
 	for i := 0; i < len(kvs)/2; i++ {
 		keys = append(keys, kvs[i*2])
 	}
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.MSet(kvs...)
 	}
@@ -1572,7 +1896,7 @@ func (r *RedisCluster) MSetNx(kvs ...string) (int64, error) {
 	for i := 0; i < len(kvs)/2; i++ {
 		keys = append(keys, kvs[i*2])
 	}
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.MSetNx(kvs...)
 	}
@@ -1581,7 +1905,7 @@ func (r *RedisCluster) MSetNx(kvs ...string) (int64, error) {
 
 //Rename  see comment in redis.go
 func (r *RedisCluster) Rename(oldKey, newKey string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Rename(oldKey, newKey)
 	}
@@ -1590,7 +1914,7 @@ func (r *RedisCluster) Rename(oldKey, newKey string) (string, error) {
 
 //RenameNx  see comment in redis.go
 func (r *RedisCluster) RenameNx(oldKey, newKey string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.RenameNx(oldKey, newKey)
 	}
@@ -1598,8 +1922,10 @@ func (r *RedisCluster) RenameNx(oldKey, newKey string) (int64, error) {
 }
 
 //RPopLPush  see comment in redis.go
+//
+//Deprecated: use LMove
 func (r *RedisCluster) RPopLPush(srcKey, destKey string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.RPopLPush(srcKey, destKey)
 	}
@@ -1608,7 +1934,7 @@ func (r *RedisCluster) RPopLPush(srcKey, destKey string) (string, error) {
 
 //SDiff  see comment in redis.go
 func (r *RedisCluster) SDiff(keys ...string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SDiff(keys...)
 	}
@@ -1617,7 +1943,7 @@ func (r *RedisCluster) SDiff(keys ...string) ([]string, error) {
 
 //SDiffStore  see comment in redis.go
 func (r *RedisCluster) SDiffStore(destKey string, keys ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SDiffStore(destKey, keys...)
 	}
@@ -1627,7 +1953,7 @@ func (r *RedisCluster) SDiffStore(destKey string, keys ...string) (int64, error)
 
 //SInter  see comment in redis.go
 func (r *RedisCluster) SInter(keys ...string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SInter(keys...)
 	}
@@ -1636,7 +1962,7 @@ func (r *RedisCluster) SInter(keys ...string) ([]string, error) {
 
 //SInterStore  see comment in redis.go
 func (r *RedisCluster) SInterStore(destKey string, keys ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SInterStore(destKey, keys...)
 	}
@@ -1646,7 +1972,7 @@ func (r *RedisCluster) SInterStore(destKey string, keys ...string) (int64, error
 
 //SMove  see comment in redis.go
 func (r *RedisCluster) SMove(srcKey, destKey, member string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SMove(srcKey, destKey, member)
 	}
@@ -1655,7 +1981,7 @@ func (r *RedisCluster) SMove(srcKey, destKey, member string) (int64, error) {
 
 //SortStore  see comment in redis.go
 func (r *RedisCluster) SortStore(key, destKey string, params ...*SortParams) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SortStore(key, destKey, params...)
 	}
@@ -1664,7 +1990,7 @@ func (r *RedisCluster) SortStore(key, destKey string, params ...*SortParams) (in
 
 //SUnion  see comment in redis.go
 func (r *RedisCluster) SUnion(keys ...string) ([]string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SUnion(keys...)
 	}
@@ -1673,7 +1999,7 @@ func (r *RedisCluster) SUnion(keys ...string) ([]string, error) {
 
 //SUnionStore  see comment in redis.go
 func (r *RedisCluster) SUnionStore(destKey string, keys ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.SUnionStore(destKey, keys...)
 	}
@@ -1683,7 +2009,7 @@ func (r *RedisCluster) SUnionStore(destKey string, keys ...string) (int64, error
 
 //ZInterStore  see comment in redis.go
 func (r *RedisCluster) ZInterStore(destKey string, sets ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZInterStore(destKey, sets...)
 	}
@@ -1693,7 +2019,7 @@ func (r *RedisCluster) ZInterStore(destKey string, sets ...string) (int64, error
 
 //ZInterStoreWithParams see redis command
 func (r *RedisCluster) ZInterStoreWithParams(destKey string, params *ZParams, sets ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZInterStoreWithParams(destKey, params, sets...)
 	}
@@ -1703,7 +2029,7 @@ func (r *RedisCluster) ZInterStoreWithParams(destKey string, params *ZParams, se
 
 //ZUnionStore see redis command
 func (r *RedisCluster) ZUnionStore(destKey string, sets ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZUnionStore(destKey, sets...)
 	}
@@ -1713,7 +2039,7 @@ func (r *RedisCluster) ZUnionStore(destKey string, sets ...string) (int64, error
 
 //ZUnionStoreWithParams see redis command
 func (r *RedisCluster) ZUnionStoreWithParams(destKey string, params *ZParams, sets ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ZUnionStoreWithParams(destKey, params, sets...)
 	}
@@ -1722,8 +2048,10 @@ func (r *RedisCluster) ZUnionStoreWithParams(destKey string, params *ZParams, se
 }
 
 //BRPopLPush see redis command
+//
+//Deprecated: use BLMove
 func (r *RedisCluster) BRPopLPush(source, destination string, timeout int) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.BRPopLPush(source, destination, timeout)
 	}
@@ -1732,7 +2060,7 @@ func (r *RedisCluster) BRPopLPush(source, destination string, timeout int) (stri
 
 //Publish see redis command
 func (r *RedisCluster) Publish(channel, message string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Publish(channel, message)
 	}
@@ -1741,7 +2069,7 @@ func (r *RedisCluster) Publish(channel, message string) (int64, error) {
 
 //Subscribe see redis command
 func (r *RedisCluster) Subscribe(redisPubSub *RedisPubSub, channels ...string) error {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		err := redis.Subscribe(redisPubSub, channels...)
 		if err != nil {
@@ -1758,7 +2086,7 @@ func (r *RedisCluster) Subscribe(redisPubSub *RedisPubSub, channels ...string) e
 
 //PSubscribe see redis command
 func (r *RedisCluster) PSubscribe(redisPubSub *RedisPubSub, patterns ...string) error {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		err := redis.PSubscribe(redisPubSub, patterns...)
 		if err != nil {
@@ -1773,9 +2101,30 @@ func (r *RedisCluster) PSubscribe(redisPubSub *RedisPubSub, patterns ...string)
 	return nil
 }
 
+//SPublish publish the message to the shard channel, the message is routed to the master owning the slot of the channel
+func (r *RedisCluster) SPublish(channel, message string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.SPublish(channel, message)
+	}
+	return ToInt64Reply(command.run(channel))
+}
+
+//SSubscribe subscribe shard channels, one connection is kept per master owning the slots of the channels,
+// the subscriptions are moved to the new owner transparently when the slots are migrated.
+// it blocks until all the shard channels are unsubscribed or the subscriptions can't be recovered
+func (r *RedisCluster) SSubscribe(redisPubSub *RedisPubSub, channels ...string) error {
+	subscriber := newClusterShardedSubscriber(r.connectionHandler, redisPubSub, r.MaxAttempts)
+	err := subscriber.subscribe(channels...)
+	if err != nil {
+		subscriber.stop(err)
+	}
+	return subscriber.wait()
+}
+
 //BitOp see redis command
 func (r *RedisCluster) BitOp(op BitOP, destKey string, srcKeys ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.BitOp(op, destKey, srcKeys...)
 	}
@@ -1797,7 +2146,7 @@ func (r *RedisCluster) Scan(cursor string, params ...*ScanParams) (*ScanResult,
 	if !newRedisClusterHashTagUtil().isClusterCompliantMatchPattern(matchPattern) {
 		return nil, errors.New("only supports SCAN commands with MATCH patterns containing hash-tags ( curly-brackets enclosed strings )")
 	}
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Scan(cursor, params...)
 	}
@@ -1806,7 +2155,7 @@ func (r *RedisCluster) Scan(cursor string, params ...*ScanParams) (*ScanResult,
 
 //PfMerge see redis command
 func (r *RedisCluster) PfMerge(destkey string, sourcekeys ...string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.PfMerge(destkey, sourcekeys...)
 	}
@@ -1816,7 +2165,7 @@ func (r *RedisCluster) PfMerge(destkey string, sourcekeys ...string) (string, er
 
 //PfCount see redis command
 func (r *RedisCluster) PfCount(keys ...string) (int64, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.PfCount(keys...)
 	}
@@ -1829,7 +2178,7 @@ func (r *RedisCluster) PfCount(keys ...string) (int64, error) {
 
 //Eval see redis command
 func (r *RedisCluster) Eval(script string, keyCount int, params ...string) (interface{}, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Eval(script, keyCount, params...)
 	}
@@ -1838,7 +2187,7 @@ func (r *RedisCluster) Eval(script string, keyCount int, params ...string) (inte
 
 //EvalSha see redis command
 func (r *RedisCluster) EvalSha(sha1 string, keyCount int, params ...string) (interface{}, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.EvalSha(sha1, keyCount, params...)
 	}
@@ -1847,7 +2196,7 @@ func (r *RedisCluster) EvalSha(sha1 string, keyCount int, params ...string) (int
 
 //ScriptExists see redis command
 func (r *RedisCluster) ScriptExists(key string, sha1 ...string) ([]bool, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ScriptExists(sha1...)
 	}
@@ -1856,7 +2205,7 @@ func (r *RedisCluster) ScriptExists(key string, sha1 ...string) ([]bool, error)
 
 //ScriptLoad see redis command
 func (r *RedisCluster) ScriptLoad(key, script string) (string, error) {
-	command := newRedisClusterCommand(r.MaxAttempts, r.connectionHandler)
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
 	command.execute = func(redis *Redis) (interface{}, error) {
 		return redis.ScriptLoad(script)
 	}
@@ -1864,3 +2213,1084 @@ func (r *RedisCluster) ScriptLoad(key, script string) (string, error) {
 }
 
 //</editor-fold>
+
+//<editor-fold desc="functioncommands">
+
+//FCall see comment in redis.go, it's routed by the keys which must be in the same slot
+func (r *RedisCluster) FCall(function string, keys, args []string) (interface{}, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.FCall(function, keys, args)
+	}
+	return command.runBatch(len(keys), keys...)
+}
+
+//FCallRO see comment in redis.go, it's sent to a replica of the master serving the keys when the master has one
+func (r *RedisCluster) FCallRO(function string, keys, args []string) (interface{}, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.FCallRO(function, keys, args)
+	}
+	return command.runReadOnlyBatch(len(keys), keys...)
+}
+
+//</editor-fold>
+
+//<editor-fold desc="streamcommands">
+
+//XAdd see comment in redis.go
+func (r *RedisCluster) XAdd(key string, args *XAddArgs) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XAdd(key, args)
+	}
+	return ToStrReply(command.run(key))
+}
+
+//XLen see comment in redis.go
+func (r *RedisCluster) XLen(key string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XLen(key)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//XDel see comment in redis.go
+func (r *RedisCluster) XDel(key string, ids ...string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XDel(key, ids...)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//XTrim see comment in redis.go
+func (r *RedisCluster) XTrim(key string, args *XTrimArgs) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XTrim(key, args)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//XRange see comment in redis.go
+func (r *RedisCluster) XRange(key, start, end string, count int64) ([]*StreamEntry, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XRange(key, start, end, count)
+	}
+	return ToStreamEntryArrReply(command.run(key))
+}
+
+//XRevRange see comment in redis.go
+func (r *RedisCluster) XRevRange(key, end, start string, count int64) ([]*StreamEntry, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XRevRange(key, end, start, count)
+	}
+	return ToStreamEntryArrReply(command.run(key))
+}
+
+//XRead see comment in redis.go, all the streams must be in the same slot
+func (r *RedisCluster) XRead(args *XReadArgs) ([]*XStream, error) {
+	if err := checkXReadArgs(args); err != nil {
+		return nil, err
+	}
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XRead(args)
+	}
+	return ToXStreamArrReply(command.runBatch(len(args.Keys), args.Keys...))
+}
+
+//XInfoStream see comment in redis.go
+func (r *RedisCluster) XInfoStream(key string) (*XInfoStream, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XInfoStream(key)
+	}
+	return ToXInfoStreamReply(command.run(key))
+}
+
+//XGroupCreate see comment in redis.go
+func (r *RedisCluster) XGroupCreate(key, group, id string, mkStream bool) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XGroupCreate(key, group, id, mkStream)
+	}
+	return ToStrReply(command.run(key))
+}
+
+//XGroupDestroy see comment in redis.go
+func (r *RedisCluster) XGroupDestroy(key, group string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XGroupDestroy(key, group)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//XGroupSetID see comment in redis.go
+func (r *RedisCluster) XGroupSetID(key, group, id string) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XGroupSetID(key, group, id)
+	}
+	return ToStrReply(command.run(key))
+}
+
+//XGroupCreateConsumer see comment in redis.go
+func (r *RedisCluster) XGroupCreateConsumer(key, group, consumer string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XGroupCreateConsumer(key, group, consumer)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//XGroupDelConsumer see comment in redis.go
+func (r *RedisCluster) XGroupDelConsumer(key, group, consumer string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XGroupDelConsumer(key, group, consumer)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//XReadGroup see comment in redis.go, all the streams must be in the same slot
+func (r *RedisCluster) XReadGroup(args *XReadGroupArgs) ([]*XStream, error) {
+	if err := checkXReadGroupArgs(args); err != nil {
+		return nil, err
+	}
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XReadGroup(args)
+	}
+	return ToXStreamArrReply(command.runBatch(len(args.Keys), args.Keys...))
+}
+
+//XAck see comment in redis.go
+func (r *RedisCluster) XAck(key, group string, ids ...string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XAck(key, group, ids...)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//XPending see comment in redis.go
+func (r *RedisCluster) XPending(key, group string) (*XPendingSummary, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XPending(key, group)
+	}
+	return ToXPendingSummaryReply(command.run(key))
+}
+
+//XPendingRange see comment in redis.go
+func (r *RedisCluster) XPendingRange(key, group string, args *XPendingArgs) ([]*XPendingEntry, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XPendingRange(key, group, args)
+	}
+	return ToXPendingEntryArrReply(command.run(key))
+}
+
+//XClaim see comment in redis.go
+func (r *RedisCluster) XClaim(key string, args *XClaimArgs) ([]*StreamEntry, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XClaim(key, args)
+	}
+	return ToStreamEntryArrReply(command.run(key))
+}
+
+//XAutoClaim see comment in redis.go
+func (r *RedisCluster) XAutoClaim(key string, args *XAutoClaimArgs) (*XAutoClaimResult, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.XAutoClaim(key, args)
+	}
+	return ToXAutoClaimResultReply(command.run(key))
+}
+
+//</editor-fold>
+
+//<editor-fold desc="stringcommands">
+
+//SetWithArgs see comment in redis.go
+func (r *RedisCluster) SetWithArgs(key, value string, args *SetArgs) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.SetWithArgs(key, value, args)
+	}
+	return ToStrReply(command.run(key))
+}
+
+//GetDel see comment in redis.go
+func (r *RedisCluster) GetDel(key string) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.GetDel(key)
+	}
+	return ToStrReply(command.run(key))
+}
+
+//GetEx see comment in redis.go
+func (r *RedisCluster) GetEx(key string, args *GetExArgs) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.GetEx(key, args)
+	}
+	return ToStrReply(command.run(key))
+}
+
+//LCS see comment in redis.go
+func (r *RedisCluster) LCS(key1, key2 string) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.LCS(key1, key2)
+	}
+	return ToStrReply(command.runBatch(2, key1, key2))
+}
+
+//LCSLen see comment in redis.go
+func (r *RedisCluster) LCSLen(key1, key2 string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.LCSLen(key1, key2)
+	}
+	return ToInt64Reply(command.runBatch(2, key1, key2))
+}
+
+//LCSIdx see comment in redis.go
+func (r *RedisCluster) LCSIdx(key1, key2 string, args *LCSIdxArgs) (*LCSResult, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.LCSIdx(key1, key2, args)
+	}
+	return ToLCSResultReply(command.runBatch(2, key1, key2))
+}
+
+//</editor-fold>
+
+//<editor-fold desc="listcommands">
+
+//LPos see comment in redis.go
+func (r *RedisCluster) LPos(key, element string, args *LPosArgs) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.LPos(key, element, args)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//LPosCount see comment in redis.go
+func (r *RedisCluster) LPosCount(key, element string, count int64, args *LPosArgs) ([]int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.LPosCount(key, element, count, args)
+	}
+	return ToInt64ArrReply(command.run(key))
+}
+
+//LMove see comment in redis.go
+func (r *RedisCluster) LMove(srcKey, destKey string, from, to *ListDirection) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.LMove(srcKey, destKey, from, to)
+	}
+	return ToStrReply(command.runBatch(2, srcKey, destKey))
+}
+
+//BLMove see comment in redis.go
+func (r *RedisCluster) BLMove(srcKey, destKey string, from, to *ListDirection, timeout time.Duration) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.BLMove(srcKey, destKey, from, to, timeout)
+	}
+	return ToStrReply(command.runBatch(2, srcKey, destKey))
+}
+
+//LMPop see comment in redis.go
+func (r *RedisCluster) LMPop(direction *ListDirection, count int64, keys ...string) (*LMPopResult, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.LMPop(direction, count, keys...)
+	}
+	return ToLMPopResultReply(command.runBatch(len(keys), keys...))
+}
+
+//BLMPop see comment in redis.go
+func (r *RedisCluster) BLMPop(timeout time.Duration, direction *ListDirection, count int64, keys ...string) (*LMPopResult, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.BLMPop(timeout, direction, count, keys...)
+	}
+	return ToLMPopResultReply(command.runBatch(len(keys), keys...))
+}
+
+//LPopCount see comment in redis.go
+func (r *RedisCluster) LPopCount(key string, count int64) ([]string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.LPopCount(key, count)
+	}
+	return ToStrArrReply(command.run(key))
+}
+
+//RPopCount see comment in redis.go
+func (r *RedisCluster) RPopCount(key string, count int64) ([]string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.RPopCount(key, count)
+	}
+	return ToStrArrReply(command.run(key))
+}
+
+//</editor-fold>
+
+//<editor-fold desc="sortedsetcommands">
+
+//ZPopMin see comment in redis.go
+func (r *RedisCluster) ZPopMin(key string, count int64) ([]Tuple, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZPopMin(key, count)
+	}
+	return ToTupleArrReply(command.run(key))
+}
+
+//ZPopMax see comment in redis.go
+func (r *RedisCluster) ZPopMax(key string, count int64) ([]Tuple, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZPopMax(key, count)
+	}
+	return ToTupleArrReply(command.run(key))
+}
+
+//BZPopMin see comment in redis.go
+func (r *RedisCluster) BZPopMin(timeout time.Duration, keys ...string) (*KeyedTuple, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.BZPopMin(timeout, keys...)
+	}
+	return ToKeyedTupleReply(command.runBatch(len(keys), keys...))
+}
+
+//BZPopMax see comment in redis.go
+func (r *RedisCluster) BZPopMax(timeout time.Duration, keys ...string) (*KeyedTuple, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.BZPopMax(timeout, keys...)
+	}
+	return ToKeyedTupleReply(command.runBatch(len(keys), keys...))
+}
+
+//ZMPop see comment in redis.go
+func (r *RedisCluster) ZMPop(option *SortedSetOption, count int64, keys ...string) (*ZMPopResult, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZMPop(option, count, keys...)
+	}
+	return ToZMPopResultReply(command.runBatch(len(keys), keys...))
+}
+
+//BZMPop see comment in redis.go
+func (r *RedisCluster) BZMPop(timeout time.Duration, option *SortedSetOption, count int64, keys ...string) (*ZMPopResult, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.BZMPop(timeout, option, count, keys...)
+	}
+	return ToZMPopResultReply(command.runBatch(len(keys), keys...))
+}
+
+//ZRandMember see comment in redis.go
+func (r *RedisCluster) ZRandMember(key string, count int64) ([]string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZRandMember(key, count)
+	}
+	return ToStrArrReply(command.run(key))
+}
+
+//ZRandMemberWithScores see comment in redis.go
+func (r *RedisCluster) ZRandMemberWithScores(key string, count int64) ([]Tuple, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZRandMemberWithScores(key, count)
+	}
+	return ToTupleArrReply(command.run(key))
+}
+
+//ZMScore see comment in redis.go
+func (r *RedisCluster) ZMScore(key string, members ...string) ([]*float64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZMScore(key, members...)
+	}
+	return ToFloat64PtrArrReply(command.run(key))
+}
+
+//ZRangeByParams see comment in redis.go
+func (r *RedisCluster) ZRangeByParams(key string, params *ZRangeParams) ([]string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZRangeByParams(key, params)
+	}
+	return ToStrArrReply(command.run(key))
+}
+
+//ZRangeWithScoresByParams see comment in redis.go
+func (r *RedisCluster) ZRangeWithScoresByParams(key string, params *ZRangeParams) ([]Tuple, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZRangeWithScoresByParams(key, params)
+	}
+	return ToTupleArrReply(command.run(key))
+}
+
+//ZRangeStore see comment in redis.go
+func (r *RedisCluster) ZRangeStore(destKey, srcKey string, params *ZRangeParams) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZRangeStore(destKey, srcKey, params)
+	}
+	return ToInt64Reply(command.runBatch(2, destKey, srcKey))
+}
+
+//ZDiff see comment in redis.go
+func (r *RedisCluster) ZDiff(keys ...string) ([]string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZDiff(keys...)
+	}
+	return ToStrArrReply(command.runBatch(len(keys), keys...))
+}
+
+//ZDiffWithScores see comment in redis.go
+func (r *RedisCluster) ZDiffWithScores(keys ...string) ([]Tuple, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZDiffWithScores(keys...)
+	}
+	return ToTupleArrReply(command.runBatch(len(keys), keys...))
+}
+
+//ZDiffStore see comment in redis.go
+func (r *RedisCluster) ZDiffStore(destKey string, keys ...string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZDiffStore(destKey, keys...)
+	}
+	arr := StrStrArrToStrArr(destKey, keys)
+	return ToInt64Reply(command.runBatch(len(arr), arr...))
+}
+
+//ZInter see comment in redis.go
+func (r *RedisCluster) ZInter(params *ZParams, keys ...string) ([]string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZInter(params, keys...)
+	}
+	return ToStrArrReply(command.runBatch(len(keys), keys...))
+}
+
+//ZInterWithScores see comment in redis.go
+func (r *RedisCluster) ZInterWithScores(params *ZParams, keys ...string) ([]Tuple, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZInterWithScores(params, keys...)
+	}
+	return ToTupleArrReply(command.runBatch(len(keys), keys...))
+}
+
+//ZUnion see comment in redis.go
+func (r *RedisCluster) ZUnion(params *ZParams, keys ...string) ([]string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZUnion(params, keys...)
+	}
+	return ToStrArrReply(command.runBatch(len(keys), keys...))
+}
+
+//ZUnionWithScores see comment in redis.go
+func (r *RedisCluster) ZUnionWithScores(params *ZParams, keys ...string) ([]Tuple, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ZUnionWithScores(params, keys...)
+	}
+	return ToTupleArrReply(command.runBatch(len(keys), keys...))
+}
+
+//</editor-fold>
+
+//<editor-fold desc="hashcommands">
+
+//HSetByMap see comment in redis.go
+func (r *RedisCluster) HSetByMap(key string, hash map[string]string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HSetByMap(key, hash)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//HStrLen see comment in redis.go
+func (r *RedisCluster) HStrLen(key, field string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HStrLen(key, field)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//HRandField see comment in redis.go
+func (r *RedisCluster) HRandField(key string, count int64) ([]string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HRandField(key, count)
+	}
+	return ToStrArrReply(command.run(key))
+}
+
+//HRandFieldWithValues see comment in redis.go
+func (r *RedisCluster) HRandFieldWithValues(key string, count int64) ([]HashFieldValue, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HRandFieldWithValues(key, count)
+	}
+	return ToHashFieldValueArrReply(command.run(key))
+}
+
+//HExpire see comment in redis.go
+func (r *RedisCluster) HExpire(key string, expiration time.Duration, condition *ExpireCondition, fields ...string) ([]HashFieldExpireStatus, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HExpire(key, expiration, condition, fields...)
+	}
+	return ToHashFieldExpireStatusArrReply(command.run(key))
+}
+
+//HPExpire see comment in redis.go
+func (r *RedisCluster) HPExpire(key string, expiration time.Duration, condition *ExpireCondition, fields ...string) ([]HashFieldExpireStatus, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HPExpire(key, expiration, condition, fields...)
+	}
+	return ToHashFieldExpireStatusArrReply(command.run(key))
+}
+
+//HExpireAt see comment in redis.go
+func (r *RedisCluster) HExpireAt(key string, at time.Time, condition *ExpireCondition, fields ...string) ([]HashFieldExpireStatus, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HExpireAt(key, at, condition, fields...)
+	}
+	return ToHashFieldExpireStatusArrReply(command.run(key))
+}
+
+//HPExpireAt see comment in redis.go
+func (r *RedisCluster) HPExpireAt(key string, at time.Time, condition *ExpireCondition, fields ...string) ([]HashFieldExpireStatus, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HPExpireAt(key, at, condition, fields...)
+	}
+	return ToHashFieldExpireStatusArrReply(command.run(key))
+}
+
+//HTTL see comment in redis.go
+func (r *RedisCluster) HTTL(key string, fields ...string) ([]int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HTTL(key, fields...)
+	}
+	return ToInt64ArrReply(command.run(key))
+}
+
+//HPTTL see comment in redis.go
+func (r *RedisCluster) HPTTL(key string, fields ...string) ([]int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HPTTL(key, fields...)
+	}
+	return ToInt64ArrReply(command.run(key))
+}
+
+//HPersist see comment in redis.go
+func (r *RedisCluster) HPersist(key string, fields ...string) ([]HashFieldPersistStatus, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HPersist(key, fields...)
+	}
+	return ToHashFieldPersistStatusArrReply(command.run(key))
+}
+
+//HGetDel see comment in redis.go
+func (r *RedisCluster) HGetDel(key string, fields ...string) ([]string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HGetDel(key, fields...)
+	}
+	return ToStrArrReply(command.run(key))
+}
+
+//HGetEx see comment in redis.go
+func (r *RedisCluster) HGetEx(key string, args *GetExArgs, fields ...string) ([]string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HGetEx(key, args, fields...)
+	}
+	return ToStrArrReply(command.run(key))
+}
+
+//HSetEx see comment in redis.go
+func (r *RedisCluster) HSetEx(key string, args *HSetExArgs, hash map[string]string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.HSetEx(key, args, hash)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//</editor-fold>
+
+//<editor-fold desc="geocommands">
+
+//GeoRadiusRO see comment in redis.go, it is sent to a replica when the master has one
+func (r *RedisCluster) GeoRadiusRO(key string, longitude, latitude, radius float64, unit *GeoUnit, param ...*GeoRadiusParams) ([]GeoRadiusResponse, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.GeoRadiusRO(key, longitude, latitude, radius, unit, param...)
+	}
+	return ToGeoRespArrReply(command.runReadOnly(key))
+}
+
+//GeoRadiusByMemberRO see comment in redis.go, it is sent to a replica when the master has one
+func (r *RedisCluster) GeoRadiusByMemberRO(key, member string, radius float64, unit *GeoUnit, param ...*GeoRadiusParams) ([]GeoRadiusResponse, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.GeoRadiusByMemberRO(key, member, radius, unit, param...)
+	}
+	return ToGeoRespArrReply(command.runReadOnly(key))
+}
+
+//GeoRadiusStore see comment in redis.go
+func (r *RedisCluster) GeoRadiusStore(key string, longitude, latitude, radius float64, unit *GeoUnit, param *GeoRadiusParams, store *GeoRadiusStoreArgs) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.GeoRadiusStore(key, longitude, latitude, radius, unit, param, store)
+	}
+	return ToInt64Reply(command.runBatch(2, key, store.Key))
+}
+
+//GeoRadiusByMemberStore see comment in redis.go
+func (r *RedisCluster) GeoRadiusByMemberStore(key, member string, radius float64, unit *GeoUnit, param *GeoRadiusParams, store *GeoRadiusStoreArgs) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.GeoRadiusByMemberStore(key, member, radius, unit, param, store)
+	}
+	return ToInt64Reply(command.runBatch(2, key, store.Key))
+}
+
+//GeoSearch see comment in redis.go
+func (r *RedisCluster) GeoSearch(key string, query *GeoSearchQuery) ([]GeoRadiusResponse, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.GeoSearch(key, query)
+	}
+	return ToGeoRespArrReply(command.run(key))
+}
+
+//GeoSearchStore see comment in redis.go
+func (r *RedisCluster) GeoSearchStore(destKey, srcKey string, query *GeoSearchQuery) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.GeoSearchStore(destKey, srcKey, query)
+	}
+	return ToInt64Reply(command.runBatch(2, destKey, srcKey))
+}
+
+//GeoSearchStoreDist see comment in redis.go
+func (r *RedisCluster) GeoSearchStoreDist(destKey, srcKey string, query *GeoSearchQuery) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.GeoSearchStoreDist(destKey, srcKey, query)
+	}
+	return ToInt64Reply(command.runBatch(2, destKey, srcKey))
+}
+
+//</editor-fold>
+
+//<editor-fold desc="keycommands">
+
+//Copy see comment in redis.go
+func (r *RedisCluster) Copy(srcKey, destKey string, args *CopyArgs) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.Copy(srcKey, destKey, args)
+	}
+	return ToInt64Reply(command.runBatch(2, srcKey, destKey))
+}
+
+//Touch see comment in redis.go
+func (r *RedisCluster) Touch(keys ...string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.Touch(keys...)
+	}
+	return ToInt64Reply(command.runBatch(len(keys), keys...))
+}
+
+//Unlink see comment in redis.go
+func (r *RedisCluster) Unlink(keys ...string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.Unlink(keys...)
+	}
+	return ToInt64Reply(command.runBatch(len(keys), keys...))
+}
+
+//Dump see comment in redis.go
+func (r *RedisCluster) Dump(key string) ([]byte, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.Dump(key)
+	}
+	return ToByteArrReply(command.run(key))
+}
+
+//Restore see comment in redis.go
+func (r *RedisCluster) Restore(key string, ttl time.Duration, serializedValue []byte, args *RestoreArgs) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.Restore(key, ttl, serializedValue, args)
+	}
+	return ToStrReply(command.run(key))
+}
+
+//RestoreAt see comment in redis.go
+func (r *RedisCluster) RestoreAt(key string, at time.Time, serializedValue []byte, args *RestoreArgs) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.RestoreAt(key, at, serializedValue, args)
+	}
+	return ToStrReply(command.run(key))
+}
+
+//Migrate see comment in redis.go
+func (r *RedisCluster) Migrate(host string, port int, destinationDB int, timeout int, params *MigrateParams, keys ...string) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.Migrate(host, port, destinationDB, timeout, params, keys...)
+	}
+	return ToStrReply(command.runBatch(len(keys), keys...))
+}
+
+//ExpireTime see comment in redis.go
+func (r *RedisCluster) ExpireTime(key string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ExpireTime(key)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//PExpireTime see comment in redis.go
+func (r *RedisCluster) PExpireTime(key string) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.PExpireTime(key)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//ExpireWithCondition see comment in redis.go
+func (r *RedisCluster) ExpireWithCondition(key string, expiration time.Duration, condition *ExpireCondition) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ExpireWithCondition(key, expiration, condition)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//PExpireWithCondition see comment in redis.go
+func (r *RedisCluster) PExpireWithCondition(key string, expiration time.Duration, condition *ExpireCondition) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.PExpireWithCondition(key, expiration, condition)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//ExpireAtWithCondition see comment in redis.go
+func (r *RedisCluster) ExpireAtWithCondition(key string, at time.Time, condition *ExpireCondition) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ExpireAtWithCondition(key, at, condition)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//PExpireAtWithCondition see comment in redis.go
+func (r *RedisCluster) PExpireAtWithCondition(key string, at time.Time, condition *ExpireCondition) (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.PExpireAtWithCondition(key, at, condition)
+	}
+	return ToInt64Reply(command.run(key))
+}
+
+//</editor-fold>
+
+//<editor-fold desc="fanoutcommands">
+
+//FanOut send the command to the nodes chosen by mode,
+// the replies are returned in a map keyed by host:port of the node.
+// when some nodes failed, the replies of the others are still returned along with a *ClusterFanOutError
+func (r *RedisCluster) FanOut(mode *FanOutMode, execute func(redis *Redis) (interface{}, error)) (map[string]interface{}, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = execute
+	return command.runWithNodes(mode)
+}
+
+//Keys find all keys matching the given pattern on every master,returns the union of them.
+// when some masters failed, the keys of the others are still returned along with a *ClusterFanOutError
+func (r *RedisCluster) Keys(pattern string) ([]string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.Keys(pattern)
+	}
+	replies, err := command.runWithNodes(FanOutAllMasters)
+	if replies == nil {
+		return nil, err
+	}
+	exists := make(map[string]bool)
+	keys := make([]string, 0)
+	for _, reply := range replies {
+		for _, key := range reply.([]string) {
+			if exists[key] {
+				continue
+			}
+			exists[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys, err
+}
+
+//RandomKey return a random key of the cluster, the masters are tried in random order until one of them has a key.
+// the failed masters are skipped, their errors are returned as a *ClusterFanOutError when no key is found
+func (r *RedisCluster) RandomKey() (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.RandomKey()
+	}
+	pools := r.connectionHandler.getMasterNodes()
+	if len(pools) == 0 {
+		return "", newNoReachableClusterNodeError("no reachable node in cluster")
+	}
+	nodeErrors := make([]*ClusterNodeError, 0)
+	for nodeKey, pool := range pools {
+		key, err := ToStrReply(command.runWithPool(pool))
+		if err != nil {
+			nodeErrors = append(nodeErrors, newClusterNodeError(nodeKey, err))
+			continue
+		}
+		if key != "" {
+			return key, nil
+		}
+	}
+	if len(nodeErrors) > 0 {
+		return "", newClusterFanOutError(nodeErrors)
+	}
+	return "", nil
+}
+
+//FlushDB clear the keys of current db on every master
+func (r *RedisCluster) FlushDB() (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.FlushDB()
+	}
+	_, err := command.runWithNodes(FanOutAllMasters)
+	if err != nil {
+		return "", err
+	}
+	return keywordOk.name, nil
+}
+
+//FlushAll clear the keys of all db on every master
+func (r *RedisCluster) FlushAll() (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.FlushAll()
+	}
+	_, err := command.runWithNodes(FanOutAllMasters)
+	if err != nil {
+		return "", err
+	}
+	return keywordOk.name, nil
+}
+
+//DbSize return the key count of the whole cluster, sum of the key count on every master.
+// when some masters failed, the sum of the others is still returned along with a *ClusterFanOutError
+func (r *RedisCluster) DbSize() (int64, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.DbSize()
+	}
+	replies, err := command.runWithNodes(FanOutAllMasters)
+	var total int64
+	for _, reply := range replies {
+		total += reply.(int64)
+	}
+	return total, err
+}
+
+//Info return the info of every node, the map key is host:port of the node.
+// when some nodes failed, the info of the others is still returned along with a *ClusterFanOutError
+func (r *RedisCluster) Info(section ...string) (map[string]string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.Info(section...)
+	}
+	replies, err := command.runWithNodes(FanOutAllNodes)
+	if replies == nil {
+		return nil, err
+	}
+	infos := make(map[string]string, len(replies))
+	for node, reply := range replies {
+		infos[node] = reply.(string)
+	}
+	return infos, err
+}
+
+//ConfigSet set the configuration parameter on every node
+func (r *RedisCluster) ConfigSet(parameter, value string) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ConfigSet(parameter, value)
+	}
+	_, err := command.runWithNodes(FanOutAllNodes)
+	if err != nil {
+		return "", err
+	}
+	return keywordOk.name, nil
+}
+
+//ScriptLoadAll load the script into the script cache of every master,returns the SHA1 digest of the script
+func (r *RedisCluster) ScriptLoadAll(script string) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ScriptLoad(script)
+	}
+	replies, err := command.runWithNodes(FanOutAllMasters)
+	if err != nil {
+		return "", err
+	}
+	sha := ""
+	for _, reply := range replies {
+		sha = reply.(string)
+	}
+	return sha, nil
+}
+
+//ScriptFlush flush the script cache of every master
+func (r *RedisCluster) ScriptFlush() (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ScriptFlush()
+	}
+	_, err := command.runWithNodes(FanOutAllMasters)
+	if err != nil {
+		return "", err
+	}
+	return keywordOk.name, nil
+}
+
+//FunctionLoadAll load the library of the code on every master, returns the name of the library
+func (r *RedisCluster) FunctionLoadAll(code string) (string, error) {
+	return r.functionLoadAll(code, false)
+}
+
+//FunctionLoadReplaceAll load the library of the code on every master and replace the existing one,
+// returns the name of the library
+func (r *RedisCluster) FunctionLoadReplaceAll(code string) (string, error) {
+	return r.functionLoadAll(code, true)
+}
+
+func (r *RedisCluster) functionLoadAll(code string, replace bool) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		if replace {
+			return redis.FunctionLoadReplace(code)
+		}
+		return redis.FunctionLoad(code)
+	}
+	replies, err := command.runWithNodes(FanOutAllMasters)
+	if err != nil {
+		return "", err
+	}
+	name := ""
+	for _, reply := range replies {
+		name = reply.(string)
+	}
+	return name, nil
+}
+
+//FunctionDeleteAll delete the library on every master
+func (r *RedisCluster) FunctionDeleteAll(libraryName string) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.FunctionDelete(libraryName)
+	}
+	_, err := command.runWithNodes(FanOutAllMasters)
+	if err != nil {
+		return "", err
+	}
+	return keywordOk.name, nil
+}
+
+//FunctionFlushAll delete all the libraries on every master
+func (r *RedisCluster) FunctionFlushAll(mode *FlushMode) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.FunctionFlush(mode)
+	}
+	_, err := command.runWithNodes(FanOutAllMasters)
+	if err != nil {
+		return "", err
+	}
+	return keywordOk.name, nil
+}
+
+//ACLSetUserAll create or modify the user on every node, replicas included as ACL is not replicated
+func (r *RedisCluster) ACLSetUserAll(username string, rules *ACLRules) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ACLSetUser(username, rules)
+	}
+	_, err := command.runWithNodes(FanOutAllNodes)
+	if err != nil {
+		return "", err
+	}
+	return keywordOk.name, nil
+}
+
+//ACLDelUserAll delete the users on every node, replicas included as ACL is not replicated
+func (r *RedisCluster) ACLDelUserAll(usernames ...string) (string, error) {
+	command := newRedisClusterCommand(r.MaxAttempts, r.MaxRedirects, r.connectionHandler)
+	command.execute = func(redis *Redis) (interface{}, error) {
+		return redis.ACLDelUser(usernames...)
+	}
+	_, err := command.runWithNodes(FanOutAllNodes)
+	if err != nil {
+		return "", err
+	}
+	return keywordOk.name, nil
+}
+
+//</editor-fold>
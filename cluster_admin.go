@@ -0,0 +1,1044 @@
+package godis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//ClusterAdminNode the commands of one cluster node used by ClusterAdmin, *Redis implements it,
+// a fake implementation can be used to test the workflows without a real cluster
+type ClusterAdminNode interface {
+	ClusterNodes() (string, error)
+	ClusterMeet(ip string, port int) (string, error)
+	ClusterAddSlots(slots ...int) (string, error)
+	ClusterSetSlotNode(slot int, nodeID string) (string, error)
+	ClusterSetSlotMigrating(slot int, nodeID string) (string, error)
+	ClusterSetSlotImporting(slot int, nodeID string) (string, error)
+	ClusterSetSlotStable(slot int) (string, error)
+	ClusterGetKeysInSlot(slot int, count int) ([]string, error)
+	ClusterCountKeysInSlot(slot int) (int64, error)
+	ClusterReplicate(nodeID string) (string, error)
+	ClusterForget(nodeID string) (string, error)
+	ClusterReset(resetType Reset) (string, error)
+	Migrate(host string, port int, destinationDB int, timeout int, params *MigrateParams, keys ...string) (string, error)
+	Close() error
+}
+
+//ClusterAdminProgress progress of a ClusterAdmin operation, reported before every step
+type ClusterAdminProgress struct {
+	Operation string //create, add-node, del-node, reshard, rebalance or fix
+	Step      string //description of the step
+	Done      int    //how many slots have been moved
+	Total     int    //how many slots will be moved, 0 if the operation doesn't move slots
+	DryRun    bool   //the step is only reported, the cluster is not changed
+}
+
+//ClusterAdminOption ClusterAdmin options
+type ClusterAdminOption struct {
+	ConnectionTimeout time.Duration                                         //redis connect timeout
+	SoTimeout         time.Duration                                         //redis read timeout
+	Password          string                                                //cluster redis password
+	MigrateTimeout    int                                                   //timeout of MIGRATE in milliseconds, default is 60000
+	MigrateBatch      int                                                   //how many keys are moved by one MIGRATE, default is 10
+	MigrateReplace    bool                                                  //replace the existing keys of the target node when moving keys
+	WaitTimeout       time.Duration                                         //how long to wait for the nodes to agree on the configuration, default is 30 seconds
+	DryRun            bool                                                  //only report the steps, the cluster is not changed
+	Progress          func(progress *ClusterAdminProgress)                  //called before every step
+	Dialer            func(host string, port int) (ClusterAdminNode, error) //connect to a node, default connects by NewRedis
+}
+
+//ClusterAdmin cluster administration workflows like redis-cli --cluster,
+// every operation connects to the nodes, changes the cluster and closes the connections
+type ClusterAdmin struct {
+	option *ClusterAdminOption
+}
+
+//NewClusterAdmin create a cluster admin tool
+func NewClusterAdmin(option *ClusterAdminOption) *ClusterAdmin {
+	if option == nil {
+		option = &ClusterAdminOption{}
+	}
+	if option.ConnectionTimeout == 0 {
+		option.ConnectionTimeout = 5 * time.Second
+	}
+	if option.SoTimeout == 0 {
+		option.SoTimeout = 5 * time.Second
+	}
+	if option.MigrateTimeout <= 0 {
+		option.MigrateTimeout = 60000
+	}
+	if option.MigrateBatch <= 0 {
+		option.MigrateBatch = 10
+	}
+	if option.WaitTimeout == 0 {
+		option.WaitTimeout = 30 * time.Second
+	}
+	return &ClusterAdmin{option: option}
+}
+
+//ReshardArgs reshard arguments
+type ReshardArgs struct {
+	From  []string //ids of the source masters, empty means all the masters except To
+	To    string   //id of the target master
+	Slots int      //how many slots are moved
+}
+
+//RebalanceArgs rebalance arguments
+type RebalanceArgs struct {
+	Weights         map[string]float64 //weights of the masters by id, default weight is 1, weight 0 makes the master empty
+	UseEmptyMasters bool               //assign slots to the masters which have no slots
+	Threshold       float64            //percentage of the imbalance which is allowed, default is 2
+}
+
+//Create create a cluster from empty nodes, the first len(nodes)/(replicas+1) nodes become masters
+// and the slots are distributed evenly, the other nodes become replicas of the masters
+func (a *ClusterAdmin) Create(nodes []string, replicas int) error {
+	if replicas < 0 {
+		return newClusterOperationError("replicas can't be negative")
+	}
+	masterCount := len(nodes) / (replicas + 1)
+	if masterCount < 3 {
+		return newClusterOperationError("at least 3 master nodes are required to create a cluster")
+	}
+	if err := checkNodeAddrs(nodes...); err != nil {
+		return err
+	}
+	s := a.newSession("create")
+	defer s.close()
+	for _, addr := range nodes {
+		node, err := s.connectEmptyNode(addr)
+		if err != nil {
+			return err
+		}
+		s.nodes = append(s.nodes, node)
+	}
+	masters := s.nodes[:masterCount]
+	slotsPerNode := float64(clusterSlotCount) / float64(masterCount)
+	first := 0
+	cursor := 0.0
+	for i, master := range masters {
+		last := int(math.Round(cursor + slotsPerNode - 1))
+		if last > clusterSlotCount-1 || i == masterCount-1 {
+			last = clusterSlotCount - 1
+		}
+		if last < first {
+			last = first
+		}
+		slots := make([]int, 0, last-first+1)
+		for slot := first; slot <= last; slot++ {
+			slots = append(slots, slot)
+		}
+		node := master
+		err := s.step(fmt.Sprintf("assigning slots %d-%d to %s", first, last, node.addr), func() error {
+			_, err := node.conn.ClusterAddSlots(slots...)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		node.addSlots(slots...)
+		first = last + 1
+		cursor += slotsPerNode
+	}
+	entry := s.nodes[0]
+	for _, n := range s.nodes[1:] {
+		node := n
+		err := s.step(fmt.Sprintf("meeting %s with %s", node.addr, entry.addr), func() error {
+			_, err := node.conn.ClusterMeet(entry.host, entry.port)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := s.waitConsistent(); err != nil {
+		return err
+	}
+	for i, n := range s.nodes[masterCount:] {
+		node := n
+		master := masters[i%masterCount]
+		if err := s.replicate(node, master); err != nil {
+			return err
+		}
+	}
+	return s.waitConsistent()
+}
+
+//AddNode add an empty node to the cluster as a master without slots, use Reshard or Rebalance to assign slots to it
+func (a *ClusterAdmin) AddNode(newNode, existingNode string) error {
+	return a.addNode(newNode, existingNode, false, "")
+}
+
+//AddReplica add an empty node to the cluster as a replica of the master,
+// empty masterID means the master which has the fewest replicas
+func (a *ClusterAdmin) AddReplica(newNode, existingNode, masterID string) error {
+	return a.addNode(newNode, existingNode, true, masterID)
+}
+
+func (a *ClusterAdmin) addNode(newNode, existingNode string, replica bool, masterID string) error {
+	if err := checkNodeAddrs(newNode); err != nil {
+		return err
+	}
+	s, err := a.load("add-node", existingNode)
+	if err != nil {
+		return err
+	}
+	defer s.close()
+	var master *clusterAdminNodeState
+	if replica {
+		if masterID == "" {
+			master = s.masterWithFewestReplicas(nil)
+		} else {
+			master = s.node(masterID)
+		}
+		if master == nil || !master.master {
+			return newClusterOperationError("master " + masterID + " is not found in the cluster")
+		}
+	}
+	node, err := s.connectEmptyNode(newNode)
+	if err != nil {
+		return err
+	}
+	entry := s.nodes[0]
+	err = s.step(fmt.Sprintf("meeting %s with %s", node.addr, entry.addr), func() error {
+		_, err := node.conn.ClusterMeet(entry.host, entry.port)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.nodes = append(s.nodes, node)
+	if err := s.waitConsistent(); err != nil {
+		return err
+	}
+	if !replica {
+		return nil
+	}
+	if err := s.replicate(node, master); err != nil {
+		return err
+	}
+	return s.waitConsistent()
+}
+
+//DelNode remove the node from the cluster, the node must have no slots,
+// the replicas of the node are moved to other masters, and the node is reset
+func (a *ClusterAdmin) DelNode(existingNode, nodeID string) error {
+	s, err := a.load("del-node", existingNode)
+	if err != nil {
+		return err
+	}
+	defer s.close()
+	target := s.node(nodeID)
+	if target == nil {
+		return newClusterOperationError("node " + nodeID + " is not found in the cluster")
+	}
+	if len(target.slots) > 0 {
+		return newClusterOperationError("node " + target.addr + " is not empty, reshard its slots away first")
+	}
+	for _, n := range s.nodes {
+		if n == target {
+			continue
+		}
+		node := n
+		if node.masterID == target.id {
+			master := s.masterWithFewestReplicas(target)
+			if master == nil {
+				return newClusterOperationError("no master left for the replica " + node.addr)
+			}
+			if err := s.replicate(node, master); err != nil {
+				return err
+			}
+		}
+		err := s.step(fmt.Sprintf("forgetting %s on %s", target.addr, node.addr), func() error {
+			_, err := node.conn.ClusterForget(target.id)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return s.step("resetting "+target.addr, func() error {
+		_, err := target.conn.ClusterReset(*ResetSoft)
+		return err
+	})
+}
+
+//Reshard move the slots from the source masters to the target master with their keys,
+// the slots are taken from the sources in proportion to the slots they have
+func (a *ClusterAdmin) Reshard(existingNode string, args *ReshardArgs) error {
+	if args == nil || args.To == "" {
+		return newDataError("the target master of reshard is required")
+	}
+	if args.Slots <= 0 || args.Slots > clusterSlotCount {
+		return newDataError("the number of slots to reshard must be between 1 and " + strconv.Itoa(clusterSlotCount))
+	}
+	s, err := a.load("reshard", existingNode)
+	if err != nil {
+		return err
+	}
+	defer s.close()
+	if err := s.checkOpenSlots(); err != nil {
+		return err
+	}
+	target := s.node(args.To)
+	if target == nil || !target.master {
+		return newClusterOperationError("target master " + args.To + " is not found in the cluster")
+	}
+	sources := make([]*clusterAdminNodeState, 0)
+	if len(args.From) == 0 {
+		for _, master := range s.masters() {
+			if master != target && len(master.slots) > 0 {
+				sources = append(sources, master)
+			}
+		}
+	} else {
+		for _, id := range args.From {
+			source := s.node(id)
+			if source == nil || !source.master {
+				return newClusterOperationError("source master " + id + " is not found in the cluster")
+			}
+			if source == target {
+				return newClusterOperationError("target master can't be a source master")
+			}
+			sources = append(sources, source)
+		}
+	}
+	owned := 0
+	for _, source := range sources {
+		owned += len(source.slots)
+	}
+	if owned < args.Slots {
+		return newClusterOperationError(fmt.Sprintf("the source masters have %d slots, fewer than the %d slots to reshard", owned, args.Slots))
+	}
+	moves := computeReshardTable(sources, args.Slots)
+	s.total = len(moves)
+	for _, move := range moves {
+		if err := s.moveSlot(move.source, target, move.slot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Rebalance move the slots between the masters, so every master has the slots in proportion to its weight
+func (a *ClusterAdmin) Rebalance(existingNode string, args *RebalanceArgs) error {
+	if args == nil {
+		args = &RebalanceArgs{}
+	}
+	threshold := args.Threshold
+	if threshold <= 0 {
+		threshold = 2
+	}
+	s, err := a.load("rebalance", existingNode)
+	if err != nil {
+		return err
+	}
+	defer s.close()
+	if err := s.checkOpenSlots(); err != nil {
+		return err
+	}
+	type balanceNode struct {
+		node    *clusterAdminNodeState
+		weight  float64
+		balance int
+	}
+	nodes := make([]*balanceNode, 0)
+	totalWeight := 0.0
+	for _, master := range s.masters() {
+		weight, ok := args.Weights[master.id]
+		if !ok {
+			weight = 1
+		}
+		if !ok && !args.UseEmptyMasters && len(master.slots) == 0 {
+			continue
+		}
+		nodes = append(nodes, &balanceNode{node: master, weight: weight})
+		totalWeight += weight
+	}
+	if totalWeight <= 0 {
+		return newClusterOperationError("total weight of the masters must be positive")
+	}
+	overThreshold := false
+	totalBalance := 0
+	for _, n := range nodes {
+		expected := int(float64(clusterSlotCount) / totalWeight * n.weight)
+		n.balance = len(n.node.slots) - expected
+		totalBalance += n.balance
+		if expected == 0 {
+			overThreshold = overThreshold || len(n.node.slots) > 0
+		} else if math.Abs(100-100/float64(expected)*float64(len(n.node.slots))) > threshold {
+			overThreshold = true
+		}
+	}
+	if !overThreshold {
+		return s.step("no rebalance is needed, all the masters are within the threshold", func() error {
+			return nil
+		})
+	}
+	//expected slots are rounded down, give the remaining slots to the masters which need slots
+	for totalBalance > 0 {
+		for _, n := range nodes {
+			if n.balance <= 0 && totalBalance > 0 {
+				n.balance--
+				totalBalance--
+			}
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].balance < nodes[j].balance
+	})
+	for _, n := range nodes {
+		if n.balance > 0 {
+			s.total += n.balance
+		}
+	}
+	dst, src := 0, len(nodes)-1
+	for dst < src {
+		receiver, sender := nodes[dst], nodes[src]
+		count := -receiver.balance
+		if sender.balance < count {
+			count = sender.balance
+		}
+		if count <= 0 {
+			break
+		}
+		for _, move := range computeReshardTable([]*clusterAdminNodeState{sender.node}, count) {
+			if err := s.moveSlot(move.source, receiver.node, move.slot); err != nil {
+				return err
+			}
+		}
+		receiver.balance += count
+		sender.balance -= count
+		if receiver.balance == 0 {
+			dst++
+		}
+		if sender.balance == 0 {
+			src--
+		}
+	}
+	return nil
+}
+
+//Fix close the open slots left by an interrupted migration, and assign the slots which are not covered by any master
+func (a *ClusterAdmin) Fix(existingNode string) error {
+	s, err := a.load("fix", existingNode)
+	if err != nil {
+		return err
+	}
+	defer s.close()
+	if err := s.fixOpenSlots(); err != nil {
+		return err
+	}
+	return s.fixUncoveredSlots()
+}
+
+//checkNodeAddrs check the addresses given by the caller are host:port
+func checkNodeAddrs(addrs ...string) error {
+	for _, addr := range addrs {
+		if _, _, ok := splitNodeKey(addr); !ok {
+			return newDataError("invalid node address, host:port is expected: " + addr)
+		}
+	}
+	return nil
+}
+
+func (a *ClusterAdmin) dial(addr string) (ClusterAdminNode, error) {
+	host, port, ok := splitNodeKey(addr)
+	if !ok {
+		return nil, newDataError("invalid node address, host:port is expected: " + addr)
+	}
+	if a.option.Dialer != nil {
+		return a.option.Dialer(host, port)
+	}
+	redis := NewRedis(&Option{
+		Host:              host,
+		Port:              port,
+		ConnectionTimeout: a.option.ConnectionTimeout,
+		SoTimeout:         a.option.SoTimeout,
+		Password:          a.option.Password,
+	})
+	if err := redis.Connect(); err != nil {
+		return nil, err
+	}
+	return redis, nil
+}
+
+func (a *ClusterAdmin) newSession(operation string) *clusterAdminSession {
+	return &clusterAdminSession{admin: a, operation: operation, nodes: make([]*clusterAdminNodeState, 0)}
+}
+
+//load connect to the node and all the nodes it knows,
+// the state of every node is read from its own CLUSTER NODES, so the open slots are found
+func (a *ClusterAdmin) load(operation, existingNode string) (*clusterAdminSession, error) {
+	if err := checkNodeAddrs(existingNode); err != nil {
+		return nil, err
+	}
+	s := a.newSession(operation)
+	conn, err := a.dial(existingNode)
+	if err != nil {
+		return nil, err
+	}
+	s.conns = append(s.conns, conn)
+	text, err := conn.ClusterNodes()
+	if err != nil {
+		s.close()
+		return nil, err
+	}
+	nodes, err := ParseClusterNodes(text)
+	if err != nil {
+		s.close()
+		return nil, err
+	}
+	for _, info := range nodes {
+		if info.HasFlag("noaddr") || info.HasFlag("handshake") {
+			continue
+		}
+		if info.IsMyself() {
+			s.nodes = append([]*clusterAdminNodeState{newClusterAdminNodeState(info, existingNode, conn)}, s.nodes...)
+			continue
+		}
+		node, err := s.connect(info.Addr())
+		if err != nil {
+			s.close()
+			return nil, err
+		}
+		s.nodes = append(s.nodes, node)
+	}
+	return s, nil
+}
+
+//clusterAdminNodeState one node of the cluster, the state is changed by the operation
+type clusterAdminNodeState struct {
+	id        string
+	addr      string
+	host      string
+	port      int
+	master    bool
+	masterID  string
+	slots     map[int]bool
+	migrating map[int]string //slot -> id of the target node
+	importing map[int]string //slot -> id of the source node
+	conn      ClusterAdminNode
+}
+
+func newClusterAdminNodeState(node *ClusterNode, addr string, conn ClusterAdminNode) *clusterAdminNodeState {
+	state := &clusterAdminNodeState{
+		id:        node.ID,
+		addr:      addr,
+		master:    node.IsMaster(),
+		masterID:  node.MasterID,
+		slots:     make(map[int]bool),
+		migrating: node.Migrating,
+		importing: node.Importing,
+		conn:      conn,
+	}
+	state.host, state.port, _ = splitNodeKey(addr)
+	for _, slotRange := range node.Slots {
+		state.addSlots(slotRange.Slots()...)
+	}
+	return state
+}
+
+func (n *clusterAdminNodeState) addSlots(slots ...int) {
+	for _, slot := range slots {
+		n.slots[slot] = true
+	}
+}
+
+func (n *clusterAdminNodeState) sortedSlots() []int {
+	slots := make([]int, 0, len(n.slots))
+	for slot := range n.slots {
+		slots = append(slots, slot)
+	}
+	sort.Ints(slots)
+	return slots
+}
+
+type reshardMove struct {
+	source *clusterAdminNodeState
+	slot   int
+}
+
+//computeReshardTable take the slots from the sources in proportion to the slots they have,
+// the source which has the most slots gives the rounded up count, the others give the rounded down count,
+// the slots left by the rounding are taken from the sources in turn
+func computeReshardTable(sources []*clusterAdminNodeState, count int) []*reshardMove {
+	sorted := make([]*clusterAdminNodeState, len(sources))
+	copy(sorted, sources)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].slots) > len(sorted[j].slots)
+	})
+	total := 0
+	for _, source := range sorted {
+		total += len(source.slots)
+	}
+	moves := make([]*reshardMove, 0, count)
+	if total == 0 {
+		return moves
+	}
+	counts := make([]int, len(sorted))
+	assigned := 0
+	for i, source := range sorted {
+		n := float64(count) / float64(total) * float64(len(source.slots))
+		if i == 0 {
+			n = math.Ceil(n)
+		} else {
+			n = math.Floor(n)
+		}
+		counts[i] = int(math.Min(n, float64(len(source.slots))))
+		assigned += counts[i]
+	}
+	for assigned < count && assigned < total {
+		for i, source := range sorted {
+			if assigned < count && counts[i] < len(source.slots) {
+				counts[i]++
+				assigned++
+			}
+		}
+	}
+	for i, source := range sorted {
+		for _, slot := range source.sortedSlots()[:counts[i]] {
+			moves = append(moves, &reshardMove{source: source, slot: slot})
+		}
+	}
+	if len(moves) > count {
+		moves = moves[:count]
+	}
+	return moves
+}
+
+//clusterAdminSession the nodes and the connections of one ClusterAdmin operation
+type clusterAdminSession struct {
+	admin     *ClusterAdmin
+	operation string
+	nodes     []*clusterAdminNodeState
+	conns     []ClusterAdminNode
+	done      int
+	total     int
+}
+
+func (s *clusterAdminSession) close() {
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.conns = nil
+}
+
+//connect connect to the node and read its state from its own CLUSTER NODES
+func (s *clusterAdminSession) connect(addr string) (*clusterAdminNodeState, error) {
+	conn, err := s.admin.dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	s.conns = append(s.conns, conn)
+	text, err := conn.ClusterNodes()
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := ParseClusterNodes(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range nodes {
+		if info.IsMyself() {
+			return newClusterAdminNodeState(info, addr, conn), nil
+		}
+	}
+	return nil, newClusterOperationError("node " + addr + " doesn't report itself in CLUSTER NODES")
+}
+
+//connectEmptyNode connect to the node which must know no other nodes and have no slots
+func (s *clusterAdminSession) connectEmptyNode(addr string) (*clusterAdminNodeState, error) {
+	node, err := s.connect(addr)
+	if err != nil {
+		return nil, err
+	}
+	text, err := node.conn.ClusterNodes()
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := ParseClusterNodes(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) != 1 || len(node.slots) > 0 {
+		return nil, newClusterOperationError("node " + addr + " is not empty, it knows other nodes or has assigned slots")
+	}
+	return node, nil
+}
+
+//step report the step by the progress callback, then run it if it's not a dry run
+func (s *clusterAdminSession) step(description string, fn func() error) error {
+	option := s.admin.option
+	if option.Progress != nil {
+		option.Progress(&ClusterAdminProgress{
+			Operation: s.operation,
+			Step:      description,
+			Done:      s.done,
+			Total:     s.total,
+			DryRun:    option.DryRun,
+		})
+	}
+	if option.DryRun {
+		return nil
+	}
+	return fn()
+}
+
+func (s *clusterAdminSession) node(id string) *clusterAdminNodeState {
+	for _, n := range s.nodes {
+		if n.id == id {
+			return n
+		}
+	}
+	return nil
+}
+
+func (s *clusterAdminSession) masters() []*clusterAdminNodeState {
+	masters := make([]*clusterAdminNodeState, 0)
+	for _, n := range s.nodes {
+		if n.master {
+			masters = append(masters, n)
+		}
+	}
+	return masters
+}
+
+func (s *clusterAdminSession) masterWithFewestReplicas(exclude *clusterAdminNodeState) *clusterAdminNodeState {
+	var result *clusterAdminNodeState
+	fewest := 0
+	for _, master := range s.masters() {
+		if master == exclude {
+			continue
+		}
+		count := 0
+		for _, n := range s.nodes {
+			if n.masterID == master.id {
+				count++
+			}
+		}
+		if result == nil || count < fewest {
+			result = master
+			fewest = count
+		}
+	}
+	return result
+}
+
+func (s *clusterAdminSession) slotOwners() []*clusterAdminNodeState {
+	owners := make([]*clusterAdminNodeState, clusterSlotCount)
+	for _, master := range s.masters() {
+		for slot := range master.slots {
+			owners[slot] = master
+		}
+	}
+	return owners
+}
+
+func (s *clusterAdminSession) replicate(replica, master *clusterAdminNodeState) error {
+	err := s.step(fmt.Sprintf("replicating %s by %s", master.addr, replica.addr), func() error {
+		_, err := replica.conn.ClusterReplicate(master.id)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	replica.master = false
+	replica.masterID = master.id
+	return nil
+}
+
+func (s *clusterAdminSession) checkOpenSlots() error {
+	for _, n := range s.nodes {
+		for slot := range n.migrating {
+			return newClusterOperationError(fmt.Sprintf("slot %d is migrating on %s, run Fix first", slot, n.addr))
+		}
+		for slot := range n.importing {
+			return newClusterOperationError(fmt.Sprintf("slot %d is importing on %s, run Fix first", slot, n.addr))
+		}
+	}
+	return nil
+}
+
+//moveSlot migrate the slot like redis-cli: set importing on the target and migrating on the source,
+// move the keys by MIGRATE, then assign the slot to the target on all the masters
+func (s *clusterAdminSession) moveSlot(source, target *clusterAdminNodeState, slot int) error {
+	err := s.step(fmt.Sprintf("moving slot %d from %s to %s", slot, source.addr, target.addr), func() error {
+		if _, err := target.conn.ClusterSetSlotImporting(slot, source.id); err != nil {
+			return err
+		}
+		if _, err := source.conn.ClusterSetSlotMigrating(slot, target.id); err != nil {
+			return err
+		}
+		if err := s.moveSlotKeys(source, target, slot); err != nil {
+			return err
+		}
+		return s.setSlotNode(slot, source, target)
+	})
+	if err != nil {
+		return err
+	}
+	delete(source.slots, slot)
+	target.slots[slot] = true
+	s.done++
+	return nil
+}
+
+func (s *clusterAdminSession) moveSlotKeys(source, target *clusterAdminNodeState, slot int) error {
+	option := s.admin.option
+	params := NewMigrateParams()
+	if option.MigrateReplace {
+		params.Replace()
+	}
+	if option.Password != "" {
+		params.Auth(option.Password)
+	}
+	for {
+		keys, err := source.conn.ClusterGetKeysInSlot(slot, option.MigrateBatch)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		if _, err := source.conn.Migrate(target.host, target.port, 0, option.MigrateTimeout, params, keys...); err != nil {
+			return err
+		}
+	}
+}
+
+//setSlotNode assign the slot to the target, the target is informed first, then the source, then the other masters
+func (s *clusterAdminSession) setSlotNode(slot int, source, target *clusterAdminNodeState) error {
+	nodes := []*clusterAdminNodeState{target}
+	if source != nil {
+		nodes = append(nodes, source)
+	}
+	for _, master := range s.masters() {
+		if master != target && master != source {
+			nodes = append(nodes, master)
+		}
+	}
+	for _, n := range nodes {
+		if _, err := n.conn.ClusterSetSlotNode(slot, target.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//fixOpenSlots close the slots which are left in migrating or importing state
+func (s *clusterAdminSession) fixOpenSlots() error {
+	open := make(map[int]bool)
+	for _, n := range s.nodes {
+		for slot := range n.migrating {
+			open[slot] = true
+		}
+		for slot := range n.importing {
+			open[slot] = true
+		}
+	}
+	slots := make([]int, 0, len(open))
+	for slot := range open {
+		slots = append(slots, slot)
+	}
+	sort.Ints(slots)
+	owners := s.slotOwners()
+	for _, slot := range slots {
+		owner := owners[slot]
+		migrating := make([]*clusterAdminNodeState, 0)
+		importing := make([]*clusterAdminNodeState, 0)
+		for _, n := range s.nodes {
+			if _, ok := n.migrating[slot]; ok {
+				migrating = append(migrating, n)
+			}
+			if _, ok := n.importing[slot]; ok {
+				importing = append(importing, n)
+			}
+		}
+		var err error
+		switch {
+		case owner != nil && len(migrating) == 1 && migrating[0] == owner && len(importing) == 1:
+			target := importing[0]
+			err = s.step(fmt.Sprintf("finishing the migration of slot %d from %s to %s", slot, owner.addr, target.addr), func() error {
+				if err := s.moveSlotKeys(owner, target, slot); err != nil {
+					return err
+				}
+				return s.setSlotNode(slot, owner, target)
+			})
+			if err == nil {
+				delete(owner.slots, slot)
+				target.slots[slot] = true
+			}
+		case owner != nil && len(migrating) == 0 && len(importing) > 0:
+			for _, n := range importing {
+				node := n
+				err = s.step(fmt.Sprintf("moving the keys of slot %d from %s back to %s", slot, node.addr, owner.addr), func() error {
+					if err := s.moveSlotKeys(node, owner, slot); err != nil {
+						return err
+					}
+					_, err := node.conn.ClusterSetSlotStable(slot)
+					return err
+				})
+				if err != nil {
+					break
+				}
+			}
+		default:
+			for _, n := range append(migrating, importing...) {
+				node := n
+				err = s.step(fmt.Sprintf("closing slot %d on %s", slot, node.addr), func() error {
+					_, err := node.conn.ClusterSetSlotStable(slot)
+					return err
+				})
+				if err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+		for _, n := range s.nodes {
+			delete(n.migrating, slot)
+			delete(n.importing, slot)
+		}
+	}
+	return nil
+}
+
+//fixUncoveredSlots assign the slots which are not covered by any master,
+// to the master which has the most keys of the slot, or the master which has the fewest slots if no master has keys
+func (s *clusterAdminSession) fixUncoveredSlots() error {
+	masters := s.masters()
+	if len(masters) == 0 {
+		return newClusterOperationError("no master is found in the cluster")
+	}
+	emptySlots := make(map[*clusterAdminNodeState][]int)
+	for slot, owner := range s.slotOwners() {
+		if owner != nil {
+			continue
+		}
+		var target *clusterAdminNodeState
+		var most int64
+		withKeys := make([]*clusterAdminNodeState, 0)
+		for _, master := range masters {
+			count, err := master.conn.ClusterCountKeysInSlot(slot)
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				continue
+			}
+			withKeys = append(withKeys, master)
+			if target == nil || count > most {
+				target = master
+				most = count
+			}
+		}
+		if target == nil {
+			for _, master := range masters {
+				if target == nil || len(master.slots)+len(emptySlots[master]) < len(target.slots)+len(emptySlots[target]) {
+					target = master
+				}
+			}
+			emptySlots[target] = append(emptySlots[target], slot)
+			continue
+		}
+		owner := target
+		sl := slot
+		err := s.step(fmt.Sprintf("assigning uncovered slot %d to %s", sl, owner.addr), func() error {
+			if _, err := owner.conn.ClusterAddSlots(sl); err != nil {
+				return err
+			}
+			for _, n := range withKeys {
+				if n == owner {
+					continue
+				}
+				if err := s.moveSlotKeys(n, owner, sl); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		owner.slots[sl] = true
+	}
+	for _, master := range masters {
+		slots, ok := emptySlots[master]
+		if !ok {
+			continue
+		}
+		node := master
+		err := s.step(fmt.Sprintf("assigning %d uncovered slots to %s", len(slots), node.addr), func() error {
+			_, err := node.conn.ClusterAddSlots(slots...)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		node.addSlots(slots...)
+	}
+	return nil
+}
+
+//waitConsistent wait until all the nodes know each other and agree on the slots
+func (s *clusterAdminSession) waitConsistent() error {
+	if s.admin.option.DryRun {
+		return nil
+	}
+	deadline := time.Now().Add(s.admin.option.WaitTimeout)
+	for {
+		consistent, err := s.consistent()
+		if err != nil {
+			return err
+		}
+		if consistent {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return newClusterOperationError("timeout waiting for the cluster nodes to agree on the configuration")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func (s *clusterAdminSession) consistent() (bool, error) {
+	signature := ""
+	for i, n := range s.nodes {
+		text, err := n.conn.ClusterNodes()
+		if err != nil {
+			return false, err
+		}
+		infos, err := ParseClusterNodes(text)
+		if err != nil {
+			return false, err
+		}
+		if len(infos) != len(s.nodes) {
+			return false, nil
+		}
+		lines := make([]string, 0, len(infos))
+		for _, info := range infos {
+			if info.HasFlag("handshake") {
+				return false, nil
+			}
+			parts := make([]string, 0, len(info.Slots))
+			for _, slotRange := range info.Slots {
+				parts = append(parts, strconv.Itoa(slotRange.Start)+"-"+strconv.Itoa(slotRange.End))
+			}
+			lines = append(lines, info.ID+" "+strings.Join(parts, ","))
+		}
+		sort.Strings(lines)
+		current := strings.Join(lines, "\n")
+		if i > 0 && current != signature {
+			return false, nil
+		}
+		signature = current
+	}
+	return true, nil
+}
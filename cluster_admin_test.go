@@ -0,0 +1,467 @@
+package godis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ ClusterAdminNode = (*Redis)(nil)
+
+//fakeCluster nodes of a fake cluster, the nodes share one state, so the gossip is done immediately
+type fakeCluster struct {
+	mu    sync.Mutex
+	nodes map[string]*fakeClusterNode
+	crc16 *crc16
+}
+
+func newFakeCluster(ports ...int) *fakeCluster {
+	cluster := &fakeCluster{nodes: make(map[string]*fakeClusterNode), crc16: newCRC16()}
+	for _, port := range ports {
+		node := &fakeClusterNode{
+			cluster:   cluster,
+			id:        fmt.Sprintf("%040d", port),
+			host:      "127.0.0.1",
+			port:      port,
+			peers:     make(map[string]bool),
+			slots:     make(map[int]bool),
+			migrating: make(map[int]string),
+			importing: make(map[int]string),
+			keys:      make(map[int][]string),
+		}
+		node.peers[node.id] = true
+		cluster.nodes[node.addr()] = node
+	}
+	return cluster
+}
+
+func (c *fakeCluster) admin(option *ClusterAdminOption) *ClusterAdmin {
+	if option == nil {
+		option = &ClusterAdminOption{}
+	}
+	option.Dialer = func(host string, port int) (ClusterAdminNode, error) {
+		node, ok := c.nodes[host+":"+strconv.Itoa(port)]
+		if !ok {
+			return nil, newConnectError("connection refused")
+		}
+		return node, nil
+	}
+	return NewClusterAdmin(option)
+}
+
+func (c *fakeCluster) node(port int) *fakeClusterNode {
+	return c.nodes["127.0.0.1:"+strconv.Itoa(port)]
+}
+
+func (c *fakeCluster) byID(id string) *fakeClusterNode {
+	for _, node := range c.nodes {
+		if node.id == id {
+			return node
+		}
+	}
+	return nil
+}
+
+func (c *fakeCluster) addKeys(keys ...string) {
+	for _, key := range keys {
+		slot := int(c.crc16.getStringSlot(key))
+		for _, node := range c.nodes {
+			if node.slots[slot] {
+				node.keys[slot] = append(node.keys[slot], key)
+			}
+		}
+	}
+}
+
+//owners count the slots of every node, and check every key is stored in the owner of its slot
+func (c *fakeCluster) owners(t *testing.T) map[int]int {
+	counts := make(map[int]int)
+	covered := 0
+	for _, node := range c.nodes {
+		counts[node.port] = len(node.slots)
+		covered += len(node.slots)
+		for slot, keys := range node.keys {
+			if len(keys) > 0 {
+				assert.True(t, node.slots[slot], "keys of slot %d are left on %s", slot, node.addr())
+			}
+		}
+	}
+	assert.Equal(t, clusterSlotCount, covered)
+	return counts
+}
+
+type fakeClusterNode struct {
+	cluster   *fakeCluster
+	id        string
+	host      string
+	port      int
+	peers     map[string]bool
+	masterID  string
+	slots     map[int]bool
+	migrating map[int]string
+	importing map[int]string
+	keys      map[int][]string
+}
+
+func (n *fakeClusterNode) addr() string {
+	return n.host + ":" + strconv.Itoa(n.port)
+}
+
+func (n *fakeClusterNode) ClusterNodes() (string, error) {
+	n.cluster.mu.Lock()
+	defer n.cluster.mu.Unlock()
+	ids := make([]string, 0)
+	for id := range n.peers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	lines := make([]string, 0)
+	for _, id := range ids {
+		node := n.cluster.byID(id)
+		flags := "master"
+		master := "-"
+		if node.masterID != "" {
+			flags = "slave"
+			master = node.masterID
+		}
+		if node == n {
+			flags = "myself," + flags
+		}
+		fields := []string{node.id, node.addr() + "@" + strconv.Itoa(node.port+10000), flags, master, "0", "0", "1", "connected"}
+		slots := make([]int, 0)
+		for slot := range node.slots {
+			slots = append(slots, slot)
+		}
+		sort.Ints(slots)
+		for _, slot := range slots {
+			fields = append(fields, strconv.Itoa(slot))
+		}
+		if node == n {
+			for slot, target := range node.migrating {
+				fields = append(fields, fmt.Sprintf("[%d->-%s]", slot, target))
+			}
+			for slot, source := range node.importing {
+				fields = append(fields, fmt.Sprintf("[%d-<-%s]", slot, source))
+			}
+		}
+		lines = append(lines, strings.Join(fields, " "))
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+func (n *fakeClusterNode) ClusterMeet(ip string, port int) (string, error) {
+	n.cluster.mu.Lock()
+	defer n.cluster.mu.Unlock()
+	other, ok := n.cluster.nodes[ip+":"+strconv.Itoa(port)]
+	if !ok {
+		return "", errors.New("ERR unknown node")
+	}
+	all := make(map[string]bool)
+	for id := range n.peers {
+		all[id] = true
+	}
+	for id := range other.peers {
+		all[id] = true
+	}
+	for id := range all {
+		node := n.cluster.byID(id)
+		node.peers = make(map[string]bool)
+		for peer := range all {
+			node.peers[peer] = true
+		}
+	}
+	return "OK", nil
+}
+
+func (n *fakeClusterNode) ClusterAddSlots(slots ...int) (string, error) {
+	for _, slot := range slots {
+		n.slots[slot] = true
+	}
+	return "OK", nil
+}
+
+func (n *fakeClusterNode) ClusterSetSlotNode(slot int, nodeID string) (string, error) {
+	if nodeID == n.id {
+		n.slots[slot] = true
+	} else {
+		delete(n.slots, slot)
+	}
+	delete(n.migrating, slot)
+	delete(n.importing, slot)
+	return "OK", nil
+}
+
+func (n *fakeClusterNode) ClusterSetSlotMigrating(slot int, nodeID string) (string, error) {
+	if !n.slots[slot] {
+		return "", errors.New("ERR I'm not the owner of hash slot " + strconv.Itoa(slot))
+	}
+	n.migrating[slot] = nodeID
+	return "OK", nil
+}
+
+func (n *fakeClusterNode) ClusterSetSlotImporting(slot int, nodeID string) (string, error) {
+	if n.slots[slot] {
+		return "", errors.New("ERR I'm already the owner of hash slot " + strconv.Itoa(slot))
+	}
+	n.importing[slot] = nodeID
+	return "OK", nil
+}
+
+func (n *fakeClusterNode) ClusterSetSlotStable(slot int) (string, error) {
+	delete(n.migrating, slot)
+	delete(n.importing, slot)
+	return "OK", nil
+}
+
+func (n *fakeClusterNode) ClusterGetKeysInSlot(slot int, count int) ([]string, error) {
+	keys := n.keys[slot]
+	if len(keys) > count {
+		keys = keys[:count]
+	}
+	return append([]string{}, keys...), nil
+}
+
+func (n *fakeClusterNode) ClusterCountKeysInSlot(slot int) (int64, error) {
+	return int64(len(n.keys[slot])), nil
+}
+
+func (n *fakeClusterNode) ClusterReplicate(nodeID string) (string, error) {
+	if len(n.slots) > 0 {
+		return "", errors.New("ERR To set a master the node must be empty and without assigned slots")
+	}
+	n.masterID = nodeID
+	return "OK", nil
+}
+
+func (n *fakeClusterNode) ClusterForget(nodeID string) (string, error) {
+	if nodeID == n.masterID {
+		return "", errors.New("ERR Can't forget my master!")
+	}
+	delete(n.peers, nodeID)
+	return "OK", nil
+}
+
+func (n *fakeClusterNode) ClusterReset(resetType Reset) (string, error) {
+	n.peers = map[string]bool{n.id: true}
+	n.masterID = ""
+	n.slots = make(map[int]bool)
+	return "OK", nil
+}
+
+func (n *fakeClusterNode) Migrate(host string, port int, destinationDB int, timeout int, params *MigrateParams, keys ...string) (string, error) {
+	target, ok := n.cluster.nodes[host+":"+strconv.Itoa(port)]
+	if !ok {
+		return "", newConnectError("connection refused")
+	}
+	for _, key := range keys {
+		slot := int(n.cluster.crc16.getStringSlot(key))
+		left := make([]string, 0)
+		for _, k := range n.keys[slot] {
+			if k != key {
+				left = append(left, k)
+			}
+		}
+		n.keys[slot] = left
+		target.keys[slot] = append(target.keys[slot], key)
+	}
+	return "OK", nil
+}
+
+func (n *fakeClusterNode) Close() error {
+	return nil
+}
+
+func createFakeCluster(t *testing.T) (*fakeCluster, *ClusterAdmin) {
+	cluster := newFakeCluster(7000, 7001, 7002, 7003, 7004, 7005, 7006)
+	admin := cluster.admin(nil)
+	err := admin.Create([]string{"127.0.0.1:7000", "127.0.0.1:7001", "127.0.0.1:7002", "127.0.0.1:7003", "127.0.0.1:7004", "127.0.0.1:7005"}, 1)
+	assert.Nil(t, err)
+	for i := 0; i < 1000; i++ {
+		cluster.addKeys("godis" + strconv.Itoa(i))
+	}
+	return cluster, admin
+}
+
+func TestClusterAdmin_Create(t *testing.T) {
+	cluster, _ := createFakeCluster(t)
+	counts := cluster.owners(t)
+	assert.Equal(t, 5461, counts[7000])
+	assert.Equal(t, 5462, counts[7001])
+	assert.Equal(t, 5461, counts[7002])
+	assert.Equal(t, cluster.node(7000).id, cluster.node(7003).masterID)
+	assert.Equal(t, cluster.node(7001).id, cluster.node(7004).masterID)
+	assert.Equal(t, cluster.node(7002).id, cluster.node(7005).masterID)
+	assert.Equal(t, 6, len(cluster.node(7005).peers))
+
+	err := cluster.admin(nil).Create([]string{"127.0.0.1:7000", "127.0.0.1:7001", "127.0.0.1:7002"}, 0)
+	assert.NotNil(t, err)
+	err = cluster.admin(nil).Create([]string{"127.0.0.1:7006"}, 0)
+	assert.NotNil(t, err)
+}
+
+func TestClusterAdmin_AddNodeAndRebalance(t *testing.T) {
+	cluster, admin := createFakeCluster(t)
+	err := admin.AddNode("127.0.0.1:7006", "127.0.0.1:7000")
+	assert.Nil(t, err)
+	assert.Equal(t, 7, len(cluster.node(7000).peers))
+
+	progresses := make([]*ClusterAdminProgress, 0)
+	admin = cluster.admin(&ClusterAdminOption{Progress: func(progress *ClusterAdminProgress) {
+		progresses = append(progresses, progress)
+	}})
+	err = admin.Rebalance("127.0.0.1:7000", &RebalanceArgs{UseEmptyMasters: true})
+	assert.Nil(t, err)
+	counts := cluster.owners(t)
+	for _, port := range []int{7000, 7001, 7002, 7006} {
+		assert.InDelta(t, 4096, counts[port], 1)
+	}
+	assert.Equal(t, 4096, len(progresses))
+	last := progresses[len(progresses)-1]
+	assert.Equal(t, "rebalance", last.Operation)
+	assert.Equal(t, 4095, last.Done)
+	assert.Equal(t, 4096, last.Total)
+
+	//balanced already
+	progresses = progresses[:0]
+	err = admin.Rebalance("127.0.0.1:7000", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(progresses))
+
+	//weight 0 makes the master empty
+	err = admin.Rebalance("127.0.0.1:7000", &RebalanceArgs{Weights: map[string]float64{cluster.node(7006).id: 0}})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cluster.owners(t)[7006])
+}
+
+func TestClusterAdmin_Reshard(t *testing.T) {
+	cluster, admin := createFakeCluster(t)
+	err := admin.Reshard("127.0.0.1:7000", &ReshardArgs{To: cluster.node(7000).id, Slots: 100})
+	assert.Nil(t, err)
+	counts := cluster.owners(t)
+	assert.Equal(t, 5561, counts[7000])
+	assert.Equal(t, 5411, counts[7001])
+	assert.Equal(t, 5412, counts[7002])
+
+	err = admin.Reshard("127.0.0.1:7000", &ReshardArgs{From: []string{cluster.node(7000).id}, To: cluster.node(7001).id, Slots: 10})
+	assert.Nil(t, err)
+	counts = cluster.owners(t)
+	assert.Equal(t, 5551, counts[7000])
+	assert.Equal(t, 5421, counts[7001])
+
+	err = admin.Reshard("127.0.0.1:7000", &ReshardArgs{To: cluster.node(7003).id, Slots: 10})
+	assert.NotNil(t, err)
+	err = admin.Reshard("127.0.0.1:7000", nil)
+	assert.IsType(t, &DataError{}, err)
+	err = admin.Reshard("127.0.0.1:7000", &ReshardArgs{Slots: 10})
+	assert.IsType(t, &DataError{}, err)
+	for _, slots := range []int{-1, 0, clusterSlotCount + 1} {
+		err = admin.Reshard("127.0.0.1:7000", &ReshardArgs{To: cluster.node(7001).id, Slots: slots})
+		assert.IsType(t, &DataError{}, err)
+	}
+	//the source has fewer slots than requested, nothing is moved
+	err = admin.Reshard("127.0.0.1:7000", &ReshardArgs{From: []string{cluster.node(7002).id}, To: cluster.node(7001).id, Slots: 5413})
+	assert.IsType(t, &ClusterOperationError{}, err)
+	assert.Equal(t, 5412, cluster.owners(t)[7002])
+}
+
+func TestComputeReshardTable(t *testing.T) {
+	sources := make([]*clusterAdminNodeState, 0)
+	for i := 0; i < 3; i++ {
+		source := &clusterAdminNodeState{slots: map[int]bool{}}
+		for slot := i * 3; slot < i*3+3; slot++ {
+			source.slots[slot] = true
+		}
+		sources = append(sources, source)
+	}
+	//2 slots of the rounded up source and 1 slot of the others, the slot left by the rounding is taken in turn
+	moves := computeReshardTable(sources, 5)
+	assert.Len(t, moves, 5)
+	taken := make(map[*clusterAdminNodeState]int)
+	for _, move := range moves {
+		assert.True(t, move.source.slots[move.slot])
+		taken[move.source]++
+	}
+	assert.Equal(t, map[*clusterAdminNodeState]int{sources[0]: 3, sources[1]: 1, sources[2]: 1}, taken)
+	assert.Len(t, computeReshardTable(sources, 9), 9)
+}
+
+func TestClusterAdmin_invalidAddress(t *testing.T) {
+	cluster := newFakeCluster(7000, 7001, 7002)
+	admin := cluster.admin(nil)
+	err := admin.Create([]string{"127.0.0.1:7000", "127.0.0.1:7001", "localhost"}, 0)
+	assert.IsType(t, &DataError{}, err)
+	//no node is changed when an address is invalid
+	assert.Equal(t, 0, len(cluster.node(7000).slots))
+	err = admin.AddNode("127.0.0.1", "127.0.0.1:7000")
+	assert.IsType(t, &DataError{}, err)
+	err = admin.AddReplica("127.0.0.1:7001", "127.0.0.1:port", "")
+	assert.IsType(t, &DataError{}, err)
+	err = admin.DelNode(":7000", "id")
+	assert.IsType(t, &DataError{}, err)
+	err = admin.Reshard("localhost", &ReshardArgs{To: "id", Slots: 1})
+	assert.IsType(t, &DataError{}, err)
+	err = admin.Rebalance("localhost", nil)
+	assert.IsType(t, &DataError{}, err)
+	err = admin.Fix("localhost")
+	assert.IsType(t, &DataError{}, err)
+}
+
+func TestClusterAdmin_DelNode(t *testing.T) {
+	cluster, admin := createFakeCluster(t)
+	master := cluster.node(7002)
+	err := admin.DelNode("127.0.0.1:7000", master.id)
+	assert.NotNil(t, err)
+
+	err = admin.Reshard("127.0.0.1:7000", &ReshardArgs{From: []string{master.id}, To: cluster.node(7000).id, Slots: len(master.slots)})
+	assert.Nil(t, err)
+	err = admin.DelNode("127.0.0.1:7000", master.id)
+	assert.Nil(t, err)
+	for _, port := range []int{7000, 7001, 7003, 7004, 7005} {
+		assert.False(t, cluster.node(port).peers[master.id])
+	}
+	assert.NotEqual(t, master.id, cluster.node(7005).masterID)
+	assert.Equal(t, 1, len(master.peers))
+	cluster.owners(t)
+}
+
+func TestClusterAdmin_Fix(t *testing.T) {
+	cluster, admin := createFakeCluster(t)
+	source, target := cluster.node(7000), cluster.node(7001)
+	//interrupted migration of slot 0, and the slot of godis2 owned by 7002 is lost with its keys on 7001
+	target.importing[0] = source.id
+	source.migrating[0] = target.id
+	lost := int(cluster.crc16.getStringSlot("godis2"))
+	owner := cluster.node(7002)
+	assert.True(t, owner.slots[lost])
+	delete(owner.slots, lost)
+	target.keys[lost] = owner.keys[lost]
+	owner.keys[lost] = nil
+
+	err := admin.Reshard("127.0.0.1:7000", &ReshardArgs{To: source.id, Slots: 1})
+	assert.NotNil(t, err)
+	err = admin.Fix("127.0.0.1:7000")
+	assert.Nil(t, err)
+	assert.True(t, target.slots[0])
+	assert.Equal(t, 0, len(source.migrating)+len(target.importing))
+	assert.True(t, target.slots[lost])
+	cluster.owners(t)
+}
+
+func TestClusterAdmin_DryRun(t *testing.T) {
+	cluster, _ := createFakeCluster(t)
+	steps := 0
+	admin := cluster.admin(&ClusterAdminOption{DryRun: true, Progress: func(progress *ClusterAdminProgress) {
+		assert.True(t, progress.DryRun)
+		steps++
+	}})
+	err := admin.Reshard("127.0.0.1:7000", &ReshardArgs{To: cluster.node(7000).id, Slots: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, 100, steps)
+	assert.Equal(t, 5461, cluster.owners(t)[7000])
+}
@@ -0,0 +1,100 @@
+package godis
+
+import (
+	"testing"
+	"time"
+)
+
+func newBenchClusterInfoCache() *redisClusterInfoCache {
+	cache := newRedisClusterInfoCache(time.Second, time.Second, "", nil)
+	cache.applyClusterView(testClusterView([]interface{}{
+		clusterSlotsReply(0, 5460, 7000, 7003),
+		clusterSlotsReply(5461, 10922, 7001, 7004),
+		clusterSlotsReply(10923, 16383, 7002, 7005),
+	}), false)
+	return cache
+}
+
+func BenchmarkRedisClusterInfoCache_getSlotPool(b *testing.B) {
+	cache := newBenchClusterInfoCache()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.getSlotPool(i % clusterSlotCount)
+	}
+}
+
+func BenchmarkRedisClusterInfoCache_getSlotPoolParallel(b *testing.B) {
+	cache := newBenchClusterInfoCache()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.getSlotPool(i % clusterSlotCount)
+			i++
+		}
+	})
+}
+
+func BenchmarkRedisClusterInfoCache_getSlotPoolDuringRefresh(b *testing.B) {
+	cache := newBenchClusterInfoCache()
+	views := []*clusterView{
+		testClusterView([]interface{}{
+			clusterSlotsReply(0, 5460, 7003, 7000),
+			clusterSlotsReply(5461, 10922, 7001, 7004),
+			clusterSlotsReply(10923, 16383, 7002, 7005),
+		}),
+		testClusterView([]interface{}{
+			clusterSlotsReply(0, 5460, 7000, 7003),
+			clusterSlotsReply(5461, 10922, 7001, 7004),
+			clusterSlotsReply(10923, 16383, 7002, 7005),
+		}),
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			cache.wLock.Lock()
+			cache.applyClusterView(views[i%2], false)
+			cache.wLock.Unlock()
+		}
+	}()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if cache.getSlotPool(i%clusterSlotCount) == nil {
+				b.Error("slot is not assigned during refresh")
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkRedisClusterInfoCache_applyClusterView(b *testing.B) {
+	cache := newBenchClusterInfoCache()
+	views := []*clusterView{
+		testClusterView([]interface{}{
+			clusterSlotsReply(0, 5460, 7003, 7000),
+			clusterSlotsReply(5461, 10922, 7001, 7004),
+			clusterSlotsReply(10923, 16383, 7002, 7005),
+		}),
+		testClusterView([]interface{}{
+			clusterSlotsReply(0, 5460, 7000, 7003),
+			clusterSlotsReply(5461, 10922, 7001, 7004),
+			clusterSlotsReply(10923, 16383, 7002, 7005),
+		}),
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.applyClusterView(views[i%2], false)
+	}
+}
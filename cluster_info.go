@@ -0,0 +1,373 @@
+package godis
+
+import (
+	"strconv"
+	"strings"
+)
+
+//ClusterNode one node of the reply of CLUSTER NODES
+type ClusterNode struct {
+	ID          string            //node id, 40 characters
+	Host        string            //ip of the node, empty when the node has no address
+	Port        int               //client port
+	BusPort     int               //cluster bus port, 0 for redis before 4.0
+	Hostname    string            //announced hostname, redis 7.0+
+	TLSPort     int               //tls port, when the cluster is running both plain and tls ports, redis 7.2+
+	Aux         map[string]string //auxiliary fields such as shard-id, redis 7.2+
+	Flags       []string          //myself, master, slave, fail?, fail, handshake, noaddr, nofailover, noflags
+	MasterID    string            //id of the master if the node is a replica, empty for masters
+	PingSent    int64             //milliseconds unix time the current active ping was sent, 0 if there are no pending pings
+	PongRecv    int64             //milliseconds unix time the last pong was received
+	ConfigEpoch int64             //configuration epoch of the node, or of its master if the node is a replica
+	LinkState   string            //connected or disconnected
+	Slots       []*SlotRange      //slots served by the node
+	Migrating   map[int]string    //slot -> id of the node the slot is migrating to, only reported for myself
+	Importing   map[int]string    //slot -> id of the node the slot is importing from, only reported for myself
+}
+
+//Addr host:port of the node
+func (n *ClusterNode) Addr() string {
+	return n.Host + ":" + strconv.Itoa(n.Port)
+}
+
+//HasFlag whether the node has the flag
+func (n *ClusterNode) HasFlag(flag string) bool {
+	for _, f := range n.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+//IsMyself whether the node is the node which replied CLUSTER NODES
+func (n *ClusterNode) IsMyself() bool {
+	return n.HasFlag("myself")
+}
+
+//IsMaster whether the node is a master
+func (n *ClusterNode) IsMaster() bool {
+	return n.HasFlag("master")
+}
+
+//IsReplica whether the node is a replica
+func (n *ClusterNode) IsReplica() bool {
+	return n.HasFlag("slave") || n.HasFlag("replica")
+}
+
+//IsFailed whether the node is in fail or pfail state
+func (n *ClusterNode) IsFailed() bool {
+	return n.HasFlag("fail") || n.HasFlag("fail?")
+}
+
+//Connected whether the link to the node is connected
+func (n *ClusterNode) Connected() bool {
+	return n.LinkState == "connected"
+}
+
+//SlotCount how many slots are served by the node
+func (n *ClusterNode) SlotCount() int {
+	count := 0
+	for _, slotRange := range n.Slots {
+		count += slotRange.End - slotRange.Start + 1
+	}
+	return count
+}
+
+//ClusterSlotNode a node which serves a slot range in the reply of CLUSTER SLOTS
+type ClusterSlotNode struct {
+	Host     string            //preferred endpoint of the node, ip by default
+	Port     int               //client port, the tls port when tls-cluster is enabled
+	ID       string            //node id, redis 4.0+
+	Hostname string            //announced hostname, redis 7.0+
+	Metadata map[string]string //networking metadata, redis 7.0+
+}
+
+//Addr host:port of the node
+func (n *ClusterSlotNode) Addr() string {
+	return n.Host + ":" + strconv.Itoa(n.Port)
+}
+
+//SlotRange a range of slots,
+// Master and Replicas are only set when the range is parsed from CLUSTER SLOTS
+type SlotRange struct {
+	Start    int
+	End      int
+	Master   *ClusterSlotNode
+	Replicas []*ClusterSlotNode
+}
+
+//Slots all the slots of the range
+func (r *SlotRange) Slots() []int {
+	slots := make([]int, 0, r.End-r.Start+1)
+	for slot := r.Start; slot <= r.End; slot++ {
+		slots = append(slots, slot)
+	}
+	return slots
+}
+
+//ClusterInfo the reply of CLUSTER INFO
+type ClusterInfo struct {
+	State         string            //ok or fail
+	SlotsAssigned int               //slots associated to some node
+	SlotsOK       int               //slots whose node is not in fail or pfail state
+	SlotsPFail    int               //slots whose node is in pfail state
+	SlotsFail     int               //slots whose node is in fail state
+	KnownNodes    int               //nodes in the cluster, including nodes in handshake state
+	Size          int               //masters serving at least one slot
+	CurrentEpoch  int64             //local current epoch
+	MyEpoch       int64             //config epoch of the node
+	Values        map[string]string //all the fields, including the stats fields
+}
+
+//ParseClusterNodes parse the reply of CLUSTER NODES, one node per line:
+// <id> <ip:port@cport[,hostname[,aux=value]*]> <flags> <master> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot> ... <slot>
+func ParseClusterNodes(text string) ([]*ClusterNode, error) {
+	nodes := make([]*ClusterNode, 0)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		node, err := parseClusterNode(line)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func parseClusterNode(line string) (*ClusterNode, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 8 {
+		return nil, newDataError("malformed CLUSTER NODES line: " + line)
+	}
+	node := &ClusterNode{
+		ID:        fields[0],
+		Flags:     strings.Split(fields[2], ","),
+		LinkState: fields[7],
+		Aux:       make(map[string]string),
+		Slots:     make([]*SlotRange, 0),
+		Migrating: make(map[int]string),
+		Importing: make(map[int]string),
+	}
+	if err := node.parseAddress(fields[1]); err != nil {
+		return nil, err
+	}
+	if fields[3] != "-" {
+		node.MasterID = fields[3]
+	}
+	var err error
+	if node.PingSent, err = strconv.ParseInt(fields[4], 10, 64); err != nil {
+		return nil, newDataError("malformed ping-sent in CLUSTER NODES line: " + line)
+	}
+	if node.PongRecv, err = strconv.ParseInt(fields[5], 10, 64); err != nil {
+		return nil, newDataError("malformed pong-recv in CLUSTER NODES line: " + line)
+	}
+	if node.ConfigEpoch, err = strconv.ParseInt(fields[6], 10, 64); err != nil {
+		return nil, newDataError("malformed config-epoch in CLUSTER NODES line: " + line)
+	}
+	for _, field := range fields[8:] {
+		if err := node.parseSlot(field); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+//parseAddress parse ip:port@cport[,hostname[,aux=value]*]
+func (n *ClusterNode) parseAddress(address string) error {
+	parts := strings.Split(address, ",")
+	addr := parts[0]
+	if index := strings.Index(addr, "@"); index >= 0 {
+		busPort, err := strconv.Atoi(addr[index+1:])
+		if err != nil {
+			return newDataError("malformed cluster bus port: " + address)
+		}
+		n.BusPort = busPort
+		addr = addr[:index]
+	}
+	index := strings.LastIndex(addr, ":")
+	if index < 0 {
+		return newDataError("malformed node address: " + address)
+	}
+	port, err := strconv.Atoi(addr[index+1:])
+	if err != nil {
+		return newDataError("malformed node port: " + address)
+	}
+	n.Host = strings.Trim(addr[:index], "[]")
+	n.Port = port
+	for i, part := range parts[1:] {
+		if kv := strings.SplitN(part, "=", 2); len(kv) == 2 {
+			n.Aux[kv[0]] = kv[1]
+		} else if i == 0 {
+			n.Hostname = part
+		}
+	}
+	if tlsPort, ok := n.Aux["tls-port"]; ok {
+		n.TLSPort, _ = strconv.Atoi(tlsPort)
+	}
+	return nil
+}
+
+//parseSlot parse a slot field: 100, 0-5460, [93-<-<node id>] or [77->-<node id>]
+func (n *ClusterNode) parseSlot(field string) error {
+	if strings.HasPrefix(field, "[") {
+		field = strings.Trim(field, "[]")
+		if index := strings.Index(field, "->-"); index >= 0 {
+			slot, err := strconv.Atoi(field[:index])
+			if err != nil {
+				return newDataError("malformed migrating slot: " + field)
+			}
+			n.Migrating[slot] = field[index+3:]
+			return nil
+		}
+		if index := strings.Index(field, "-<-"); index >= 0 {
+			slot, err := strconv.Atoi(field[:index])
+			if err != nil {
+				return newDataError("malformed importing slot: " + field)
+			}
+			n.Importing[slot] = field[index+3:]
+			return nil
+		}
+		return newDataError("malformed open slot: " + field)
+	}
+	bounds := strings.SplitN(field, "-", 2)
+	start, err := strconv.Atoi(bounds[0])
+	if err != nil {
+		return newDataError("malformed slot: " + field)
+	}
+	end := start
+	if len(bounds) == 2 {
+		if end, err = strconv.Atoi(bounds[1]); err != nil {
+			return newDataError("malformed slot: " + field)
+		}
+	}
+	n.Slots = append(n.Slots, &SlotRange{Start: start, End: end})
+	return nil
+}
+
+//ParseClusterSlots parse the reply of CLUSTER SLOTS, every slot range is:
+// start slot, end slot, master node, replica nodes..., every node is: ip, port, id, metadata
+func ParseClusterSlots(reply []interface{}) ([]*SlotRange, error) {
+	ranges := make([]*SlotRange, 0, len(reply))
+	for _, item := range reply {
+		slotInfo, ok := item.([]interface{})
+		if !ok || len(slotInfo) < 2 {
+			return nil, newDataError("malformed CLUSTER SLOTS reply")
+		}
+		start, ok1 := slotInfo[0].(int64)
+		end, ok2 := slotInfo[1].(int64)
+		if !ok1 || !ok2 {
+			return nil, newDataError("malformed slot range in CLUSTER SLOTS reply")
+		}
+		slotRange := &SlotRange{Start: int(start), End: int(end), Replicas: make([]*ClusterSlotNode, 0)}
+		for i := masterNodeIndex; i < len(slotInfo); i++ {
+			nodeInfo, ok := slotInfo[i].([]interface{})
+			if !ok {
+				return nil, newDataError("malformed node in CLUSTER SLOTS reply")
+			}
+			if len(nodeInfo) == 0 {
+				continue
+			}
+			node, err := parseClusterSlotNode(nodeInfo)
+			if err != nil {
+				return nil, err
+			}
+			if slotRange.Master == nil {
+				slotRange.Master = node
+			} else {
+				slotRange.Replicas = append(slotRange.Replicas, node)
+			}
+		}
+		ranges = append(ranges, slotRange)
+	}
+	return ranges, nil
+}
+
+func parseClusterSlotNode(nodeInfo []interface{}) (*ClusterSlotNode, error) {
+	if len(nodeInfo) < 2 {
+		return nil, newDataError("malformed node in CLUSTER SLOTS reply")
+	}
+	host, ok1 := nodeInfo[0].([]byte)
+	port, ok2 := nodeInfo[1].(int64)
+	if !ok1 || !ok2 {
+		return nil, newDataError("malformed node address in CLUSTER SLOTS reply")
+	}
+	node := &ClusterSlotNode{Host: string(host), Port: int(port), Metadata: make(map[string]string)}
+	if len(nodeInfo) > 2 {
+		if id, ok := nodeInfo[2].([]byte); ok {
+			node.ID = string(id)
+		}
+	}
+	if len(nodeInfo) > 3 {
+		if metadata, ok := nodeInfo[3].([]interface{}); ok {
+			for i := 0; i+1 < len(metadata); i += 2 {
+				node.Metadata[replyToString(metadata[i])] = replyToString(metadata[i+1])
+			}
+		}
+	}
+	node.Hostname = node.Metadata["hostname"]
+	return node, nil
+}
+
+func replyToString(reply interface{}) string {
+	switch v := reply.(type) {
+	case []byte:
+		return string(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case string:
+		return v
+	}
+	return ""
+}
+
+//ParseClusterInfo parse the reply of CLUSTER INFO, one field:value per line
+func ParseClusterInfo(text string) (*ClusterInfo, error) {
+	info := &ClusterInfo{Values: make(map[string]string)}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		kv := strings.SplitN(line, ":", 2)
+		if len(kv) != 2 {
+			return nil, newDataError("malformed CLUSTER INFO line: " + line)
+		}
+		info.Values[kv[0]] = kv[1]
+	}
+	info.State = info.Values["cluster_state"]
+	ints := map[string]*int{
+		"cluster_slots_assigned": &info.SlotsAssigned,
+		"cluster_slots_ok":       &info.SlotsOK,
+		"cluster_slots_pfail":    &info.SlotsPFail,
+		"cluster_slots_fail":     &info.SlotsFail,
+		"cluster_known_nodes":    &info.KnownNodes,
+		"cluster_size":           &info.Size,
+	}
+	for key, field := range ints {
+		if value, ok := info.Values[key]; ok {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, newDataError("malformed " + key + " in CLUSTER INFO: " + value)
+			}
+			*field = n
+		}
+	}
+	int64s := map[string]*int64{
+		"cluster_current_epoch": &info.CurrentEpoch,
+		"cluster_my_epoch":      &info.MyEpoch,
+	}
+	for key, field := range int64s {
+		if value, ok := info.Values[key]; ok {
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, newDataError("malformed " + key + " in CLUSTER INFO: " + value)
+			}
+			*field = n
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,99 @@
+package godis
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseClusterNodes(t *testing.T) {
+	text := "07c37dfeb235213a872192d90877d0cd55635b91 127.0.0.1:30004@31004,,tls-port=0,shard-id=69bc slave e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 0 1426238317239 4 connected\n" +
+		"67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 127.0.0.1:30002@31002,redis-2.example.com master - 0 1426238316232 2 connected 5461-10922 [5461->-e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca]\n" +
+		"e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 127.0.0.1:30001@31001 myself,master - 0 0 1 connected 0-5460 10923 [10924-<-67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1]\n" +
+		"6ec23923021cf3ffec47632106199cb7f496ce01 :0@0 master,fail?,noaddr - 1426238316232 0 5 disconnected\n"
+	nodes, err := ParseClusterNodes(text)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(nodes))
+
+	replica := nodes[0]
+	assert.True(t, replica.IsReplica())
+	assert.False(t, replica.IsMaster())
+	assert.Equal(t, "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca", replica.MasterID)
+	assert.Equal(t, "127.0.0.1:30004", replica.Addr())
+	assert.Equal(t, 31004, replica.BusPort)
+	assert.Equal(t, "", replica.Hostname)
+	assert.Equal(t, "69bc", replica.Aux["shard-id"])
+	assert.Equal(t, int64(1426238317239), replica.PongRecv)
+	assert.Equal(t, int64(4), replica.ConfigEpoch)
+	assert.True(t, replica.Connected())
+
+	master := nodes[1]
+	assert.Equal(t, "redis-2.example.com", master.Hostname)
+	assert.Equal(t, 5462, master.SlotCount())
+	assert.Equal(t, "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca", master.Migrating[5461])
+
+	myself := nodes[2]
+	assert.True(t, myself.IsMyself())
+	assert.Equal(t, 2, len(myself.Slots))
+	assert.Equal(t, 10923, myself.Slots[1].Start)
+	assert.Equal(t, 10923, myself.Slots[1].End)
+	assert.Equal(t, "67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1", myself.Importing[10924])
+
+	failed := nodes[3]
+	assert.True(t, failed.IsFailed())
+	assert.True(t, failed.HasFlag("noaddr"))
+	assert.Equal(t, "", failed.Host)
+	assert.False(t, failed.Connected())
+
+	_, err = ParseClusterNodes("07c37dfeb235213a872192d90877d0cd55635b91 127.0.0.1:30004@31004 slave")
+	assert.NotNil(t, err)
+	_, err = ParseClusterNodes("07c37dfeb235213a872192d90877d0cd55635b91 127.0.0.1:30004@31004 master - 0 0 1 connected a-b")
+	assert.NotNil(t, err)
+}
+
+func TestParseClusterSlots(t *testing.T) {
+	reply := []interface{}{
+		[]interface{}{int64(0), int64(5460),
+			[]interface{}{[]byte("127.0.0.1"), int64(30001), []byte("09dbe9720cda62f7865eabc5fd8857c5d2678366"),
+				[]interface{}{[]byte("hostname"), []byte("host-1.redis.example.com")}},
+			[]interface{}{[]byte("127.0.0.1"), int64(30004), []byte("821d8ca00d7ccf931ed3ffc7e3db0599d2271abf")},
+		},
+		[]interface{}{int64(5461), int64(10922),
+			[]interface{}{[]byte("127.0.0.1"), int64(30002)},
+		},
+	}
+	ranges, err := ParseClusterSlots(reply)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(ranges))
+	assert.Equal(t, 5461, len(ranges[0].Slots()))
+	assert.Equal(t, "127.0.0.1:30001", ranges[0].Master.Addr())
+	assert.Equal(t, "09dbe9720cda62f7865eabc5fd8857c5d2678366", ranges[0].Master.ID)
+	assert.Equal(t, "host-1.redis.example.com", ranges[0].Master.Hostname)
+	assert.Equal(t, 1, len(ranges[0].Replicas))
+	assert.Equal(t, 30004, ranges[0].Replicas[0].Port)
+	assert.Equal(t, "", ranges[1].Master.ID)
+	assert.Equal(t, 0, len(ranges[1].Replicas))
+
+	_, err = ParseClusterSlots([]interface{}{[]interface{}{int64(0)}})
+	assert.NotNil(t, err)
+	_, err = ParseClusterSlots([]interface{}{[]interface{}{int64(0), int64(1), []interface{}{int64(1), int64(2)}}})
+	assert.NotNil(t, err)
+}
+
+func TestParseClusterInfo(t *testing.T) {
+	text := "cluster_state:ok\r\ncluster_slots_assigned:16384\r\ncluster_slots_ok:16384\r\ncluster_slots_pfail:0\r\n" +
+		"cluster_slots_fail:0\r\ncluster_known_nodes:6\r\ncluster_size:3\r\ncluster_current_epoch:6\r\n" +
+		"cluster_my_epoch:2\r\ncluster_stats_messages_sent:1483972\r\n"
+	info, err := ParseClusterInfo(text)
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", info.State)
+	assert.Equal(t, 16384, info.SlotsAssigned)
+	assert.Equal(t, 16384, info.SlotsOK)
+	assert.Equal(t, 6, info.KnownNodes)
+	assert.Equal(t, 3, info.Size)
+	assert.Equal(t, int64(6), info.CurrentEpoch)
+	assert.Equal(t, int64(2), info.MyEpoch)
+	assert.Equal(t, "1483972", info.Values["cluster_stats_messages_sent"])
+
+	_, err = ParseClusterInfo("cluster_size:three")
+	assert.NotNil(t, err)
+}
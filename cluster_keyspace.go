@@ -0,0 +1,274 @@
+package godis
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+//ClusterKeyspaceOption option of the keyspace notification subscriber of the cluster
+type ClusterKeyspaceOption struct {
+	Keyspace        bool                         //subscribe the __keyspace@*__:* channels
+	Keyevent        bool                         //subscribe the __keyevent@*__:* channels, both are subscribed if Keyspace and Keyevent are false
+	IncludeReplicas bool                         //subscribe the replicas too, their events are deduplicated with the events of their masters
+	DedupWindow     time.Duration                //the same event of the same shard received from several channels or nodes within the window is delivered once, default is 1 second
+	OnEvent         func(event *KeyspaceEvent)   //receive the event, the events are delivered serially
+	OnError         func(node string, err error) //the subscription of the node failed, it's retried in background
+}
+
+//ClusterKeyspaceSubscriber subscribe the keyspace notifications on every master of the cluster,
+// the subscriptions follow the topology changes found by the slot cache refresh.
+// the notifications must be enabled by notify-keyspace-events on the nodes
+type ClusterKeyspaceSubscriber struct {
+	handler  *redisClusterConnectionHandler
+	option   *ClusterKeyspaceOption
+	patterns []string
+
+	mu       sync.Mutex
+	watchers map[string]*keyspaceWatcher //node -> watcher
+	shards   map[string]string           //replica -> master
+	listener *clusterListener            //follow the topology changes, removed by Close
+	closed   bool
+
+	deliverLock sync.Mutex
+	recent      map[string]*recentKeyspaceEvent
+	lastPrune   time.Time
+}
+
+type keyspaceWatcher struct {
+	node string
+	stop chan struct{}
+	conn *Redis
+}
+
+//recentKeyspaceEvent the occurrences of an event of a shard, the n-th occurrence sent by a source
+// is the same as the n-th occurrence sent by the other sources, as every node of the shard
+// and every channel type sends the events in the same order
+type recentKeyspaceEvent struct {
+	at        time.Time
+	delivered int            //how many occurrences are delivered
+	sources   map[string]int //node and channel type -> how many occurrences it sent
+}
+
+//SubscribeKeyspace subscribe the keyspace notifications on every master of the cluster,
+// the subscriber keeps running in background until it's closed
+func (r *RedisCluster) SubscribeKeyspace(option *ClusterKeyspaceOption) (*ClusterKeyspaceSubscriber, error) {
+	if option == nil || option.OnEvent == nil {
+		return nil, newDataError("OnEvent of the keyspace subscriber is required")
+	}
+	subscriber := newClusterKeyspaceSubscriber(r.connectionHandler, option)
+	subscriber.sync()
+	if subscriber.nodeCount() == 0 {
+		subscriber.Close()
+		return nil, newNoReachableClusterNodeError("no node in cluster")
+	}
+	listener := r.connectionHandler.cache.addEventListener(func(event *ClusterEvent) {
+		subscriber.sync()
+	})
+	subscriber.mu.Lock()
+	subscriber.listener = listener
+	subscriber.mu.Unlock()
+	return subscriber, nil
+}
+
+func newClusterKeyspaceSubscriber(handler *redisClusterConnectionHandler, option *ClusterKeyspaceOption) *ClusterKeyspaceSubscriber {
+	if option.DedupWindow <= 0 {
+		option.DedupWindow = time.Second
+	}
+	patterns := make([]string, 0, 2)
+	if option.Keyspace || !option.Keyevent {
+		patterns = append(patterns, keyspaceChannelPrefix+"*__:*")
+	}
+	if option.Keyevent || !option.Keyspace {
+		patterns = append(patterns, keyeventChannelPrefix+"*__:*")
+	}
+	return &ClusterKeyspaceSubscriber{
+		handler:  handler,
+		option:   option,
+		patterns: patterns,
+		watchers: make(map[string]*keyspaceWatcher),
+		shards:   make(map[string]string),
+		recent:   make(map[string]*recentKeyspaceEvent),
+	}
+}
+
+//Nodes return host:port of the subscribed nodes
+func (s *ClusterKeyspaceSubscriber) Nodes() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nodes := make([]string, 0, len(s.watchers))
+	for node := range s.watchers {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+//Close unsubscribe all the nodes, the subscriber can't be used anymore after Close
+func (s *ClusterKeyspaceSubscriber) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.listener != nil {
+		s.handler.cache.removeEventListener(s.listener)
+		s.listener = nil
+	}
+	for node, watcher := range s.watchers {
+		s.stopWatcher(watcher)
+		delete(s.watchers, node)
+	}
+}
+
+func (s *ClusterKeyspaceSubscriber) nodeCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.watchers)
+}
+
+//sync subscribe the new nodes and unsubscribe the nodes not wanted anymore
+func (s *ClusterKeyspaceSubscriber) sync() {
+	var nodes map[string]*Pool
+	if s.option.IncludeReplicas {
+		nodes = s.handler.getNodes()
+	} else {
+		nodes = s.handler.getMasterNodes()
+	}
+	shards := s.handler.cache.getReplicas()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.shards = shards
+	for node, watcher := range s.watchers {
+		if _, ok := nodes[node]; !ok {
+			s.stopWatcher(watcher)
+			delete(s.watchers, node)
+		}
+	}
+	for node := range nodes {
+		if _, ok := s.watchers[node]; !ok {
+			watcher := &keyspaceWatcher{node: node, stop: make(chan struct{})}
+			s.watchers[node] = watcher
+			go s.watch(watcher)
+		}
+	}
+}
+
+//stopWatcher the caller must hold the lock
+func (s *ClusterKeyspaceSubscriber) stopWatcher(watcher *keyspaceWatcher) {
+	close(watcher.stop)
+	if watcher.conn != nil {
+		_ = watcher.conn.Close()
+		watcher.conn = nil
+	}
+}
+
+//watch subscribe the node until the watcher is stopped, the subscription is retried when it fails
+func (s *ClusterKeyspaceSubscriber) watch(watcher *keyspaceWatcher) {
+	host, port, _ := splitNodeKey(watcher.node)
+	cache := s.handler.cache
+	for retries := 0; ; retries++ {
+		conn := NewRedis(&Option{
+			Host:              host,
+			Port:              port,
+			ConnectionTimeout: cache.connectionTimeout,
+			SoTimeout:         cache.soTimeout,
+			Password:          cache.password,
+		})
+		if !s.attach(watcher, conn) {
+			return
+		}
+		pubSub := &RedisPubSub{
+			OnPMessage: func(pattern string, channel, message string) {
+				s.deliver(watcher.node, channel, message)
+			},
+			OnPSubscribe: func(pattern string, subscribedChannels int) {
+				retries = 0
+			},
+			OnPUnSubscribe: func(pattern string, subscribedChannels int) {},
+		}
+		err := conn.Connect()
+		if err == nil {
+			err = conn.PSubscribe(pubSub, s.patterns...)
+		}
+		_ = conn.Close()
+		if err == nil {
+			err = newConnectError("keyspace notifications are unsubscribed by the node")
+		}
+		select {
+		case <-watcher.stop:
+			return
+		default:
+		}
+		if s.option.OnError != nil {
+			s.option.OnError(watcher.node, err)
+		}
+		select {
+		case <-watcher.stop:
+			return
+		case <-time.After(clusterBackoffDuration(retries)):
+		}
+	}
+}
+
+//attach set the connection of the watcher, false is returned if the watcher is stopped
+func (s *ClusterKeyspaceSubscriber) attach(watcher *keyspaceWatcher, conn *Redis) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-watcher.stop:
+		return false
+	default:
+	}
+	watcher.conn = conn
+	return true
+}
+
+func (s *ClusterKeyspaceSubscriber) deliver(node, channel, message string) {
+	event, ok := parseKeyspaceEvent(channel, message)
+	if !ok {
+		return
+	}
+	event.Node = node
+	s.deliverLock.Lock()
+	defer s.deliverLock.Unlock()
+	if s.isDuplicate(event) {
+		return
+	}
+	s.option.OnEvent(event)
+}
+
+//isDuplicate check whether the event is already delivered from another node of the shard or another channel type,
+// the same event from the same node and channel type is a new event, so the occurrences are counted per source.
+// a replica lagging behind by more than DedupWindow delivers its events again. the caller must hold the deliverLock
+func (s *ClusterKeyspaceSubscriber) isDuplicate(event *KeyspaceEvent) bool {
+	s.mu.Lock()
+	shard, ok := s.shards[event.Node]
+	s.mu.Unlock()
+	if !ok {
+		shard = event.Node
+	}
+	now := time.Now()
+	if now.Sub(s.lastPrune) > s.option.DedupWindow {
+		for id, recent := range s.recent {
+			if now.Sub(recent.at) > s.option.DedupWindow {
+				delete(s.recent, id)
+			}
+		}
+		s.lastPrune = now
+	}
+	id := shard + "|" + strconv.Itoa(event.DB) + "|" + event.Event + "|" + event.Key
+	source := event.Node + "|" + strconv.FormatBool(event.Keyspace)
+	recent, ok := s.recent[id]
+	if !ok || now.Sub(recent.at) > s.option.DedupWindow {
+		recent = &recentKeyspaceEvent{sources: make(map[string]int)}
+		s.recent[id] = recent
+	}
+	recent.at = now
+	recent.sources[source]++
+	if recent.sources[source] <= recent.delivered {
+		return true
+	}
+	recent.delivered = recent.sources[source]
+	return false
+}
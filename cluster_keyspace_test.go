@@ -0,0 +1,113 @@
+package godis
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestClusterKeyspaceSubscriber_dedup(t *testing.T) {
+	cluster := newTestClusterWithSlots(map[string][]int{"localhost:7000": slotRange(0, 16383)})
+	events := make([]*KeyspaceEvent, 0)
+	subscriber := newClusterKeyspaceSubscriber(cluster.connectionHandler, &ClusterKeyspaceOption{
+		IncludeReplicas: true,
+		OnEvent: func(event *KeyspaceEvent) {
+			events = append(events, event)
+		},
+	})
+	assert.Equal(t, []string{"__keyspace@*__:*", "__keyevent@*__:*"}, subscriber.patterns)
+	subscriber.shards = map[string]string{"localhost:7003": "localhost:7000"}
+
+	subscriber.deliver("localhost:7000", "__keyspace@0__:godis", "set")
+	subscriber.deliver("localhost:7000", "__keyevent@0__:set", "godis")
+	subscriber.deliver("localhost:7003", "__keyspace@0__:godis", "set")
+	subscriber.deliver("localhost:7003", "__keyevent@0__:set", "godis")
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, "localhost:7000", events[0].Node)
+
+	//the same event from the same source is a new event
+	subscriber.deliver("localhost:7000", "__keyspace@0__:godis", "set")
+	//another shard
+	subscriber.deliver("localhost:7001", "__keyspace@0__:godis", "set")
+	subscriber.deliver("localhost:7000", "__keyspace@0__:godis", "del")
+	subscriber.deliver("localhost:7000", "news", "godis")
+	assert.Equal(t, 4, len(events))
+
+	subscriber.option.DedupWindow = time.Millisecond
+	time.Sleep(2 * time.Millisecond)
+	subscriber.deliver("localhost:7003", "__keyspace@0__:godis", "del")
+	assert.Equal(t, 5, len(events))
+	assert.Equal(t, 1, len(subscriber.recent))
+}
+
+func TestClusterKeyspaceSubscriber_dedupLaggingReplica(t *testing.T) {
+	cluster := newTestClusterWithSlots(map[string][]int{"localhost:7000": slotRange(0, 16383)})
+	events := make([]*KeyspaceEvent, 0)
+	subscriber := newClusterKeyspaceSubscriber(cluster.connectionHandler, &ClusterKeyspaceOption{
+		IncludeReplicas: true,
+		OnEvent: func(event *KeyspaceEvent) {
+			events = append(events, event)
+		},
+	})
+	subscriber.shards = map[string]string{"localhost:7003": "localhost:7000"}
+	//the master sends the event twice before the replica sends the first one
+	subscriber.deliver("localhost:7000", "__keyspace@0__:godis", "incr")
+	subscriber.deliver("localhost:7000", "__keyspace@0__:godis", "incr")
+	subscriber.deliver("localhost:7003", "__keyspace@0__:godis", "incr")
+	subscriber.deliver("localhost:7003", "__keyspace@0__:godis", "incr")
+	assert.Equal(t, 2, len(events))
+	//the replica catches up and sends a new one first
+	subscriber.deliver("localhost:7003", "__keyspace@0__:godis", "incr")
+	subscriber.deliver("localhost:7000", "__keyspace@0__:godis", "incr")
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, "localhost:7003", events[2].Node)
+}
+
+func TestRedisCluster_SubscribeKeyspace_removeListener(t *testing.T) {
+	cluster := newTestClusterWithSlots(map[string][]int{"127.0.0.1:1": slotRange(0, 16383)})
+	subscriber, err := cluster.SubscribeKeyspace(&ClusterKeyspaceOption{
+		OnEvent: func(event *KeyspaceEvent) {},
+	})
+	require.Nil(t, err)
+	cache := cluster.connectionHandler.cache
+	assert.Equal(t, 1, len(cache.listeners))
+	subscriber.Close()
+	assert.Equal(t, 0, len(cache.listeners))
+	assert.Equal(t, 0, len(subscriber.Nodes()))
+}
+
+func TestRedisCluster_SubscribeKeyspace(t *testing.T) {
+	cluster := NewRedisCluster(clusterOption)
+	clearKeys(cluster)
+	for node := range cluster.connectionHandler.getMasterNodes() {
+		host, port, _ := splitNodeKey(node)
+		redis := NewRedis(&Option{Host: host, Port: port})
+		_, err := redis.ConfigSet("notify-keyspace-events", "KEA")
+		assert.Nil(t, err)
+		_ = redis.Close()
+	}
+	received := make(chan *KeyspaceEvent, 10)
+	subscriber, err := cluster.SubscribeKeyspace(&ClusterKeyspaceOption{
+		Keyevent: true,
+		OnEvent: func(event *KeyspaceEvent) {
+			received <- event
+		},
+	})
+	require.Nil(t, err)
+	defer subscriber.Close()
+	assert.Equal(t, 3, len(subscriber.Nodes()))
+	time.Sleep(500 * time.Millisecond)
+
+	for _, key := range []string{"{a}godis", "{b}godis", "{c}godis"} {
+		_, err = cluster.Set(key, "good")
+		assert.Nil(t, err)
+		select {
+		case event := <-received:
+			assert.Equal(t, key, event.Key)
+			assert.Equal(t, "set", event.Event)
+		case <-time.After(2 * time.Second):
+			t.Fatal("keyspace event timeout")
+		}
+	}
+}
@@ -0,0 +1,411 @@
+package godis
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//clusterShardedSubscriber keeps one dedicated connection per master owning subscribed shard channels,
+// the callbacks of the RedisPubSub are called with the number of shard channels subscribed on the whole cluster
+type clusterShardedSubscriber struct {
+	handler     *redisClusterConnectionHandler
+	pubSub      *RedisPubSub
+	maxAttempts int
+	crc16       *crc16
+
+	mu         sync.Mutex
+	channels   map[string]string //channel -> node the channel is subscribed on, empty means not routed yet
+	subscribed map[string]bool   //channels confirmed by the nodes
+	moving     map[string]bool   //confirmed channels being moved to another node, not notified again when confirmed
+	conns      map[string]*Redis //node -> dedicated pubsub connection
+
+	callbackLock sync.Mutex
+	done         chan struct{}
+	doneOnce     sync.Once
+	err          error
+}
+
+func newClusterShardedSubscriber(handler *redisClusterConnectionHandler, pubSub *RedisPubSub, maxAttempts int) *clusterShardedSubscriber {
+	subscriber := &clusterShardedSubscriber{
+		handler:     handler,
+		pubSub:      pubSub,
+		maxAttempts: maxAttempts,
+		crc16:       newCRC16(),
+		channels:    make(map[string]string),
+		subscribed:  make(map[string]bool),
+		moving:      make(map[string]bool),
+		conns:       make(map[string]*Redis),
+		done:        make(chan struct{}),
+	}
+	pubSub.sharded = subscriber
+	return subscriber
+}
+
+func (s *clusterShardedSubscriber) subscribe(channels ...string) error {
+	if len(channels) == 0 {
+		return newDataError("at least one shard channel is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.isDone() {
+		return newConnectError("sharded subscriber is closed")
+	}
+	pending := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		if _, ok := s.channels[channel]; !ok {
+			s.channels[channel] = ""
+			pending = append(pending, channel)
+		}
+	}
+	return s.route(pending)
+}
+
+func (s *clusterShardedSubscriber) unsubscribe(channels ...string) error {
+	s.mu.Lock()
+	if len(channels) == 0 {
+		for channel := range s.channels {
+			channels = append(channels, channel)
+		}
+	}
+	groups := make(map[string][]string)
+	for _, channel := range channels {
+		node, ok := s.channels[channel]
+		if !ok {
+			continue
+		}
+		delete(s.channels, channel)
+		if node != "" {
+			groups[node] = append(groups[node], channel)
+		} else {
+			delete(s.moving, channel)
+		}
+	}
+	var err error
+	for node, nodeChannels := range groups {
+		conn, ok := s.conns[node]
+		if !ok {
+			continue
+		}
+		if e := s.send(conn, cmdSUnSubscribe, nodeChannels); e != nil {
+			err = e
+		}
+	}
+	finished := len(s.channels) == 0 && len(s.subscribed) == 0
+	s.mu.Unlock()
+	if finished {
+		s.stop(nil)
+	}
+	return err
+}
+
+//route subscribe the channels on the masters owning their slots, the caller must hold the lock
+func (s *clusterShardedSubscriber) route(channels []string) error {
+	if len(channels) == 0 {
+		return nil
+	}
+	owners := s.handler.getSlotOwners()
+	groups, unknown := groupChannelsByOwner(s.crc16, owners, channels)
+	if len(unknown) > 0 {
+		s.handler.renewSlotCache()
+		owners = s.handler.getSlotOwners()
+		groups, unknown = groupChannelsByOwner(s.crc16, owners, channels)
+		if len(unknown) > 0 {
+			return newClusterOperationError(fmt.Sprintf("no master owns the slot of shard channel %s", unknown[0]))
+		}
+	}
+	for node, nodeChannels := range groups {
+		conn, err := s.connection(node)
+		if err != nil {
+			return err
+		}
+		for _, channel := range nodeChannels {
+			s.channels[channel] = node
+		}
+		if err := s.send(conn, cmdSSubscribe, nodeChannels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//connection return the pubsub connection of the node, a new one is connected if absent, the caller must hold the lock
+func (s *clusterShardedSubscriber) connection(node string) (*Redis, error) {
+	if conn, ok := s.conns[node]; ok {
+		return conn, nil
+	}
+	host, port, _ := splitNodeKey(node)
+	cache := s.handler.cache
+	conn := NewRedis(&Option{
+		Host:              host,
+		Port:              port,
+		ConnectionTimeout: cache.connectionTimeout,
+		SoTimeout:         cache.soTimeout,
+		Password:          cache.password,
+	})
+	if err := conn.Connect(); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if err := conn.client.connection.setTimeoutInfinite(); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	s.conns[node] = conn
+	go s.receive(node, conn)
+	return conn, nil
+}
+
+func (s *clusterShardedSubscriber) send(conn *Redis, cmd protocolCommand, channels []string) error {
+	err := conn.client.sendCommand(cmd, StrArrToByteArrArr(channels)...)
+	if err != nil {
+		return err
+	}
+	return conn.client.flush()
+}
+
+func (s *clusterShardedSubscriber) receive(node string, conn *Redis) {
+	for {
+		reply, err := conn.client.connection.getRawObjectMultiBulkReply()
+		if err != nil {
+			switch e := err.(type) {
+			case *MovedDataError:
+				s.reroute(node, e.Slot)
+				continue
+			case *AskDataError:
+				clusterBackoff(1)
+				s.reroute(node, e.Slot)
+				continue
+			case *ClusterError, *TryAgainError:
+				clusterBackoff(1)
+				s.reroute(node, -1)
+				continue
+			}
+			s.recover(node, conn, err)
+			return
+		}
+		if len(reply) < 3 {
+			s.stop(fmt.Errorf("unknown message type: %v", reply))
+			return
+		}
+		channel := pubSubReplyString(reply[1])
+		switch strings.ToUpper(pubSubReplyString(reply[0])) {
+		case keywordSSubscribe.name:
+			s.onSubscribe(node, channel)
+		case keywordSUnsubscribe.name:
+			s.onUnSubscribe(node, channel, reply[2].(int64))
+		case keywordSMessage.name:
+			s.onMessage(channel, pubSubReplyString(reply[2]))
+		default:
+			s.stop(fmt.Errorf("unknown message type: %v", reply))
+			return
+		}
+	}
+}
+
+func (s *clusterShardedSubscriber) onSubscribe(node, channel string) {
+	s.mu.Lock()
+	if s.channels[channel] != node || s.subscribed[channel] {
+		s.mu.Unlock()
+		return
+	}
+	s.subscribed[channel] = true
+	moved := s.moving[channel]
+	delete(s.moving, channel)
+	count := len(s.subscribed) + len(s.moving)
+	s.mu.Unlock()
+	if moved {
+		return
+	}
+	s.notify(func() {
+		s.pubSub.subscribedChannels = count
+		if s.pubSub.OnSSubscribe != nil {
+			s.pubSub.OnSSubscribe(channel, count)
+		}
+	})
+}
+
+func (s *clusterShardedSubscriber) onUnSubscribe(node, channel string, nodeChannels int64) {
+	s.mu.Lock()
+	wanted, ok := s.channels[channel]
+	if ok && wanted != node {
+		//late reply of the node the channel was moved from
+		s.mu.Unlock()
+		return
+	}
+	confirmed := s.subscribed[channel] || s.moving[channel]
+	delete(s.subscribed, channel)
+	if ok {
+		s.channels[channel] = ""
+		if confirmed {
+			s.moving[channel] = true
+		}
+	} else {
+		delete(s.moving, channel)
+	}
+	if nodeChannels == 0 && !s.hasChannels(node) {
+		s.closeConnection(node)
+	}
+	if ok {
+		//the node unsubscribed the channel by itself because its slot was migrated
+		err := s.rerouteLocked([]string{channel})
+		s.mu.Unlock()
+		if err != nil {
+			s.stop(err)
+		}
+		return
+	}
+	count := len(s.subscribed) + len(s.moving)
+	finished := len(s.channels) == 0 && len(s.subscribed) == 0
+	s.mu.Unlock()
+	if confirmed {
+		s.notify(func() {
+			s.pubSub.subscribedChannels = count
+			if s.pubSub.OnSUnSubscribe != nil {
+				s.pubSub.OnSUnSubscribe(channel, count)
+			}
+		})
+	}
+	if finished {
+		s.stop(nil)
+	}
+}
+
+func (s *clusterShardedSubscriber) onMessage(channel, message string) {
+	s.notify(func() {
+		if s.pubSub.OnSMessage != nil {
+			s.pubSub.OnSMessage(channel, message)
+		}
+	})
+}
+
+//reroute move the unconfirmed channels of the node to their current owners,
+// only the channels of the slot are moved when slot is not negative
+func (s *clusterShardedSubscriber) reroute(node string, slot int) {
+	s.mu.Lock()
+	channels := make([]string, 0)
+	for channel, owner := range s.channels {
+		if owner != node || s.subscribed[channel] {
+			continue
+		}
+		if slot < 0 || int(s.crc16.getStringSlot(channel)) == slot {
+			s.channels[channel] = ""
+			channels = append(channels, channel)
+		}
+	}
+	err := s.rerouteLocked(channels)
+	s.mu.Unlock()
+	if err != nil {
+		s.stop(err)
+	}
+}
+
+//rerouteLocked renew the slot cache and subscribe the channels on their owners, the caller must hold the lock
+func (s *clusterShardedSubscriber) rerouteLocked(channels []string) error {
+	if len(channels) == 0 || s.isDone() {
+		return nil
+	}
+	var err error
+	for attempt := 0; attempt < s.maxAttempts; attempt++ {
+		if attempt > 0 {
+			clusterBackoff(attempt)
+		}
+		s.handler.renewSlotCache()
+		if err = s.route(channels); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+//recover resubscribe the channels of a broken connection on their current owners
+func (s *clusterShardedSubscriber) recover(node string, conn *Redis, err error) {
+	s.mu.Lock()
+	if s.isDone() || s.conns[node] != conn {
+		//the connection was closed on purpose
+		s.mu.Unlock()
+		return
+	}
+	s.closeConnection(node)
+	channels := make([]string, 0)
+	for channel, owner := range s.channels {
+		if owner == node {
+			s.channels[channel] = ""
+			if s.subscribed[channel] {
+				s.moving[channel] = true
+			}
+			delete(s.subscribed, channel)
+			channels = append(channels, channel)
+		}
+	}
+	err = s.rerouteLocked(channels)
+	s.mu.Unlock()
+	if err != nil {
+		s.stop(err)
+	}
+}
+
+func (s *clusterShardedSubscriber) hasChannels(node string) bool {
+	for _, owner := range s.channels {
+		if owner == node {
+			return true
+		}
+	}
+	return false
+}
+
+//closeConnection the caller must hold the lock
+func (s *clusterShardedSubscriber) closeConnection(node string) {
+	if conn, ok := s.conns[node]; ok {
+		delete(s.conns, node)
+		_ = conn.Close()
+	}
+}
+
+func (s *clusterShardedSubscriber) notify(callback func()) {
+	s.callbackLock.Lock()
+	defer s.callbackLock.Unlock()
+	callback()
+}
+
+func (s *clusterShardedSubscriber) isDone() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *clusterShardedSubscriber) stop(err error) {
+	s.doneOnce.Do(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.err = err
+		close(s.done)
+		for node := range s.conns {
+			s.closeConnection(node)
+		}
+	})
+}
+
+func (s *clusterShardedSubscriber) wait() error {
+	<-s.done
+	return s.err
+}
+
+//groupChannelsByOwner group the channels by the master owning their slots,
+// the channels whose slots are not assigned are returned separately
+func groupChannelsByOwner(crc16 *crc16, owners []string, channels []string) (map[string][]string, []string) {
+	groups := make(map[string][]string)
+	unknown := make([]string, 0)
+	for _, channel := range channels {
+		owner := owners[crc16.getStringSlot(channel)]
+		if owner == "" {
+			unknown = append(unknown, channel)
+			continue
+		}
+		groups[owner] = append(groups[owner], channel)
+	}
+	return groups, unknown
+}
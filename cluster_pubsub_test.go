@@ -0,0 +1,93 @@
+package godis
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGroupChannelsByOwner(t *testing.T) {
+	cluster := newTestClusterWithSlots(map[string][]int{
+		"localhost:7000": slotRange(0, 8191),
+		"localhost:7001": slotRange(8192, 12287),
+	})
+	crc16 := newCRC16()
+	//{x} is in slot 16287, which is not assigned
+	groups, unknown := groupChannelsByOwner(crc16, cluster.connectionHandler.getSlotOwners(),
+		[]string{"{b}news", "{b}sport", "{d}news", "{x}news"})
+	assert.Equal(t, []string{"{x}news"}, unknown)
+	assert.Equal(t, []string{"{b}news", "{b}sport"}, groups["localhost:7000"])
+	assert.Equal(t, []string{"{d}news"}, groups["localhost:7001"])
+}
+
+func TestClusterShardedSubscriber_callbacks(t *testing.T) {
+	cluster := newTestClusterWithSlots(map[string][]int{"localhost:7000": slotRange(0, 16383)})
+	events := make([]string, 0)
+	counts := make([]int, 0)
+	pubsub := &RedisPubSub{
+		OnSSubscribe: func(channel string, subscribedChannels int) {
+			events = append(events, "ssubscribe "+channel)
+			counts = append(counts, subscribedChannels)
+		},
+		OnSUnSubscribe: func(channel string, subscribedChannels int) {
+			events = append(events, "sunsubscribe "+channel)
+			counts = append(counts, subscribedChannels)
+		},
+		OnSMessage: func(channel, message string) {
+			events = append(events, "smessage "+channel+" "+message)
+		},
+	}
+	subscriber := newClusterShardedSubscriber(cluster.connectionHandler, pubsub, 1)
+	subscriber.channels["a"] = "localhost:7000"
+	subscriber.channels["b"] = "localhost:7000"
+	subscriber.onSubscribe("localhost:7000", "a")
+	subscriber.onSubscribe("localhost:7000", "b")
+	//confirmation of a node the channel is not routed to is ignored
+	subscriber.onSubscribe("localhost:7001", "a")
+	subscriber.onMessage("a", "hello")
+
+	assert.Nil(t, pubsub.SUnSubscribe("a", "b"))
+	subscriber.onUnSubscribe("localhost:7000", "a", 1)
+	assert.False(t, subscriber.isDone())
+	subscriber.onUnSubscribe("localhost:7000", "b", 0)
+	assert.Nil(t, subscriber.wait())
+
+	assert.Equal(t, []string{"ssubscribe a", "ssubscribe b", "smessage a hello", "sunsubscribe a", "sunsubscribe b"}, events)
+	assert.Equal(t, []int{1, 2, 1, 0}, counts)
+	assert.NotNil(t, pubsub.SSubscribe("c"))
+}
+
+func TestRedisCluster_SSubscribe(t *testing.T) {
+	cluster := NewRedisCluster(clusterOption)
+	received := make(chan string, 2)
+	subscribed := make(chan bool, 2)
+	pubsub := &RedisPubSub{
+		OnSMessage: func(channel, message string) {
+			received <- channel + ":" + message
+		},
+		OnSSubscribe: func(channel string, subscribedChannels int) {
+			subscribed <- true
+		},
+	}
+	done := make(chan error)
+	go func() {
+		done <- cluster.SSubscribe(pubsub, "{a}godis", "{b}godis")
+	}()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-subscribed:
+		case <-time.After(2 * time.Second):
+			t.Fatal("subscribe timeout")
+		}
+	}
+	n, err := cluster.SPublish("{a}godis", "good")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, "{a}godis:good", <-received)
+	_, err = cluster.SPublish("{b}godis", "bad")
+	assert.Nil(t, err)
+	assert.Equal(t, "{b}godis:bad", <-received)
+
+	assert.Nil(t, pubsub.SUnSubscribe())
+	assert.Nil(t, <-done)
+}
@@ -0,0 +1,373 @@
+package godis
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	//how many nodes are asked for CLUSTER SLOTS when the topology is refreshed in background
+	clusterRefreshSampleSize = 3
+	//how often a retired pool is checked for the borrowed connections
+	clusterRetiredPoolCheckInterval = 50 * time.Millisecond
+)
+
+//ClusterEventType type of the cluster topology change
+type ClusterEventType struct {
+	name string
+}
+
+func newClusterEventType(name string) *ClusterEventType {
+	return &ClusterEventType{name}
+}
+
+var (
+	//ClusterEventNodeAdded a node joined the cluster, Node is the new node
+	ClusterEventNodeAdded = newClusterEventType("NODE_ADDED")
+	//ClusterEventNodeRemoved a node left the cluster and its pool is destroyed once the borrowed connections are returned,
+	// Node is the left node
+	ClusterEventNodeRemoved = newClusterEventType("NODE_REMOVED")
+	//ClusterEventSlotsMoved the owner of the slots changed from OldNode to Node,
+	// OldNode is empty when the slots were not assigned, Node is empty when the slots are not assigned anymore
+	ClusterEventSlotsMoved = newClusterEventType("SLOTS_MOVED")
+	//ClusterEventFailover the replica Node is promoted to the master of the slots of OldNode
+	ClusterEventFailover = newClusterEventType("FAILOVER")
+)
+
+//ClusterEvent change of the cluster topology, found by the slot cache refresh
+type ClusterEvent struct {
+	Type    *ClusterEventType
+	Node    string //host:port of the node
+	OldNode string //host:port of the previous owner of the slots
+	Slots   []int  //slots whose owner changed
+}
+
+//ClusterEventListener listen to the cluster topology changes, the listener is called synchronously by the refresh,
+// so it should return quickly
+type ClusterEventListener func(event *ClusterEvent)
+
+//AddEventListener listen to the topology changes found by the slot cache refresh,
+// set ClusterOption.RefreshInterval to find the changes in background
+func (r *RedisCluster) AddEventListener(listener ClusterEventListener) {
+	r.connectionHandler.cache.addEventListener(listener)
+}
+
+//clusterView topology of the cluster seen by one node, parsed from CLUSTER SLOTS
+type clusterView struct {
+	owners   []string          //host:port of the master of every slot
+	replicas map[string]string //replica host:port -> master host:port
+	nodes    map[string]bool   //all the masters and replicas
+}
+
+func newClusterView(ranges []*SlotRange) *clusterView {
+	view := &clusterView{
+		owners:   make([]string, clusterSlotCount),
+		replicas: make(map[string]string),
+		nodes:    make(map[string]bool),
+	}
+	for _, slotRange := range ranges {
+		if slotRange.Master == nil {
+			continue
+		}
+		master := slotRange.Master.Addr()
+		view.nodes[master] = true
+		for slot := slotRange.Start; slot <= slotRange.End && slot < clusterSlotCount; slot++ {
+			view.owners[slot] = master
+		}
+		for _, replica := range slotRange.Replicas {
+			view.nodes[replica.Addr()] = true
+			view.replicas[replica.Addr()] = master
+		}
+	}
+	return view
+}
+
+//parseClusterView parse the reply of CLUSTER SLOTS to the view, the node addresses are mapped by mapper if it's not nil
+func parseClusterView(slots []interface{}, mapper AddressMapper) (*clusterView, error) {
+	ranges, err := ParseClusterSlots(slots)
+	if err != nil {
+		return nil, err
+	}
+	if mapper != nil {
+		for _, slotRange := range ranges {
+			nodes := append([]*ClusterSlotNode{slotRange.Master}, slotRange.Replicas...)
+			for _, node := range nodes {
+				if node != nil {
+					node.Host, node.Port = mapper(node.Host, node.Port)
+				}
+			}
+		}
+	}
+	return newClusterView(ranges), nil
+}
+
+//fingerprint compact description of the view, the views with the same fingerprint are the same topology
+func (v *clusterView) fingerprint() string {
+	var builder strings.Builder
+	for start := 0; start < clusterSlotCount; {
+		end := start
+		for end+1 < clusterSlotCount && v.owners[end+1] == v.owners[start] {
+			end++
+		}
+		builder.WriteString(strconv.Itoa(start) + "-" + strconv.Itoa(end) + "=" + v.owners[start] + ";")
+		start = end + 1
+	}
+	replicas := make([]string, 0, len(v.replicas))
+	for replica, master := range v.replicas {
+		replicas = append(replicas, replica+">"+master)
+	}
+	sort.Strings(replicas)
+	builder.WriteString(strings.Join(replicas, ";"))
+	return builder.String()
+}
+
+//consensusClusterView choose the view agreed by the majority of the views, nil means there is no majority
+func consensusClusterView(views []*clusterView) *clusterView {
+	votes := make(map[string]int)
+	first := make(map[string]*clusterView)
+	for _, view := range views {
+		fingerprint := view.fingerprint()
+		votes[fingerprint]++
+		if _, ok := first[fingerprint]; !ok {
+			first[fingerprint] = view
+		}
+	}
+	for fingerprint, count := range votes {
+		if count*2 > len(views) {
+			return first[fingerprint]
+		}
+	}
+	return nil
+}
+
+//applyClusterView update the nodes and the slots to the view, the caller must hold wLock.
+// when removeLeft is true, the pools of the nodes which are not in the view are retired
+func (r *redisClusterInfoCache) applyClusterView(view *clusterView, removeLeft bool) []*ClusterEvent {
+	events := make([]*ClusterEvent, 0)
+	for nodeKey := range view.nodes {
+		host, port, ok := splitNodeKey(nodeKey)
+		if !ok {
+			//a custom AddressMapper returned an invalid address, the node is unreachable
+			continue
+		}
+		if r.getNode(nodeKey) == nil {
+			events = append(events, &ClusterEvent{Type: ClusterEventNodeAdded, Node: nodeKey})
+		}
+		r.setupNodeIfNotExist(false, host, port)
+	}
+
+	oldOwners := r.getSlotOwners()
+	table := r.copySlots()
+	changes := make(map[[2]string][]int)
+	pairs := make([][2]string, 0)
+	for slot, owner := range view.owners {
+		oldOwner := oldOwners[slot]
+		if owner == oldOwner {
+			continue
+		}
+		if owner == "" {
+			table.masters[slot] = nil
+		} else {
+			table.masters[slot] = r.getNode(owner)
+		}
+		pair := [2]string{oldOwner, owner}
+		if _, ok := changes[pair]; !ok {
+			pairs = append(pairs, pair)
+		}
+		changes[pair] = append(changes[pair], slot)
+	}
+	for _, pair := range pairs {
+		eventType := ClusterEventSlotsMoved
+		if pair[0] != "" && pair[1] != "" && (r.replicas[pair[1]] == pair[0] || view.replicas[pair[0]] == pair[1]) {
+			eventType = ClusterEventFailover
+		}
+		events = append(events, &ClusterEvent{Type: eventType, Node: pair[1], OldNode: pair[0], Slots: changes[pair]})
+	}
+	table.replicas = r.setupReplicas(view)
+	r.storeSlots(table)
+	r.rwLock.Lock()
+	r.replicas = view.replicas
+	r.rwLock.Unlock()
+
+	if removeLeft {
+		for nodeKey, pool := range r.getNodes() {
+			if view.nodes[nodeKey] {
+				continue
+			}
+			r.nodes.Delete(nodeKey)
+			r.retirePool(pool)
+			events = append(events, &ClusterEvent{Type: ClusterEventNodeRemoved, Node: nodeKey})
+		}
+	}
+	return events
+}
+
+//setupReplicas build the index of the read only pools of the replicas by their masters,
+// the read only pools of the nodes which are no longer replicas are retired
+func (r *redisClusterInfoCache) setupReplicas(view *clusterView) map[*Pool][]*Pool {
+	replicaPools := make(map[*Pool][]*Pool)
+	for replica, master := range view.replicas {
+		host, port, ok := splitNodeKey(replica)
+		masterPool := r.getNode(master)
+		if !ok || masterPool == nil {
+			continue
+		}
+		replicaPools[masterPool] = append(replicaPools[masterPool], r.setupReplicaIfNotExist(host, port))
+	}
+	r.replicaNodes.Range(func(key, value interface{}) bool {
+		if _, ok := view.replicas[key.(string)]; !ok {
+			r.replicaNodes.Delete(key)
+			r.retirePool(value.(*Pool))
+		}
+		return true
+	})
+	return replicaPools
+}
+
+//retirePool destroy the pool of a removed node after the connections borrowed by the commands in flight are returned,
+// the commands which got the pool before the node was removed can still borrow connections until then.
+// a connection held longer than the wait, like a blocking command, is closed when it's returned
+func (r *redisClusterInfoCache) retirePool(pool *Pool) {
+	if pool.internalPool.GetNumActive() == 0 {
+		pool.Destroy()
+		return
+	}
+	wait := r.connectionTimeout + r.soTimeout
+	if wait < clusterMaxRetryBackoff {
+		wait = clusterMaxRetryBackoff
+	}
+	go func() {
+		deadline := time.Now().Add(wait)
+		for pool.internalPool.GetNumActive() > 0 && time.Now().Before(deadline) {
+			time.Sleep(clusterRetiredPoolCheckInterval)
+		}
+		pool.Destroy()
+	}()
+}
+
+//splitNodeKey split host:port of a node, ok is false when the host is empty or the port is missing or out of range
+func splitNodeKey(nodeKey string) (string, int, bool) {
+	index := strings.LastIndex(nodeKey, ":")
+	if index <= 0 {
+		return "", 0, false
+	}
+	port, err := strconv.Atoi(nodeKey[index+1:])
+	if err != nil || port <= 0 || port > 65535 {
+		return "", 0, false
+	}
+	return nodeKey[:index], port, true
+}
+
+//clusterListener a registered listener, the pointer identifies the registration to remove
+type clusterListener struct {
+	listener ClusterEventListener
+}
+
+func (r *redisClusterInfoCache) addEventListener(listener ClusterEventListener) *clusterListener {
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	registration := &clusterListener{listener: listener}
+	r.listeners = append(r.listeners, registration)
+	return registration
+}
+
+//removeEventListener remove the registered listener, a new slice is created as emit may be iterating the old one
+func (r *redisClusterInfoCache) removeEventListener(registration *clusterListener) {
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	listeners := make([]*clusterListener, 0, len(r.listeners))
+	for _, l := range r.listeners {
+		if l != registration {
+			listeners = append(listeners, l)
+		}
+	}
+	r.listeners = listeners
+}
+
+func (r *redisClusterInfoCache) emit(events []*ClusterEvent) {
+	if len(events) == 0 {
+		return
+	}
+	r.listenerLock.Lock()
+	listeners := r.listeners
+	r.listenerLock.Unlock()
+	for _, event := range events {
+		for _, l := range listeners {
+			l.listener(event)
+		}
+	}
+}
+
+//refreshWithConsensus ask several nodes for CLUSTER SLOTS, the topology agreed by the majority is applied,
+// and the nodes which have left the cluster are removed
+func (r *redisClusterInfoCache) refreshWithConsensus() error {
+	views := make([]*clusterView, 0, clusterRefreshSampleSize)
+	for _, pool := range r.getShuffledNodesPool() {
+		if len(views) >= clusterRefreshSampleSize {
+			break
+		}
+		redis, err := pool.GetResource()
+		if err != nil {
+			continue
+		}
+		slots, err := redis.ClusterSlots()
+		_ = redis.Close()
+		if err != nil {
+			continue
+		}
+		view, err := parseClusterView(slots, r.addressMapper)
+		if err != nil {
+			continue
+		}
+		views = append(views, view)
+	}
+	if len(views) == 0 {
+		return newNoReachableClusterNodeError("no reachable node in cluster")
+	}
+	view := consensusClusterView(views)
+	if view == nil {
+		//the nodes don't agree with each other, maybe the cluster is resharding, wait for the next refresh
+		return nil
+	}
+	r.wLock.Lock()
+	events := r.applyClusterView(view, true)
+	r.wLock.Unlock()
+	r.emit(events)
+	return nil
+}
+
+//clusterRefresher refresh the slot cache periodically
+type clusterRefresher struct {
+	cache    *redisClusterInfoCache
+	interval time.Duration
+	stop     chan struct{}
+	once     sync.Once
+}
+
+func newClusterRefresher(cache *redisClusterInfoCache, interval time.Duration) *clusterRefresher {
+	refresher := &clusterRefresher{cache: cache, interval: interval, stop: make(chan struct{})}
+	go refresher.run()
+	return refresher
+}
+
+func (r *clusterRefresher) run() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			_ = r.cache.refreshWithConsensus()
+		}
+	}
+}
+
+func (r *clusterRefresher) close() {
+	r.once.Do(func() {
+		close(r.stop)
+	})
+}
@@ -0,0 +1,155 @@
+package godis
+
+import (
+	"bufio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+	"time"
+)
+
+func clusterSlotsReply(start, end int, nodes ...int) []interface{} {
+	slotInfo := []interface{}{int64(start), int64(end)}
+	for _, port := range nodes {
+		slotInfo = append(slotInfo, []interface{}{[]byte("localhost"), int64(port), []byte("id")})
+	}
+	return slotInfo
+}
+
+func TestClusterView_consensus(t *testing.T) {
+	view1 := testClusterView([]interface{}{clusterSlotsReply(0, 8191, 7000, 7003), clusterSlotsReply(8192, 16383, 7001)})
+	view2 := testClusterView([]interface{}{clusterSlotsReply(8192, 16383, 7001), clusterSlotsReply(0, 8191, 7000, 7003)})
+	view3 := testClusterView([]interface{}{clusterSlotsReply(0, 8191, 7003, 7000), clusterSlotsReply(8192, 16383, 7001)})
+	assert.Equal(t, "localhost:7000", view1.owners[8191])
+	assert.Equal(t, "localhost:7000", view1.replicas["localhost:7003"])
+	assert.Equal(t, 3, len(view1.nodes))
+	assert.Equal(t, view1.fingerprint(), view2.fingerprint())
+	assert.NotEqual(t, view1.fingerprint(), view3.fingerprint())
+
+	assert.Equal(t, view1, consensusClusterView([]*clusterView{view1, view3, view2}))
+	assert.Equal(t, view3, consensusClusterView([]*clusterView{view3}))
+	assert.Nil(t, consensusClusterView([]*clusterView{view1, view3}))
+}
+
+func TestRedisClusterInfoCache_applyClusterView(t *testing.T) {
+	cache := newRedisClusterInfoCache(time.Second, time.Second, "", nil)
+	events := cache.applyClusterView(testClusterView([]interface{}{
+		clusterSlotsReply(0, 8191, 7000, 7003),
+		clusterSlotsReply(8192, 16383, 7001, 7004),
+	}), false)
+	assert.Equal(t, 6, len(events))
+	assert.Equal(t, 4, len(cache.getNodes()))
+
+	//7003 is promoted, 100 slots are migrated from 7001 to 7002, 7004 left the cluster
+	events = cache.applyClusterView(testClusterView([]interface{}{
+		clusterSlotsReply(0, 8191, 7003, 7000),
+		clusterSlotsReply(8192, 8291, 7002),
+		clusterSlotsReply(8292, 16383, 7001),
+	}), true)
+	types := make(map[*ClusterEventType]*ClusterEvent)
+	for _, event := range events {
+		types[event.Type] = event
+	}
+	assert.Equal(t, 4, len(events))
+	assert.Equal(t, "localhost:7002", types[ClusterEventNodeAdded].Node)
+	assert.Equal(t, "localhost:7003", types[ClusterEventFailover].Node)
+	assert.Equal(t, "localhost:7000", types[ClusterEventFailover].OldNode)
+	assert.Equal(t, 8192, len(types[ClusterEventFailover].Slots))
+	assert.Equal(t, "localhost:7002", types[ClusterEventSlotsMoved].Node)
+	assert.Equal(t, "localhost:7001", types[ClusterEventSlotsMoved].OldNode)
+	assert.Equal(t, 100, len(types[ClusterEventSlotsMoved].Slots))
+	assert.Equal(t, "localhost:7004", types[ClusterEventNodeRemoved].Node)
+	assert.Nil(t, cache.getNode("localhost:7004"))
+	assert.Equal(t, "localhost:7002", cache.getSlotOwners()[8192])
+}
+
+func TestRedisClusterInfoCache_retirePool(t *testing.T) {
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		go func() {
+			for readFakeCommand(reader) != nil {
+				_, _ = conn.Write([]byte("+OK\r\n"))
+			}
+		}()
+	})
+	pool := NewPool(nil, serverOption)
+	inFlight, err := pool.GetResource()
+	require.Nil(t, err)
+	cache := newRedisClusterInfoCache(time.Second, time.Second, "", nil)
+	cache.retirePool(pool)
+	assert.False(t, pool.internalPool.IsClosed())
+	//a command which got the pool before the node was removed can still borrow a connection
+	redis, err := pool.GetResource()
+	require.Nil(t, err)
+	assert.Nil(t, redis.Close())
+	assert.Nil(t, inFlight.Close())
+	time.Sleep(10 * clusterRetiredPoolCheckInterval)
+	assert.True(t, pool.internalPool.IsClosed())
+
+	pool = NewPool(nil, serverOption)
+	cache.retirePool(pool)
+	assert.True(t, pool.internalPool.IsClosed())
+}
+
+func TestRedisCluster_AddEventListener(t *testing.T) {
+	cluster := newTestClusterWithSlots(map[string][]int{"localhost:7000": slotRange(0, 16383)})
+	received := make([]*ClusterEvent, 0)
+	cluster.AddEventListener(func(event *ClusterEvent) {
+		received = append(received, event)
+	})
+	cache := cluster.connectionHandler.cache
+	cache.emit(cache.applyClusterView(testClusterView([]interface{}{clusterSlotsReply(0, 16383, 7001)}), true))
+	assert.Equal(t, 3, len(received))
+}
+
+func TestNewStaticAddressMapper_invalid(t *testing.T) {
+	for _, addresses := range []map[string]string{
+		{"localhost:7000": "10.0.0.1"},
+		{"localhost:7000": ":17000"},
+		{"localhost:7000": "10.0.0.1:port"},
+		{"localhost:7000": "10.0.0.1:65536"},
+		{"localhost": "10.0.0.1:17000"},
+	} {
+		mapper, err := NewStaticAddressMapper(addresses)
+		assert.NotNil(t, err, addresses)
+		assert.Nil(t, mapper)
+	}
+	host, port, ok := splitNodeKey("::1:7000")
+	assert.True(t, ok)
+	assert.Equal(t, "::1", host)
+	assert.Equal(t, 7000, port)
+	_, _, ok = splitNodeKey("10.0.0.1:0")
+	assert.False(t, ok)
+}
+
+func testClusterView(slots []interface{}) *clusterView {
+	view, err := parseClusterView(slots, nil)
+	if err != nil {
+		panic(err)
+	}
+	return view
+}
+
+func TestParseClusterView_addressMapper(t *testing.T) {
+	mapper, err := NewStaticAddressMapper(map[string]string{
+		"localhost:7000": "10.0.0.1:17000",
+		"localhost:7003": "10.0.0.1:17003",
+	})
+	require.Nil(t, err)
+	view, err := parseClusterView([]interface{}{
+		clusterSlotsReply(0, 8191, 7000, 7003),
+		clusterSlotsReply(8192, 16383, 7001),
+	}, mapper)
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.1:17000", view.owners[0])
+	assert.Equal(t, "10.0.0.1:17000", view.replicas["10.0.0.1:17003"])
+	assert.Equal(t, "localhost:7001", view.owners[8192])
+
+	cluster := newTestClusterWithSlots(map[string][]int{"localhost:7000": slotRange(0, 16383)})
+	cluster.connectionHandler.cache.addressMapper = func(host string, port int) (string, int) {
+		return "127.0.0.1", port + 10000
+	}
+	_, _ = cluster.connectionHandler.getConnectionFromNode("172.17.0.2", 7001)
+	assert.NotNil(t, cluster.connectionHandler.getNode("127.0.0.1:17001"))
+	assert.Nil(t, cluster.connectionHandler.getNode("172.17.0.2:7001"))
+}
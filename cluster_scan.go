@@ -0,0 +1,252 @@
+package godis
+
+import "strconv"
+
+// clusterScanPass scan one master node with its own cursor,
+// only the keys belong to the slots in the pass are returned
+type clusterScanPass struct {
+	node   string
+	cursor string
+	slots  []bool
+}
+
+func (p *clusterScanPass) started() bool {
+	return p.cursor != "0"
+}
+
+func (p *clusterScanPass) hasSlots() bool {
+	for _, s := range p.slots {
+		if s {
+			return true
+		}
+	}
+	return false
+}
+
+//ClusterScanIterator iterate the whole keyspace of the cluster,
+// it walks every master with its own cursor, one batch of keys is returned by each Next call.
+//
+//The iterator keeps a snapshot of the slot owners, when a slot is migrated or the master
+// is failed over during the scan, the new owner of the slot is scanned from the beginning for that slot.
+// the slot cache is renewed from every master after it's scanned, and the slots which are MIGRATING
+// on the master are scanned on the IMPORTING nodes too, so a key which exists during the whole iteration
+// is returned even if its slot is moved. like SCAN, a key may be returned more than once.
+type ClusterScanIterator struct {
+	cluster  *RedisCluster
+	params   *ScanParams
+	owners   []string
+	passes   []*clusterScanPass
+	finished []*clusterScanPass //the passes scanned to the end, the topology is checked through their nodes by the next call
+	crc16    *crc16
+}
+
+//NewScanIterator create an iterator which scans every master of the cluster,
+// MATCH,COUNT and TYPE of the params are sent to every master,
+// MATCH patterns don't need to contain a hash tag
+func (r *RedisCluster) NewScanIterator(params ...*ScanParams) *ClusterScanIterator {
+	param := NewScanParams()
+	if len(params) > 0 && params[0] != nil {
+		param = params[0]
+	}
+	it := &ClusterScanIterator{
+		cluster: r,
+		params:  param,
+		owners:  r.connectionHandler.getSlotOwners(),
+		passes:  make([]*clusterScanPass, 0),
+		crc16:   newCRC16(),
+	}
+	nodes := make(map[string]*clusterScanPass)
+	for slot, owner := range it.owners {
+		if owner == "" {
+			continue
+		}
+		pass, ok := nodes[owner]
+		if !ok {
+			pass = &clusterScanPass{node: owner, cursor: "0", slots: make([]bool, clusterSlotCount)}
+			nodes[owner] = pass
+			it.passes = append(it.passes, pass)
+		}
+		pass.slots[slot] = true
+	}
+	return it
+}
+
+//HasNext whether there are masters not fully scanned
+func (it *ClusterScanIterator) HasNext() bool {
+	return len(it.passes) > 0 || len(it.finished) > 0
+}
+
+//Next scan the next batch of keys, the batch may be empty even if HasNext is still true
+func (it *ClusterScanIterator) Next() ([]string, error) {
+	for len(it.finished) > 0 {
+		if err := it.finishPass(it.finished[0]); err != nil {
+			return nil, err
+		}
+		it.finished = it.finished[1:]
+	}
+	it.followTopology()
+	if len(it.passes) == 0 {
+		return []string{}, nil
+	}
+	pass := it.passes[0]
+	result, err := it.scanNode(pass)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(result.Results))
+	for _, key := range result.Results {
+		if pass.slots[it.crc16.getStringSlot(key)] {
+			keys = append(keys, key)
+		}
+	}
+	pass.cursor = result.Cursor
+	if !pass.started() {
+		it.passes = it.passes[1:]
+		it.finished = append(it.finished, pass)
+	}
+	return keys, nil
+}
+
+//scanNode send SCAN to the node of the pass, the slot cache is renewed when the node is unreachable,
+// so the topology change can be followed by the next call
+func (it *ClusterScanIterator) scanNode(pass *clusterScanPass) (*ScanResult, error) {
+	handler := it.cluster.connectionHandler
+	var lastErr error
+	for attempts := it.cluster.MaxAttempts; attempts > 0; attempts-- {
+		pool := handler.getNode(pass.node)
+		if pool == nil {
+			//an IMPORTING node may have no slot yet, so it's not in the slot cache
+			host, port, ok := splitNodeKey(pass.node)
+			if !ok {
+				return nil, newClusterOperationError("cluster node " + pass.node + " is not found")
+			}
+			pool = handler.cache.setupNodeIfNotExist(true, host, port)
+		}
+		redis, err := pool.GetResource()
+		if err == nil {
+			var result *ScanResult
+			result, err = redis.Scan(pass.cursor, it.params)
+			_ = redis.Close()
+			if err == nil {
+				return result, nil
+			}
+		}
+		lastErr = err
+		if _, ok := err.(*ConnectError); !ok {
+			return nil, err
+		}
+	}
+	handler.renewSlotCache()
+	return nil, lastErr
+}
+
+//finishPass check the topology through the node of the pass after it's scanned to the end,
+// the keys which left the node during the pass are scanned where they are: the slots which are MIGRATING
+// are scanned on the IMPORTING nodes, and the slot cache is renewed from the node, so the slots which
+// changed owners are scanned on the new owners by followTopology. when the node is unreachable,
+// the slot cache is renewed from the other nodes, the other errors are returned and the check is retried
+func (it *ClusterScanIterator) finishPass(pass *clusterScanPass) error {
+	handler := it.cluster.connectionHandler
+	pool := handler.getNode(pass.node)
+	if pool == nil {
+		//the node is removed, its slots have new owners in the slot cache
+		return nil
+	}
+	redis, err := pool.GetResource()
+	if err == nil {
+		defer redis.Close()
+		var reply string
+		if reply, err = redis.ClusterNodes(); err == nil {
+			if err = it.scanImportingNodes(pass, reply); err != nil {
+				return err
+			}
+			err = handler.cache.renewClusterSlots(redis)
+		}
+	}
+	if _, ok := err.(*ConnectError); ok {
+		handler.renewSlotCache()
+		return nil
+	}
+	return err
+}
+
+//scanImportingNodes add the MIGRATING slots of the pass in the CLUSTER NODES reply of its node
+// to the passes of the IMPORTING nodes
+func (it *ClusterScanIterator) scanImportingNodes(pass *clusterScanPass, clusterNodes string) error {
+	nodes, err := ParseClusterNodes(clusterNodes)
+	if err != nil {
+		return err
+	}
+	addrs := make(map[string]string)
+	for _, node := range nodes {
+		if node.Host == "" {
+			continue
+		}
+		host, port := node.Host, node.Port
+		if it.cluster.connectionHandler.cache.addressMapper != nil {
+			host, port = it.cluster.connectionHandler.cache.addressMapper(host, port)
+		}
+		addrs[node.ID] = host + ":" + strconv.Itoa(port)
+	}
+	for _, node := range nodes {
+		if !node.IsMyself() {
+			continue
+		}
+		for slot, id := range node.Migrating {
+			if addr, ok := addrs[id]; ok && slot >= 0 && slot < clusterSlotCount && pass.slots[slot] {
+				it.addSlots(addr, []int{slot})
+			}
+		}
+	}
+	return nil
+}
+
+//followTopology compare the current slot owners with the snapshot,
+// the moved slots are removed from the passes of the old owners and scanned again on the new owners
+func (it *ClusterScanIterator) followTopology() {
+	owners := it.cluster.connectionHandler.getSlotOwners()
+	moved := make(map[string][]int)
+	for slot, owner := range owners {
+		if owner == it.owners[slot] {
+			continue
+		}
+		it.owners[slot] = owner
+		for _, pass := range it.passes {
+			pass.slots[slot] = false
+		}
+		if owner != "" {
+			moved[owner] = append(moved[owner], slot)
+		}
+	}
+	if len(moved) == 0 {
+		return
+	}
+	for owner, slots := range moved {
+		it.addSlots(owner, slots)
+	}
+	passes := make([]*clusterScanPass, 0, len(it.passes))
+	for _, pass := range it.passes {
+		if pass.hasSlots() {
+			passes = append(passes, pass)
+		}
+	}
+	it.passes = passes
+}
+
+//addSlots add the slots to the pass of the node which is not started, a new pass is created if there is none
+func (it *ClusterScanIterator) addSlots(node string, slots []int) {
+	var target *clusterScanPass
+	for _, pass := range it.passes {
+		if pass.node == node && !pass.started() {
+			target = pass
+			break
+		}
+	}
+	if target == nil {
+		target = &clusterScanPass{node: node, cursor: "0", slots: make([]bool, clusterSlotCount)}
+		it.passes = append(it.passes, target)
+	}
+	for _, slot := range slots {
+		target.slots[slot] = true
+	}
+}
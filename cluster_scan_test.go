@@ -0,0 +1,253 @@
+package godis
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestClusterWithSlots(owners map[string][]int) *RedisCluster {
+	cache := newRedisClusterInfoCache(time.Second, time.Second, "", nil)
+	for node, slots := range owners {
+		arr := strings.Split(node, ":")
+		port, _ := strconv.Atoi(arr[1])
+		cache.assignSlotsToNode(false, slots, arr[0], port)
+	}
+	return &RedisCluster{MaxAttempts: 1, connectionHandler: &redisClusterConnectionHandler{cache: cache}}
+}
+
+func slotRange(start, end int) []int {
+	slots := make([]int, 0)
+	for i := start; i <= end; i++ {
+		slots = append(slots, i)
+	}
+	return slots
+}
+
+func TestClusterScanIterator_followTopology(t *testing.T) {
+	cluster := newTestClusterWithSlots(map[string][]int{
+		"localhost:7000": slotRange(0, 8191),
+		"localhost:7001": slotRange(8192, 16383),
+	})
+	it := cluster.NewScanIterator()
+	assert.Equal(t, 2, len(it.passes))
+
+	for _, pass := range it.passes {
+		if pass.node == "localhost:7001" {
+			pass.cursor = "100"
+		}
+	}
+	//slot 8192 is migrated from 7001 to 7000, the pass of 7000 is not started, so it takes the slot
+	cluster.connectionHandler.cache.assignSlotToNode(8192, "localhost", 7000)
+	it.followTopology()
+	assert.Equal(t, 2, len(it.passes))
+	for _, pass := range it.passes {
+		assert.Equal(t, pass.node == "localhost:7000", pass.slots[8192])
+	}
+
+	//slot 0 is migrated from 7000 to 7001, the pass of 7001 is started, so a new pass is created
+	cluster.connectionHandler.cache.assignSlotToNode(0, "localhost", 7001)
+	it.followTopology()
+	assert.Equal(t, 3, len(it.passes))
+	last := it.passes[2]
+	assert.Equal(t, "localhost:7001", last.node)
+	assert.Equal(t, "0", last.cursor)
+	assert.True(t, last.slots[0])
+	assert.False(t, it.passes[0].slots[0] || it.passes[1].slots[0])
+}
+
+//fakeScanCluster masters which reply SCAN with all their keys in one batch,
+// and CLUSTER SLOTS and CLUSTER NODES by the shared slot owners
+type fakeScanCluster struct {
+	mu        sync.Mutex
+	ids       []string
+	ports     []int
+	keys      []map[string]bool
+	owners    []int       //index of the master of every slot
+	migrating map[int]int //slot -> index of the IMPORTING master
+}
+
+func newFakeScanCluster(t *testing.T, masters int) *fakeScanCluster {
+	c := &fakeScanCluster{owners: make([]int, clusterSlotCount), migrating: make(map[int]int)}
+	for i := 0; i < masters; i++ {
+		index := i
+		serverOption := fakeServer(t, func(_ int, conn net.Conn, reader *bufio.Reader) {
+			go c.serve(index, conn, reader)
+		})
+		c.ids = append(c.ids, strings.Repeat(strconv.Itoa(i), 40))
+		c.ports = append(c.ports, serverOption.Port)
+		c.keys = append(c.keys, make(map[string]bool))
+	}
+	for slot := range c.owners {
+		c.owners[slot] = slot * masters / clusterSlotCount
+	}
+	return c
+}
+
+func (c *fakeScanCluster) serve(index int, conn net.Conn, reader *bufio.Reader) {
+	defer conn.Close()
+	for command := readFakeCommand(reader); command != nil; command = readFakeCommand(reader) {
+		c.mu.Lock()
+		switch strings.ToUpper(strings.Join(command[:2], " ")) {
+		case "CLUSTER SLOTS":
+			_, _ = conn.Write([]byte(c.slotsReply()))
+		case "CLUSTER NODES":
+			nodes := c.nodesReply(index)
+			_, _ = conn.Write([]byte(fmt.Sprintf("$%d\r\n%s\r\n", len(nodes), nodes)))
+		default:
+			keys := make([]string, 0)
+			for key := range c.keys[index] {
+				keys = append(keys, fmt.Sprintf("$%d\r\n%s\r\n", len(key), key))
+			}
+			sort.Strings(keys)
+			_, _ = conn.Write([]byte(fmt.Sprintf("*2\r\n$1\r\n0\r\n*%d\r\n%s", len(keys), strings.Join(keys, ""))))
+		}
+		c.mu.Unlock()
+	}
+}
+
+//slotRanges the slot ranges of the master, as start and end pairs
+func (c *fakeScanCluster) slotRanges(index int) [][2]int {
+	ranges := make([][2]int, 0)
+	for slot := 0; slot < clusterSlotCount; slot++ {
+		if c.owners[slot] != index {
+			continue
+		}
+		if len(ranges) > 0 && ranges[len(ranges)-1][1] == slot-1 {
+			ranges[len(ranges)-1][1] = slot
+		} else {
+			ranges = append(ranges, [2]int{slot, slot})
+		}
+	}
+	return ranges
+}
+
+func (c *fakeScanCluster) slotsReply() string {
+	count := 0
+	reply := ""
+	for index := range c.ids {
+		for _, r := range c.slotRanges(index) {
+			count++
+			reply += fmt.Sprintf("*3\r\n:%d\r\n:%d\r\n*3\r\n$9\r\n127.0.0.1\r\n:%d\r\n$40\r\n%s\r\n", r[0], r[1], c.ports[index], c.ids[index])
+		}
+	}
+	return fmt.Sprintf("*%d\r\n", count) + reply
+}
+
+func (c *fakeScanCluster) nodesReply(myself int) string {
+	nodes := ""
+	for index, id := range c.ids {
+		flags := "master"
+		if index == myself {
+			flags = "myself,master"
+		}
+		nodes += fmt.Sprintf("%s 127.0.0.1:%d@%d %s - 0 0 %d connected", id, c.ports[index], c.ports[index]+10000, flags, index+1)
+		for _, r := range c.slotRanges(index) {
+			nodes += fmt.Sprintf(" %d-%d", r[0], r[1])
+		}
+		for slot, importing := range c.migrating {
+			if index == myself && c.owners[slot] == myself {
+				nodes += fmt.Sprintf(" [%d->-%s]", slot, c.ids[importing])
+			}
+		}
+		nodes += "\n"
+	}
+	return nodes
+}
+
+func (c *fakeScanCluster) addr(index int) string {
+	return "127.0.0.1:" + strconv.Itoa(c.ports[index])
+}
+
+//fakeScanKey find a key of the slots owned by the master, which is not in the slots of the excluded keys
+func (c *fakeScanCluster) fakeScanKey(index int, excluded ...string) string {
+	crc16 := newCRC16()
+	for i := 0; ; i++ {
+		key := "godis" + strconv.Itoa(i)
+		slot := crc16.getStringSlot(key)
+		ok := c.owners[slot] == index
+		for _, e := range excluded {
+			ok = ok && crc16.getStringSlot(e) != slot
+		}
+		if ok {
+			return key
+		}
+	}
+}
+
+func TestClusterScanIterator_slotsMovedWithoutRefresh(t *testing.T) {
+	c := newFakeScanCluster(t, 2)
+	crc16 := newCRC16()
+	key0 := c.fakeScanKey(0)
+	key1 := c.fakeScanKey(1)
+	moved := c.fakeScanKey(1, key1)
+	migrating := c.fakeScanKey(1, key1, moved)
+	c.keys[0][key0] = true
+	c.keys[1][key1] = true
+	c.keys[1][moved] = true
+	//the slot of migrating is MIGRATING from 1 to 0, and the key is already moved to 0
+	c.migrating[int(crc16.getStringSlot(migrating))] = 0
+	c.keys[0][migrating] = true
+
+	cluster := newTestClusterWithSlots(map[string][]int{c.addr(0): slotRange(0, 8191), c.addr(1): slotRange(8192, 16383)})
+	it := cluster.NewScanIterator()
+	keys, err := it.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{key0}, keys)
+
+	//the slot of moved is migrated from 1 which is not scanned yet to 0 which is scanned,
+	// no refresher is running, so only the iterator can find it
+	c.mu.Lock()
+	c.owners[crc16.getStringSlot(moved)] = 0
+	delete(c.keys[1], moved)
+	c.keys[0][moved] = true
+	c.mu.Unlock()
+
+	found := map[string]bool{key0: true}
+	for i := 0; it.HasNext() && i < 10; i++ {
+		keys, err = it.Next()
+		assert.Nil(t, err)
+		for _, key := range keys {
+			found[key] = true
+		}
+	}
+	assert.False(t, it.HasNext())
+	assert.Equal(t, map[string]bool{key0: true, key1: true, moved: true, migrating: true}, found)
+}
+
+func TestRedisCluster_NewScanIterator(t *testing.T) {
+	redis := NewRedisCluster(clusterOption)
+	redis.FlushAll()
+
+	for i := 0; i < 1000; i++ {
+		redis.Set(fmt.Sprintf("godis%d", i), fmt.Sprintf("godis%d", i))
+	}
+	redis.SAdd("godisset", "good")
+
+	it := redis.NewScanIterator(NewScanParams().Match("godis*").Count(10))
+	keys := make(map[string]bool)
+	for it.HasNext() {
+		result, err := it.Next()
+		assert.Nil(t, err)
+		for _, key := range result {
+			keys[key] = true
+		}
+	}
+	assert.Equal(t, 1001, len(keys))
+
+	it = redis.NewScanIterator(NewScanParams().Match("godis*").Type("set"))
+	total := 0
+	for it.HasNext() {
+		result, err := it.Next()
+		assert.Nil(t, err)
+		total += len(result)
+	}
+	assert.Equal(t, 1, total)
+}
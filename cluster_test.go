@@ -1,8 +1,11 @@
 package godis
 
 import (
+	"bufio"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
 	"sync"
 	"testing"
 	"time"
@@ -25,6 +28,22 @@ func clearKeys(cluster *RedisCluster) {
 	}
 }
 
+func TestRedisCluster_ACLSetUserAll(t *testing.T) {
+	cluster := NewRedisCluster(clusterOption)
+	reply, err := cluster.ACLSetUserAll("godis", NewACLRules().On().NoPass().AllKeys().AllCommands())
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", reply)
+	replies, err := cluster.FanOut(FanOutAllNodes, func(redis *Redis) (interface{}, error) {
+		return redis.ACLGetUser("godis")
+	})
+	require.Nil(t, err)
+	for _, user := range replies {
+		assert.NotNil(t, user)
+	}
+	_, err = cluster.ACLDelUserAll("godis")
+	assert.Nil(t, err)
+}
+
 func TestRedisCluster_Append(t *testing.T) {
 	cluster := NewRedisCluster(clusterOption)
 	clearKeys(cluster)
@@ -170,6 +189,22 @@ func TestRedisCluster_Brpoplpush(t *testing.T) {
 	time.Sleep(1 * time.Second)
 }
 
+func TestRedisCluster_Copy(t *testing.T) {
+	cluster := NewRedisCluster(clusterOption)
+	_, err := cluster.Set("{godis}a", "1")
+	assert.Nil(t, err)
+	copied, err := cluster.Copy("{godis}a", "{godis}b", NewCopyArgs().Replace())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), copied)
+	_, err = cluster.Copy("a", "b", nil)
+	assert.NotNil(t, err)
+	_, err = cluster.Touch("a", "b")
+	assert.NotNil(t, err)
+	unlinked, err := cluster.Unlink("{godis}a", "{godis}b")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), unlinked)
+}
+
 func TestRedisCluster_Decr(t *testing.T) {
 	redis := NewRedisCluster(clusterOption)
 	clearKeys(redis)
@@ -265,6 +300,25 @@ func TestRedisCluster_ExpireAt(t *testing.T) {
 	assert.Equal(t, "", ret)
 }
 
+func TestRedisCluster_FCall(t *testing.T) {
+	cluster := NewRedisCluster(clusterOption)
+	name, err := cluster.FunctionLoadReplaceAll(testFunctionLibrary)
+	assert.Nil(t, err)
+	assert.Equal(t, "godislib", name)
+	_, err = cluster.Set("godis", "good")
+	assert.Nil(t, err)
+	reply, err := cluster.FCall("godis_get", []string{"godis"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "good", reply)
+	reply, err = cluster.FCallRO("godis_echo", []string{"godis"}, []string{"a"})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a"}, reply)
+	_, err = cluster.FCall("godis_get", []string{"a", "b"}, nil)
+	assert.NotNil(t, err)
+	_, err = cluster.FunctionDeleteAll("godislib")
+	assert.Nil(t, err)
+}
+
 func TestRedisCluster_Geo(t *testing.T) {
 	redis := NewRedisCluster(clusterOption)
 	clearKeys(redis)
@@ -315,6 +369,22 @@ func TestRedisCluster_Geo(t *testing.T) {
 	t.Log(resp)
 }
 
+func TestRedisCluster_GeoSearch(t *testing.T) {
+	cluster := NewRedisCluster(clusterOption)
+	_, err := cluster.GeoAdd("{godis}src", 121, 37, "a")
+	assert.Nil(t, err)
+	resp, err := cluster.GeoRadiusRO("{godis}src", 121, 37, 1, GeoUnitKm)
+	assert.Nil(t, err)
+	assert.Len(t, resp, 1)
+	count, err := cluster.GeoSearchStore("{godis}dst", "{godis}src", NewGeoSearchQuery().FromMember("a").ByRadius(1, GeoUnitKm))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+	_, err = cluster.GeoSearchStore("dst", "src", NewGeoSearchQuery().FromMember("a").ByRadius(1, GeoUnitKm))
+	assert.NotNil(t, err)
+	_, err = cluster.Del("{godis}src", "{godis}dst")
+	assert.Nil(t, err)
+}
+
 func TestRedisCluster_Get(t *testing.T) {
 	redis := NewRedisCluster(clusterOption)
 	redis.Set("godis", "good")
@@ -584,6 +654,112 @@ func TestRedisCluster_IncrByFloat(t *testing.T) {
 func TestRedisCluster_Keys(t *testing.T) {
 	redis := NewRedisCluster(clusterOption)
 	clearKeys(redis)
+
+	redis.Set("godis1", "good")
+	redis.Set("godis2", "good")
+	redis.Set("godis3", "good")
+	keys, err := redis.Keys("godis*")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"godis1", "godis2", "godis3"}, keys)
+}
+
+func TestRedisCluster_FanOut(t *testing.T) {
+	redis := NewRedisCluster(clusterOption)
+	s, err := redis.FlushAll()
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", s)
+
+	for i := 0; i < 100; i++ {
+		redis.Set(fmt.Sprintf("godis%d", i), "good")
+	}
+	c, err := redis.DbSize()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100), c)
+
+	key, err := redis.RandomKey()
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", key)
+
+	infos, err := redis.Info("server")
+	assert.Nil(t, err)
+	assert.Equal(t, len(clusterOption.Nodes), len(infos))
+
+	replies, err := redis.FanOut(FanOutAllMasters, func(redis *Redis) (interface{}, error) {
+		return redis.Ping()
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(replies))
+
+	_, err = redis.FanOut(FanOutAllMasters, func(redis *Redis) (interface{}, error) {
+		return redis.ConfigSet("godis", "good")
+	})
+	fanOutErr, ok := err.(*ClusterFanOutError)
+	require.True(t, ok)
+	assert.Equal(t, 3, len(fanOutErr.NodeErrors))
+
+	sha, err := redis.ScriptLoadAll(`return redis.call("get",KEYS[1])`)
+	assert.Nil(t, err)
+	v, err := redis.EvalSha(sha, 1, "godis1")
+	assert.Nil(t, err)
+	assert.Equal(t, "good", v)
+
+	s, err = redis.ScriptFlush()
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", s)
+
+	s, err = redis.FlushDB()
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", s)
+	c, err = redis.DbSize()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), c)
+}
+
+func TestRedisCluster_FanOut_partial(t *testing.T) {
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		for {
+			command := readFakeCommand(reader)
+			if command == nil {
+				return
+			}
+			switch command[0] {
+			case "KEYS":
+				_, _ = conn.Write([]byte("*1\r\n$5\r\ngodis\r\n"))
+			case "DBSIZE":
+				_, _ = conn.Write([]byte(":3\r\n"))
+			case "INFO":
+				_, _ = conn.Write([]byte("$4\r\ninfo\r\n"))
+			case "RANDOMKEY":
+				_, _ = conn.Write([]byte("$5\r\ngodis\r\n"))
+			}
+		}
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	deadPort := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+	alive := fmt.Sprintf("127.0.0.1:%d", serverOption.Port)
+	dead := fmt.Sprintf("127.0.0.1:%d", deadPort)
+	cluster := newTestClusterWithSlots(map[string][]int{alive: slotRange(0, 8191), dead: slotRange(8192, 16383)})
+
+	keys, err := cluster.Keys("*")
+	assert.Equal(t, []string{"godis"}, keys)
+	fanOutErr, ok := err.(*ClusterFanOutError)
+	require.True(t, ok)
+	assert.Equal(t, dead, fanOutErr.NodeErrors[0].Node)
+	size, err := cluster.DbSize()
+	assert.Equal(t, int64(3), size)
+	fanOutErr, ok = err.(*ClusterFanOutError)
+	require.True(t, ok)
+	assert.Len(t, fanOutErr.NodeErrors, 1)
+	infos, err := cluster.Info()
+	assert.Equal(t, map[string]string{alive: "info"}, infos)
+	fanOutErr, ok = err.(*ClusterFanOutError)
+	require.True(t, ok)
+	assert.Len(t, fanOutErr.NodeErrors, 1)
+	key, err := cluster.RandomKey()
+	assert.Nil(t, err)
+	assert.Equal(t, "godis", key)
 }
 
 func TestRedisCluster_Lindex(t *testing.T) {
@@ -697,6 +873,19 @@ func TestRedisCluster_List(t *testing.T) {
 	assert.Equal(t, []string{"1", "2", "2.0", "3"}, arr)
 }
 
+func TestRedisCluster_LMove(t *testing.T) {
+	cluster := NewRedisCluster(clusterOption)
+	_, err := cluster.RPush("{godis}a", "a")
+	assert.Nil(t, err)
+	element, err := cluster.LMove("{godis}a", "{godis}b", ListDirectionLeft, ListDirectionLeft)
+	assert.Nil(t, err)
+	assert.Equal(t, "a", element)
+	_, err = cluster.LMove("a", "b", ListDirectionLeft, ListDirectionLeft)
+	assert.NotNil(t, err)
+	_, err = cluster.Del("{godis}a", "{godis}b")
+	assert.Nil(t, err)
+}
+
 func TestRedisCluster_Persist(t *testing.T) {
 	redis := NewRedisCluster(clusterOption)
 	clearKeys(redis)
@@ -846,6 +1035,80 @@ func TestRedisCluster_Rename(t *testing.T) {
 	assert.Equal(t, int64(0), c)
 }
 
+func TestRedisCluster_runReadOnly(t *testing.T) {
+	var mu sync.Mutex
+	replicaCommands := make([][]string, 0)
+	masterCommands := make([][]string, 0)
+	masterOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		go func() {
+			defer conn.Close()
+			for command := readFakeCommand(reader); command != nil; command = readFakeCommand(reader) {
+				mu.Lock()
+				masterCommands = append(masterCommands, command)
+				mu.Unlock()
+				_, _ = conn.Write([]byte("$6\r\nmaster\r\n"))
+			}
+		}()
+	})
+	master := fmt.Sprintf("127.0.0.1:%d", masterOption.Port)
+	replicaOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		go func() {
+			defer conn.Close()
+			for command := readFakeCommand(reader); command != nil; command = readFakeCommand(reader) {
+				mu.Lock()
+				replicaCommands = append(replicaCommands, command)
+				mu.Unlock()
+				switch {
+				case command[0] == "READONLY":
+					_, _ = conn.Write([]byte("+OK\r\n"))
+				case command[1] == "wrongtype":
+					_, _ = conn.Write([]byte("-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"))
+				case command[1] == "moved":
+					_, _ = conn.Write([]byte("-MOVED 0 " + master + "\r\n"))
+				default:
+					_, _ = conn.Write([]byte("$7\r\nreplica\r\n"))
+				}
+			}
+		}()
+	})
+	replica := fmt.Sprintf("127.0.0.1:%d", replicaOption.Port)
+	cluster := newTestClusterWithSlots(map[string][]int{master: slotRange(0, 16383)})
+	view := &clusterView{
+		owners:   make([]string, clusterSlotCount),
+		replicas: map[string]string{replica: master},
+		nodes:    map[string]bool{master: true, replica: true},
+	}
+	for slot := range view.owners {
+		view.owners[slot] = master
+	}
+	cluster.connectionHandler.cache.applyClusterView(view, false)
+	get := func(key string) (interface{}, error) {
+		command := newRedisClusterCommand(cluster.MaxAttempts, cluster.MaxRedirects, cluster.connectionHandler)
+		command.execute = func(redis *Redis) (interface{}, error) {
+			return redis.Get(key)
+		}
+		return command.runReadOnly(key)
+	}
+
+	//READONLY is sent once when the connection of the replica is created
+	for i := 0; i < 2; i++ {
+		reply, err := get("godis")
+		assert.Nil(t, err)
+		assert.Equal(t, "replica", reply)
+	}
+	//the errors of the command are not retried on the master
+	_, err := get("wrongtype")
+	assert.NotNil(t, err)
+	reply, err := get("moved")
+	assert.Nil(t, err)
+	assert.Equal(t, "master", reply)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, [][]string{{"READONLY"}, {"GET", "godis"}, {"GET", "godis"}, {"GET", "wrongtype"}, {"GET", "moved"}}, replicaCommands)
+	assert.Equal(t, [][]string{{"GET", "moved"}}, masterCommands)
+}
+
 func TestRedisCluster_Scan(t *testing.T) {
 	redis := NewRedisCluster(clusterOption)
 	clearKeys(redis)
@@ -1170,6 +1433,21 @@ func TestRedisCluster_Type(t *testing.T) {
 	assert.Equal(t, "string", s)
 }
 
+func TestRedisCluster_XRead(t *testing.T) {
+	cluster := NewRedisCluster(clusterOption)
+	_, err := cluster.XAdd("{godis}a", &XAddArgs{Fields: map[string]string{"a": "1"}})
+	assert.Nil(t, err)
+	_, err = cluster.XAdd("{godis}b", &XAddArgs{Fields: map[string]string{"b": "2"}})
+	assert.Nil(t, err)
+	streams, err := cluster.XRead(&XReadArgs{Keys: []string{"{godis}a", "{godis}b"}, IDs: []string{"0", "0"}})
+	assert.Nil(t, err)
+	assert.Len(t, streams, 2)
+	_, err = cluster.XRead(&XReadArgs{Keys: []string{"a", "b"}, IDs: []string{"0", "0"}})
+	assert.NotNil(t, err)
+	_, err = cluster.Del("{godis}a", "{godis}b")
+	assert.Nil(t, err)
+}
+
 func TestRedisCluster_Zadd(t *testing.T) {
 	redis := NewRedisCluster(clusterOption)
 	clearKeys(redis)
@@ -1324,6 +1602,21 @@ func TestRedisCluster_Zadd(t *testing.T) {
 	assert.Equal(t, int64(4), c) //f is not in godis
 }
 
+func TestRedisCluster_ZDiff(t *testing.T) {
+	cluster := NewRedisCluster(clusterOption)
+	_, err := cluster.ZAddByMap("{godis}a", map[string]float64{"a": 1, "b": 2})
+	assert.Nil(t, err)
+	_, err = cluster.ZAddByMap("{godis}b", map[string]float64{"a": 1})
+	assert.Nil(t, err)
+	members, err := cluster.ZDiff("{godis}a", "{godis}b")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b"}, members)
+	_, err = cluster.ZDiff("a", "b")
+	assert.NotNil(t, err)
+	_, err = cluster.Del("{godis}a", "{godis}b")
+	assert.Nil(t, err)
+}
+
 func TestRedisCluster_Zscan(t *testing.T) {
 	redis := NewRedisCluster(clusterOption)
 	clearKeys(redis)
@@ -1400,15 +1693,12 @@ func TestRedisCluster_Mset(t *testing.T) {
 func TestRedisCluster_Basic(t *testing.T) {
 	cluster := NewRedisCluster(clusterOption)
 	clearKeys(cluster)
-	cluster.connectionHandler.cache.slots.Range(func(key, value interface{}) bool {
-		cluster.connectionHandler.cache.slots.Delete(key)
-		return true
-	})
+	cluster.connectionHandler.cache.storeSlots(&clusterSlotTable{})
 	s, err := cluster.Echo("godis")
 	assert.Nil(t, err)
 	assert.Equal(t, "godis", s)
 
-	cmd := newRedisClusterCommand(cluster.MaxAttempts, cluster.connectionHandler)
+	cmd := newRedisClusterCommand(cluster.MaxAttempts, cluster.MaxRedirects, cluster.connectionHandler)
 	cmd.execute = func(redis *Redis) (interface{}, error) {
 		return redis.Echo("godis")
 	}
@@ -1426,3 +1716,71 @@ func TestRedisCluster_Basic(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Nil(t, resp)
 }
+
+func TestRedisCluster_runWithRetries(t *testing.T) {
+	cluster := NewRedisCluster(clusterOption)
+	clearKeys(cluster)
+
+	cmd := newRedisClusterCommand(cluster.MaxAttempts, cluster.MaxRedirects, cluster.connectionHandler)
+	tries := 0
+	cmd.execute = func(redis *Redis) (interface{}, error) {
+		tries++
+		if tries < 3 {
+			return nil, newTryAgainError("TRYAGAIN Multiple keys request during rehashing of slot")
+		}
+		return redis.Echo("godis")
+	}
+	resp, err := cmd.run("godis")
+	assert.Nil(t, err)
+	assert.Equal(t, "godis", resp)
+	assert.Equal(t, 3, tries)
+
+	cmd = newRedisClusterCommand(cluster.MaxAttempts, cluster.MaxRedirects, cluster.connectionHandler)
+	tries = 0
+	cmd.execute = func(redis *Redis) (interface{}, error) {
+		tries++
+		return nil, newClusterError("CLUSTERDOWN The cluster is down")
+	}
+	resp, err = cmd.run("godis")
+	assert.NotNil(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, cluster.MaxAttempts, tries)
+
+	cmd = newRedisClusterCommand(cluster.MaxAttempts, 2, cluster.connectionHandler)
+	tries = 0
+	cmd.execute = func(redis *Redis) (interface{}, error) {
+		tries++
+		return nil, newAskDataError("ASK 4768 localhost:7000", "localhost", 7000, 4768)
+	}
+	resp, err = cmd.run("godis")
+	assert.NotNil(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, 3, tries)
+}
+
+func TestRedisClusterInfoCache_getSlotReplicaPools(t *testing.T) {
+	cache := newRedisClusterInfoCache(time.Second, time.Second, "", nil)
+	cache.applyClusterView(testClusterView([]interface{}{
+		clusterSlotsReply(0, 8191, 7000, 7003, 7004),
+		clusterSlotsReply(8192, 16383, 7001),
+	}), false)
+	pools := cache.getSlotReplicaPools(0)
+	assert.Len(t, pools, 2)
+	replica, _ := cache.replicaNodes.Load("localhost:7003")
+	assert.Contains(t, pools, replica)
+	assert.NotContains(t, pools, cache.getNode("localhost:7003"))
+	replica, _ = cache.replicaNodes.Load("localhost:7004")
+	assert.Contains(t, pools, replica)
+	assert.Len(t, cache.getSlotReplicaPools(16383), 0)
+	assert.Equal(t, map[string]string{"localhost:7003": "localhost:7000", "localhost:7004": "localhost:7000"}, cache.getReplicas())
+
+	//7003 is promoted, its read only pool is retired
+	cache.applyClusterView(testClusterView([]interface{}{
+		clusterSlotsReply(0, 8191, 7003, 7004),
+		clusterSlotsReply(8192, 16383, 7001),
+	}), false)
+	pools = cache.getSlotReplicaPools(0)
+	assert.Equal(t, []*Pool{replica.(*Pool)}, pools)
+	_, ok := cache.replicaNodes.Load("localhost:7003")
+	assert.False(t, ok)
+}
@@ -0,0 +1,113 @@
+package godis
+
+//ClusterTx transaction of the cluster, the connection is pinned to the master which owns the slot of the keys
+type ClusterTx struct {
+	redis *Redis
+	keys  []string
+}
+
+//Redis get the pinned connection, use it to read the watched keys before Pipelined,
+// the connection is managed by the cluster, don't close it
+func (tx *ClusterTx) Redis() *Redis {
+	return tx.redis
+}
+
+//Keys the keys of the transaction
+func (tx *ClusterTx) Keys() []string {
+	return tx.keys
+}
+
+//Pipelined queue the commands of fn between MULTI and EXEC, then execute the transaction,
+// the commands must only access the keys of the transaction.
+// when one of the watched keys was modified, TxFailedError is returned and the transaction is retried by Watch
+func (tx *ClusterTx) Pipelined(fn func(t *Transaction) error) ([]interface{}, error) {
+	t, err := tx.redis.Multi()
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(t); err != nil {
+		_, _ = t.Discard()
+		return nil, err
+	}
+	return t.execWatched()
+}
+
+//TxPipelined run the commands of fn in a MULTI/EXEC transaction on the master which owns the slot of the keys,
+// all the keys must be in the same slot, use hash tags like {user}.name and {user}.age to make sure of it.
+// when the slot is moved during the transaction, the transaction is restarted on the new owner
+func (r *RedisCluster) TxPipelined(keys []string, fn func(t *Transaction) error) ([]interface{}, error) {
+	var result []interface{}
+	err := r.runTx(keys, false, func(tx *ClusterTx) error {
+		var err error
+		result, err = tx.Pipelined(fn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//Watch WATCH the keys on the master which owns the slot of the keys, then call fn with the pinned transaction,
+// all the keys must be in the same slot.
+// fn usually reads the keys by tx.Redis() and writes them by tx.Pipelined,
+// fn is called again when one of the keys was modified by others or the slot is moved, at most MaxAttempts times
+func (r *RedisCluster) Watch(fn func(tx *ClusterTx) error, keys ...string) error {
+	return r.runTx(keys, true, fn)
+}
+
+func (r *RedisCluster) runTx(keys []string, watch bool, fn func(tx *ClusterTx) error) error {
+	if len(keys) == 0 {
+		return newClusterOperationError("no way to dispatch this transaction to Redis cluster,because keys are empty")
+	}
+	crc16 := newCRC16()
+	slot := crc16.getStringSlot(keys[0])
+	for _, key := range keys[1:] {
+		if crc16.getStringSlot(key) != slot {
+			return newClusterOperationError("no way to dispatch this transaction to Redis cluster,because keys have different slots")
+		}
+	}
+	var err error
+	for retries := 0; retries < r.MaxAttempts; retries++ {
+		err = r.runTxOnce(int(slot), keys, watch, fn)
+		switch err.(type) {
+		case nil:
+			return nil
+		case *MovedDataError, *ConnectError:
+			continue
+		case *AskDataError, *TryAgainError, *ClusterError, *TxFailedError:
+			clusterBackoff(retries)
+			continue
+		}
+		return err
+	}
+	return err
+}
+
+func (r *RedisCluster) runTxOnce(slot int, keys []string, watch bool, fn func(tx *ClusterTx) error) error {
+	redis, err := r.connectionHandler.getConnectionFromSlot(slot)
+	if err != nil {
+		if _, ok := err.(*ConnectError); ok {
+			r.connectionHandler.renewSlotCache()
+		}
+		return err
+	}
+	defer redis.Close()
+	if watch {
+		if _, err = redis.Watch(keys...); err == nil {
+			err = fn(&ClusterTx{redis: redis, keys: keys})
+		}
+		if redis.client.isInWatch {
+			_, _ = redis.Unwatch()
+		}
+	} else {
+		err = fn(&ClusterTx{redis: redis, keys: keys})
+	}
+	switch err.(type) {
+	case *MovedDataError, *ClusterError:
+		r.connectionHandler.renewSlotCache(redis)
+	case *ConnectError:
+		r.connectionHandler.renewSlotCache()
+	}
+	return err
+}
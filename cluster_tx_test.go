@@ -0,0 +1,62 @@
+package godis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisCluster_TxPipelined(t *testing.T) {
+	cluster := NewRedisCluster(clusterOption)
+	cluster.Del("{godis}1", "{godis}2")
+
+	result, err := cluster.TxPipelined([]string{"{godis}1", "{godis}2"}, func(t *Transaction) error {
+		if _, err := t.MSet("{godis}1", "good", "{godis}2", "1"); err != nil {
+			return err
+		}
+		_, err := t.Exists("{godis}1", "{godis}2")
+		return err
+	})
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+	s, _ := cluster.Get("{godis}1")
+	assert.Equal(t, "good", s)
+
+	_, err = cluster.TxPipelined([]string{"godis1", "godis2"}, func(t *Transaction) error {
+		return nil
+	})
+	assert.NotNil(t, err)
+
+	_, err = cluster.TxPipelined(nil, func(t *Transaction) error {
+		return nil
+	})
+	assert.NotNil(t, err)
+}
+
+func TestRedisCluster_Watch(t *testing.T) {
+	cluster := NewRedisCluster(clusterOption)
+	cluster.Del("{godis}1")
+	cluster.Set("{godis}1", "1")
+
+	calls := 0
+	err := cluster.Watch(func(tx *ClusterTx) error {
+		calls++
+		n, err := tx.Redis().Get("{godis}1")
+		if err != nil {
+			return err
+		}
+		if calls == 1 {
+			//modified by others, the transaction should be retried
+			cluster.Set("{godis}1", "10")
+		}
+		_, err = tx.Pipelined(func(t *Transaction) error {
+			_, err := t.MSet("{godis}1", n+"0")
+			return err
+		})
+		return err
+	}, "{godis}1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, calls)
+	s, _ := cluster.Get("{godis}1")
+	assert.Equal(t, "100", s)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 //ZAddParams ...
@@ -34,6 +35,25 @@ func (p *ZAddParams) CH() *ZAddParams {
 	return p
 }
 
+//GT set GT parameter, Only update existing elements if the new score is greater than the current score. Never prevent adding new elements.
+func (p *ZAddParams) GT() *ZAddParams {
+	p.params["GT"] = "GT"
+	return p
+}
+
+//LT set LT parameter, Only update existing elements if the new score is less than the current score. Never prevent adding new elements.
+func (p *ZAddParams) LT() *ZAddParams {
+	p.params["LT"] = "LT"
+	return p
+}
+
+//INCR set INCR parameter, ZADD acts like ZINCRBY and replies the new score, so it's only accepted by ZIncrBy,
+// ZIncrBy sets it implicitly when the params are given.
+func (p *ZAddParams) INCR() *ZAddParams {
+	p.params["INCR"] = "INCR"
+	return p
+}
+
 //getByteParams get all params
 func (p *ZAddParams) getByteParams(key []byte, args ...[]byte) [][]byte {
 	arr := make([][]byte, 0)
@@ -44,9 +64,18 @@ func (p *ZAddParams) getByteParams(key []byte, args ...[]byte) [][]byte {
 	if p.Contains("NX") {
 		arr = append(arr, []byte("NX"))
 	}
+	if p.Contains("GT") {
+		arr = append(arr, []byte("GT"))
+	}
+	if p.Contains("LT") {
+		arr = append(arr, []byte("LT"))
+	}
 	if p.Contains("CH") {
 		arr = append(arr, []byte("CH"))
 	}
+	if p.Contains("INCR") {
+		arr = append(arr, []byte("INCR"))
+	}
 	for _, a := range args {
 		arr = append(arr, a)
 	}
@@ -150,6 +179,12 @@ func (s *ScanParams) Count(count int) *ScanParams {
 	return s
 }
 
+//Type only return keys of the given type,such as string,list,set,zset,hash,stream
+func (s *ScanParams) Type(keyType string) *ScanParams {
+	s.params[keywordType.name] = keyType
+	return s
+}
+
 //getParams get all scan params
 func (s ScanParams) getParams() [][]byte {
 	arr := make([][]byte, 0)
@@ -168,6 +203,14 @@ func (s ScanParams) GetMatch() string {
 	return ""
 }
 
+//GetType get the type param value
+func (s ScanParams) GetType() string {
+	if v, ok := s.params[keywordType.name]; ok {
+		return v
+	}
+	return ""
+}
+
 //ListOption  list option
 type ListOption struct {
 	name string // name  ...
@@ -190,6 +233,70 @@ var (
 	ListOptionAfter = newListOption("AFTER")
 )
 
+//ExpireCondition the condition of setting the expiration of a key or a hash field (redis 7.0+)
+type ExpireCondition struct {
+	name string // name of ExpireCondition
+}
+
+//getRaw get the name byte array
+func (c *ExpireCondition) getRaw() []byte {
+	return []byte(c.name)
+}
+
+func newExpireCondition(name string) *ExpireCondition {
+	return &ExpireCondition{name}
+}
+
+var (
+	//ExpireConditionNX set the expiration only when there is no expiration
+	ExpireConditionNX = newExpireCondition("NX")
+	//ExpireConditionXX set the expiration only when there is an expiration
+	ExpireConditionXX = newExpireCondition("XX")
+	//ExpireConditionGT set the expiration only when it's greater than the current one, no expiration is infinite
+	ExpireConditionGT = newExpireCondition("GT")
+	//ExpireConditionLT set the expiration only when it's less than the current one, no expiration is infinite
+	ExpireConditionLT = newExpireCondition("LT")
+)
+
+//FlushMode how the memory is freed by the FLUSH commands
+type FlushMode struct {
+	name string // name of FlushMode
+}
+
+//getRaw get the name byte array
+func (m *FlushMode) getRaw() []byte {
+	return []byte(m.name)
+}
+
+func newFlushMode(name string) *FlushMode {
+	return &FlushMode{name}
+}
+
+var (
+	//FlushModeAsync free the memory in another thread
+	FlushModeAsync = newFlushMode("ASYNC")
+	//FlushModeSync free the memory before returning
+	FlushModeSync = newFlushMode("SYNC")
+)
+
+//FanOutMode decide which cluster nodes a keyless command is sent to
+type FanOutMode struct {
+	name string // name of fan out mode
+}
+
+func newFanOutMode(name string) *FanOutMode {
+	return &FanOutMode{name}
+}
+
+var (
+	//FanOutAllMasters send the command to every master node
+	FanOutAllMasters = newFanOutMode("ALL_MASTERS")
+	//FanOutAllNodes send the command to every node, include replicas
+	FanOutAllNodes = newFanOutMode("ALL_NODES")
+	//FanOutAnyNode send the command to one reachable node
+	FanOutAnyNode = newFanOutMode("ANY_NODE")
+)
+
 //GeoUnit geo unit,m|mi|km|ft
 type GeoUnit struct {
 	name string // name of geo unit
@@ -299,23 +406,69 @@ type Tuple struct {
 	score   float64
 }
 
+//Element return the member of the tuple
+func (t Tuple) Element() string {
+	return t.element
+}
+
+//Score return the score of the tuple
+func (t Tuple) Score() float64 {
+	return t.score
+}
+
 //GeoRadiusResponse geo radius response
 type GeoRadiusResponse struct {
 	member     string
 	distance   float64
 	coordinate GeoCoordinate
+	hash       int64
 }
 
 func newGeoRadiusResponse(member string) *GeoRadiusResponse {
 	return &GeoRadiusResponse{member: member}
 }
 
+//Member return the member of the response
+func (g GeoRadiusResponse) Member() string {
+	return g.member
+}
+
+//Distance return the distance from the center, it's only set with WITHDIST
+func (g GeoRadiusResponse) Distance() float64 {
+	return g.distance
+}
+
+//Coordinate return the coordinate of the member, it's only set with WITHCOORD
+func (g GeoRadiusResponse) Coordinate() GeoCoordinate {
+	return g.coordinate
+}
+
+//Hash return the raw geohash-encoded sorted set score of the member, it's only set with WITHHASH
+func (g GeoRadiusResponse) Hash() int64 {
+	return g.hash
+}
+
 //GeoCoordinate geo coordinate struct
 type GeoCoordinate struct {
 	longitude float64
 	latitude  float64
 }
 
+//NewGeoCoordinate create a coordinate of longitude and latitude
+func NewGeoCoordinate(longitude, latitude float64) GeoCoordinate {
+	return GeoCoordinate{longitude: longitude, latitude: latitude}
+}
+
+//Longitude return the longitude of the coordinate
+func (g GeoCoordinate) Longitude() float64 {
+	return g.longitude
+}
+
+//Latitude return the latitude of the coordinate
+func (g GeoCoordinate) Latitude() float64 {
+	return g.latitude
+}
+
 //ScanResult scan result struct
 type ScanResult struct {
 	Cursor  string
@@ -388,6 +541,23 @@ type RedisPubSub struct {
 	OnPUnSubscribe     func(pattern string, subscribedChannels int)  //listen pattern unsubscribe event
 	OnPSubscribe       func(pattern string, subscribedChannels int)  //listen pattern subscribe event
 	OnPong             func(channel string)                          //listen heart beat event
+	OnSMessage         func(channel, message string)                 //receive shard channel message
+	OnSSubscribe       func(channel string, subscribedChannels int)  //listen shard channel subscribe event
+	OnSUnSubscribe     func(channel string, subscribedChannels int)  //listen shard channel unsubscribe event
+
+	Reconnect           bool            //reconnect with backoff and resubscribe when the connection is broken
+	MaxReconnectRetries int             //max retries of one reconnection, 0 means retry until success
+	OnReconnect         func(err error) //the broken connection is reconnected and resubscribed, messages may be missed meanwhile
+
+	HealthCheckInterval time.Duration //send PING in the interval, the connection is broken if the pong isn't received in time, 0 disables the health check
+	HealthCheckTimeout  time.Duration //max wait time of the pong, default is the read timeout of the redis
+
+	sharded *clusterShardedSubscriber
+	pinging bool //a PING is waiting for the pong, guarded by the lock of the redis
+	//subscriptions confirmed by the server, they are resubscribed after reconnecting
+	channels      map[string]bool
+	patterns      map[string]bool
+	shardChannels map[string]bool
 }
 
 //Subscribe subscribe some channels
@@ -462,6 +632,41 @@ func (r *RedisPubSub) PUnSubscribe(channels ...string) error {
 	return nil
 }
 
+//SSubscribe subscribe some shard channels,
+// when the pubsub is subscribed to a cluster, the channels are routed to the masters owning their slots
+func (r *RedisPubSub) SSubscribe(channels ...string) error {
+	if r.sharded != nil {
+		return r.sharded.subscribe(channels...)
+	}
+	r.redis.mu.RLock()
+	defer r.redis.mu.RUnlock()
+	if r.redis.client == nil {
+		return newConnectError("redisPubSub is not subscribed to a Redis instance")
+	}
+	err := r.redis.client.ssubscribe(channels...)
+	if err != nil {
+		return err
+	}
+	return r.redis.client.flush()
+}
+
+//SUnSubscribe unsubscribe some shard channels, unsubscribe all the shard channels if channels is empty
+func (r *RedisPubSub) SUnSubscribe(channels ...string) error {
+	if r.sharded != nil {
+		return r.sharded.unsubscribe(channels...)
+	}
+	r.redis.mu.RLock()
+	defer r.redis.mu.RUnlock()
+	if r.redis.client == nil {
+		return newConnectError("redisPubSub is not subscribed to a Redis instance")
+	}
+	err := r.redis.client.sunsubscribe(channels...)
+	if err != nil {
+		return err
+	}
+	return r.redis.client.flush()
+}
+
 func (r *RedisPubSub) proceed(redis *Redis, channels ...string) error {
 	r.redis = redis
 	err := r.redis.client.subscribe(channels...)
@@ -472,7 +677,8 @@ func (r *RedisPubSub) proceed(redis *Redis, channels ...string) error {
 	if err != nil {
 		return err
 	}
-	return r.process(redis)
+	r.channels = trackSubscriptions(r.channels, channels, true)
+	return r.processWithReconnect(redis)
 }
 
 func (r *RedisPubSub) isSubscribed() bool {
@@ -489,7 +695,137 @@ func (r *RedisPubSub) proceedWithPatterns(redis *Redis, patterns ...string) erro
 	if err != nil {
 		return err
 	}
-	return r.process(redis)
+	r.patterns = trackSubscriptions(r.patterns, patterns, true)
+	return r.processWithReconnect(redis)
+}
+
+func (r *RedisPubSub) proceedWithShardChannels(redis *Redis, channels ...string) error {
+	r.redis = redis
+	err := r.redis.client.ssubscribe(channels...)
+	if err != nil {
+		return err
+	}
+	err = r.redis.client.flush()
+	if err != nil {
+		return err
+	}
+	r.shardChannels = trackSubscriptions(r.shardChannels, channels, true)
+	return r.processWithReconnect(redis)
+}
+
+//processWithReconnect process the replies, the connection is reconnected and resubscribed when it's broken if Reconnect is set
+func (r *RedisPubSub) processWithReconnect(redis *Redis) error {
+	if r.HealthCheckInterval > 0 {
+		stop := make(chan struct{})
+		defer close(stop)
+		go r.healthCheck(redis, stop)
+	}
+	for {
+		err := r.process(redis)
+		if err == nil || !r.Reconnect {
+			return err
+		}
+		if _, ok := err.(*ConnectError); !ok {
+			return err
+		}
+		if err := r.reconnect(redis, err); err != nil {
+			return err
+		}
+	}
+}
+
+//healthCheck send PING periodically until stop is closed
+func (r *RedisPubSub) healthCheck(redis *Redis, stop chan struct{}) {
+	ticker := time.NewTicker(r.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			r.ping(redis)
+		}
+	}
+}
+
+//ping send PING with a read deadline, process returns a ConnectError on the deadline if the pong is missed
+func (r *RedisPubSub) ping(redis *Redis) {
+	redis.mu.Lock()
+	defer redis.mu.Unlock()
+	if r.pinging || redis.client == nil || !redis.client.connection.isConnected() {
+		return
+	}
+	timeout := r.HealthCheckTimeout
+	if timeout <= 0 {
+		timeout = redis.client.connection.soTimeout
+	}
+	//the deadline is set before sending, so it can't override the clearing by a fast pong
+	err := redis.client.connection.setReadDeadline(time.Now().Add(timeout))
+	if err == nil {
+		err = redis.client.ping()
+	}
+	if err == nil {
+		err = redis.client.flush()
+	}
+	if err != nil {
+		//unblock process, it reconnects or returns the error
+		_ = redis.client.close()
+		return
+	}
+	r.pinging = true
+}
+
+//pong clear the read deadline set by ping
+func (r *RedisPubSub) pong(redis *Redis) {
+	redis.mu.Lock()
+	defer redis.mu.Unlock()
+	r.pinging = false
+	_ = redis.client.connection.setReadDeadline(time.Time{})
+}
+
+//reconnect retry with backoff until the subscriptions are restored or MaxReconnectRetries is reached
+func (r *RedisPubSub) reconnect(redis *Redis, cause error) error {
+	err := cause
+	for retries := 0; r.MaxReconnectRetries <= 0 || retries < r.MaxReconnectRetries; retries++ {
+		time.Sleep(pubSubBackoff(retries))
+		if err = r.resubscribe(redis); err == nil {
+			if r.OnReconnect != nil {
+				r.OnReconnect(cause)
+			}
+			return nil
+		}
+	}
+	return err
+}
+
+func (r *RedisPubSub) resubscribe(redis *Redis) error {
+	redis.mu.Lock()
+	defer redis.mu.Unlock()
+	r.pinging = false
+	_ = redis.client.close()
+	redis.client.resetPipelinedCount()
+	if err := redis.client.connect(); err != nil {
+		return err
+	}
+	if err := redis.client.connection.setTimeoutInfinite(); err != nil {
+		return err
+	}
+	if len(r.channels) > 0 {
+		if err := redis.client.subscribe(subscriptionNames(r.channels)...); err != nil {
+			return err
+		}
+	}
+	if len(r.patterns) > 0 {
+		if err := redis.client.psubscribe(subscriptionNames(r.patterns)...); err != nil {
+			return err
+		}
+	}
+	if len(r.shardChannels) > 0 {
+		if err := redis.client.ssubscribe(subscriptionNames(r.shardChannels)...); err != nil {
+			return err
+		}
+	}
+	return redis.client.flush()
 }
 
 func (r *RedisPubSub) process(redis *Redis) error {
@@ -501,19 +837,32 @@ func (r *RedisPubSub) process(redis *Redis) error {
 		respUpper := strings.ToUpper(string(reply[0].([]byte)))
 		switch respUpper {
 		case keywordSubscribe.name:
+			r.channels = trackSubscriptions(r.channels, []string{pubSubReplyString(reply[1])}, true)
 			r.processSubscribe(reply)
 		case keywordUnsubscribe.name:
+			r.channels = trackSubscriptions(r.channels, []string{pubSubReplyString(reply[1])}, false)
 			r.processUnSubscribe(reply)
 		case keywordMessage.name:
 			r.processMessage(reply)
 		case keywordPMessage.name:
 			r.processPMessage(reply)
 		case keywordPSubscribe.name:
+			r.patterns = trackSubscriptions(r.patterns, []string{pubSubReplyString(reply[1])}, true)
 			r.processPSubscribe(reply)
 		case cmdPUnSubscribe.name:
+			r.patterns = trackSubscriptions(r.patterns, []string{pubSubReplyString(reply[1])}, false)
 			r.processPUnSubscribe(reply)
 		case keywordPong.name:
+			r.pong(redis)
 			r.processPong(reply)
+		case keywordSSubscribe.name:
+			r.shardChannels = trackSubscriptions(r.shardChannels, []string{pubSubReplyString(reply[1])}, true)
+			r.processSSubscribe(reply)
+		case keywordSUnsubscribe.name:
+			r.shardChannels = trackSubscriptions(r.shardChannels, []string{pubSubReplyString(reply[1])}, false)
+			r.processSUnSubscribe(reply)
+		case keywordSMessage.name:
+			r.processSMessage(reply)
 		default:
 			return fmt.Errorf("unknown message type: %v", reply)
 		}
@@ -603,13 +952,65 @@ func (r *RedisPubSub) processPUnSubscribe(reply []interface{}) {
 	r.OnPUnSubscribe(strPattern, r.subscribedChannels)
 }
 
+func (r *RedisPubSub) processSSubscribe(reply []interface{}) {
+	r.subscribedChannels = int(reply[2].(int64))
+	if r.OnSSubscribe != nil {
+		r.OnSSubscribe(pubSubReplyString(reply[1]), r.subscribedChannels)
+	}
+}
+
+func (r *RedisPubSub) processSUnSubscribe(reply []interface{}) {
+	r.subscribedChannels = int(reply[2].(int64))
+	if r.OnSUnSubscribe != nil {
+		r.OnSUnSubscribe(pubSubReplyString(reply[1]), r.subscribedChannels)
+	}
+}
+
+func (r *RedisPubSub) processSMessage(reply []interface{}) {
+	if r.OnSMessage != nil {
+		r.OnSMessage(pubSubReplyString(reply[1]), pubSubReplyString(reply[2]))
+	}
+}
+
 func (r *RedisPubSub) processPong(reply []interface{}) {
 	bPattern := reply[1].([]byte)
 	strPattern := ""
 	if bPattern != nil {
 		strPattern = string(bPattern)
 	}
-	r.OnPong(strPattern)
+	if r.OnPong != nil {
+		r.OnPong(strPattern)
+	}
+}
+
+//trackSubscriptions add or remove the subscriptions, the map is created if it's nil
+func trackSubscriptions(subscriptions map[string]bool, names []string, subscribed bool) map[string]bool {
+	if subscriptions == nil {
+		subscriptions = make(map[string]bool)
+	}
+	for _, name := range names {
+		if subscribed {
+			subscriptions[name] = true
+		} else {
+			delete(subscriptions, name)
+		}
+	}
+	return subscriptions
+}
+
+func subscriptionNames(subscriptions map[string]bool) []string {
+	names := make([]string, 0, len(subscriptions))
+	for name := range subscriptions {
+		names = append(names, name)
+	}
+	return names
+}
+
+func pubSubReplyString(reply interface{}) string {
+	if b, ok := reply.([]byte); ok {
+		return string(b)
+	}
+	return ""
 }
 
 //BitOP bit operation struct
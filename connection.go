@@ -17,6 +17,7 @@ type connection struct {
 	protocol          *protocol
 	broken            bool
 	pipelinedCommands int
+	infiniteTimeout   bool //the read deadline is managed by setTimeoutInfinite and setBlockingTimeout, see refreshWriteDeadline
 }
 
 func newConnection(host string, port int, connectionTimeout, soTimeout time.Duration) *connection {
@@ -53,14 +54,78 @@ func (c *connection) setTimeoutInfinite() error {
 		c.broken = true
 		return newConnectError(err.Error())
 	}
+	c.infiniteTimeout = true
 	return nil
 }
 
+//refreshWriteDeadline extend the deadline by soTimeout before the buffered commands are written.
+// with infinite timeout only the write deadline is extended, the read deadline is kept,
+// otherwise a blocking command or a subscription would time out after soTimeout while waiting for a healthy server
+func (c *connection) refreshWriteDeadline() error {
+	deadline := time.Now().Add(c.soTimeout)
+	var err error
+	if c.infiniteTimeout {
+		err = c.socket.SetWriteDeadline(deadline)
+	} else {
+		err = c.socket.SetDeadline(deadline)
+	}
+	if err != nil {
+		return newConnectError(err.Error())
+	}
+	return nil
+}
+
+//refreshReadDeadline extend the deadline by soTimeout after a read, so the next read of the reply has a full soTimeout.
+// with infinite timeout the read deadline is kept, see refreshWriteDeadline
+func (c *connection) refreshReadDeadline() error {
+	if c.infiniteTimeout {
+		return nil
+	}
+	if err := c.socket.SetDeadline(time.Now().Add(c.soTimeout)); err != nil {
+		return newConnectError(err.Error())
+	}
+	return nil
+}
+
+//setReadDeadline set the read deadline of the connection with infinite timeout, zero time means no deadline
+func (c *connection) setReadDeadline(deadline time.Time) error {
+	if c.socket == nil {
+		return newConnectError("socket is closed")
+	}
+	err := c.socket.SetReadDeadline(deadline)
+	if err != nil {
+		return newConnectError(err.Error())
+	}
+	return nil
+}
+
+//setBlockingTimeout extend the read timeout by the block time of a blocking command, negative block means blocking forever,
+// rollbackTimeout must be called after the reply is read
+func (c *connection) setBlockingTimeout(block time.Duration) error {
+	if err := c.setTimeoutInfinite(); err != nil {
+		return err
+	}
+	if block < 0 {
+		return nil
+	}
+	return c.setReadDeadline(time.Now().Add(block + c.soTimeout))
+}
+
+//setCommandBlockingTimeout like setBlockingTimeout for the commands taking a timeout argument like BZPOPMIN,
+// whose timeout 0 means blocking forever
+func (c *connection) setCommandBlockingTimeout(timeout time.Duration) error {
+	if timeout <= 0 {
+		return c.setBlockingTimeout(-1)
+	}
+	return c.setBlockingTimeout(timeout)
+}
+
 func (c *connection) rollbackTimeout() error {
 	if c.socket == nil {
 		c.broken = true
 		return newConnectError("socket is closed")
 	}
+	c.infiniteTimeout = false
 	err := c.socket.SetDeadline(time.Now().Add(c.connectionTimeout))
 	if err != nil {
 		c.broken = true
@@ -296,6 +361,7 @@ func (c *connection) connect() error {
 		return newConnectError(err.Error())
 	}
 	c.socket = conn
+	c.broken = false
 	os := newRedisOutputStream(bufio.NewWriter(c.socket), c)
 	is := newRedisInputStream(bufio.NewReader(c.socket), c)
 	c.protocol = newProtocol(os, is)
@@ -0,0 +1,62 @@
+package godis
+
+import (
+	"bufio"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+//slowFakeServer reply +OK to every command after the delay, no reply is sent to GET
+func slowFakeServer(t *testing.T, delay time.Duration) *Option {
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		go func() {
+			defer conn.Close()
+			for {
+				command := readFakeCommand(reader)
+				if command == nil {
+					return
+				}
+				if command[0] == "GET" {
+					continue
+				}
+				time.Sleep(delay)
+				_, _ = conn.Write([]byte("+OK\r\n"))
+			}
+		}()
+	})
+	serverOption.SoTimeout = 200 * time.Millisecond
+	return serverOption
+}
+
+func TestConnection_soTimeout(t *testing.T) {
+	redis := NewRedis(slowFakeServer(t, 100*time.Millisecond))
+	defer redis.Close()
+	//the deadline is extended by soTimeout for every command, so the slow replies within soTimeout are read
+	for i := 0; i < 3; i++ {
+		reply, err := redis.Set("godis", "good")
+		assert.Nil(t, err)
+		assert.Equal(t, "OK", reply)
+	}
+	start := time.Now()
+	_, err := redis.Get("godis")
+	assert.IsType(t, &ConnectError{}, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestConnection_infiniteTimeout(t *testing.T) {
+	redis := NewRedis(slowFakeServer(t, 500*time.Millisecond))
+	defer redis.Close()
+	//the reply of the blocking command comes after soTimeout, it's still read
+	reply, err := redis.BLMove("src", "dst", ListDirectionLeft, ListDirectionRight, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", reply)
+	assert.False(t, redis.client.connection.infiniteTimeout)
+
+	//soTimeout applies again after the blocking command
+	start := time.Now()
+	_, err = redis.Get("godis")
+	assert.IsType(t, &ConnectError{}, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
@@ -204,6 +204,8 @@ func ObjArrToGeoRadiusResponseReply(reply []interface{}, err error) ([]GeoRadius
 						longitude: ByteArrToFloat64(coord[0].([]byte)),
 						latitude:  ByteArrToFloat64(coord[1].([]byte)),
 					}
+				case int64:
+					resp.hash = info.(int64)
 				default:
 					resp.distance = ByteArrToFloat64(info.([]byte))
 				}
@@ -3,8 +3,10 @@ package godis
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"math"
 	"testing"
+	"time"
 )
 
 func TestBoolToByteArray(t *testing.T) {
@@ -292,3 +294,301 @@ func Test_stringBuilder_build(t *testing.T) {
 	assert.NotNil(t, e)
 	assert.Equal(t, "", r)
 }
+
+func TestObjArrToLCSResultReply(t *testing.T) {
+	params := (&LCSIdxArgs{MinMatchLen: 4, WithMatchLen: true}).getParams("a", "b")
+	assert.Equal(t, []string{"a", "b", "IDX", "MINMATCHLEN", "4", "WITHMATCHLEN"}, byteArrArrToStrArr(params))
+
+	result, err := ObjArrToLCSResultReply([]interface{}{
+		[]byte("matches"),
+		[]interface{}{
+			[]interface{}{
+				[]interface{}{int64(4), int64(7)},
+				[]interface{}{int64(5), int64(8)},
+				int64(4),
+			},
+			[]interface{}{
+				[]interface{}{int64(2), int64(3)},
+				[]interface{}{int64(0), int64(1)},
+			},
+		},
+		[]byte("len"), int64(6),
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &LCSResult{
+		Matches: []*LCSMatch{
+			{Key1: LCSRange{Start: 4, End: 7}, Key2: LCSRange{Start: 5, End: 8}, Len: 4},
+			{Key1: LCSRange{Start: 2, End: 3}, Key2: LCSRange{Start: 0, End: 1}},
+		},
+		Len: 6,
+	}, result)
+
+	_, err = ObjArrToLCSResultReply([]interface{}{[]byte("matches"), []interface{}{[]interface{}{int64(1)}}}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestObjArrToLMPopResultReply(t *testing.T) {
+	result, err := ObjArrToLMPopResultReply([]interface{}{[]byte("l"), []interface{}{[]byte("a"), []byte("b")}}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &LMPopResult{Key: "l", Elements: []string{"a", "b"}}, result)
+	result, err = ObjArrToLMPopResultReply([]interface{}{}, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	_, err = ObjArrToLMPopResultReply([]interface{}{[]byte("l")}, nil)
+	assert.IsType(t, &DataError{}, err)
+	_, err = ObjArrToLMPopResultReply([]interface{}{[]byte("l"), []byte("a")}, nil)
+	assert.IsType(t, &DataError{}, err)
+
+	index, err := ObjToLPosReply(nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), index)
+	index, err = ObjToLPosReply(int64(0), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), index)
+}
+
+func TestStrArrToHashFieldValueArrReply(t *testing.T) {
+	values, err := StrArrToHashFieldValueArrReply([]string{"a", "1", "b", "2"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []HashFieldValue{{Field: "a", Value: "1"}, {Field: "b", Value: "2"}}, values)
+	_, err = StrArrToHashFieldValueArrReply([]string{"a"}, nil)
+	assert.NotNil(t, err)
+
+	arr, err := ObjArrToStrArrReply([]interface{}{[]byte("a"), nil}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", ""}, arr)
+
+	statuses, err := HashFieldExpireStatusArrBuilder.build([]interface{}{int64(-2), int64(1)})
+	assert.Nil(t, err)
+	assert.Equal(t, []HashFieldExpireStatus{HashFieldExpireNoField, HashFieldExpireSet}, statuses)
+}
+
+func TestObjArrToTupleReply(t *testing.T) {
+	tuples, err := ObjArrToTupleArrReply([]interface{}{[]byte("a"), []byte("1.5"), []byte("b"), []byte("2")}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []Tuple{{element: "a", score: 1.5}, {element: "b", score: 2}}, tuples)
+	assert.Equal(t, "a", tuples[0].Element())
+	assert.Equal(t, 1.5, tuples[0].Score())
+	_, err = ObjArrToTupleArrReply([]interface{}{[]byte("a")}, nil)
+	assert.NotNil(t, err)
+
+	tuple, err := ObjArrToKeyedTupleReply([]interface{}{[]byte("z"), []byte("a"), []byte("1")}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &KeyedTuple{Key: "z", Tuple: Tuple{element: "a", score: 1}}, tuple)
+	tuple, err = ObjArrToKeyedTupleReply([]interface{}{}, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, tuple)
+
+	result, err := ObjArrToZMPopResultReply([]interface{}{
+		[]byte("z"),
+		[]interface{}{
+			[]interface{}{[]byte("a"), []byte("1")},
+			[]interface{}{[]byte("b"), []byte("2")},
+		},
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &ZMPopResult{Key: "z", Tuples: []Tuple{{element: "a", score: 1}, {element: "b", score: 2}}}, result)
+	result, err = ObjArrToZMPopResultReply([]interface{}{}, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+
+	scores, err := ObjArrToFloat64PtrArrReply([]interface{}{[]byte("1"), nil}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, *scores[0])
+	assert.Nil(t, scores[1])
+}
+
+func TestObjArrToGeoRadiusResponseReply_withHash(t *testing.T) {
+	arr, err := ObjArrToGeoRadiusResponseReply([]interface{}{
+		[]interface{}{[]byte("a"), []byte("1.5"), int64(4054421060663027), []interface{}{[]byte("121"), []byte("37")}},
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "a", arr[0].Member())
+	assert.Equal(t, 1.5, arr[0].Distance())
+	assert.Equal(t, int64(4054421060663027), arr[0].Hash())
+	assert.Equal(t, NewGeoCoordinate(121, 37), arr[0].Coordinate())
+
+	resp, err := GeoRadiusResponseArrBuilder.build(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []GeoRadiusResponse{}, resp)
+}
+
+func TestObjArrToFunctionLibraryArrReply(t *testing.T) {
+	assert.Equal(t, []string{"f", "2", "a", "b", "x"}, byteArrArrToStrArr(fcallParams("f", []string{"a", "b"}, []string{"x"})))
+	assert.Equal(t, []string{"f", "0"}, byteArrArrToStrArr(fcallParams("f", nil, nil)))
+
+	libraries, err := ObjArrToFunctionLibraryArrReply([]interface{}{
+		[]interface{}{
+			[]byte("library_name"), []byte("mylib"),
+			[]byte("engine"), []byte("LUA"),
+			[]byte("functions"), []interface{}{
+				[]interface{}{[]byte("name"), []byte("f1"), []byte("description"), nil, []byte("flags"), []interface{}{}},
+				[]interface{}{[]byte("name"), []byte("f2"), []byte("description"), []byte("d"), []byte("flags"), []interface{}{[]byte("no-writes")}},
+			},
+			[]byte("library_code"), []byte("code"),
+		},
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []*FunctionLibrary{{
+		Name:   "mylib",
+		Engine: "LUA",
+		Functions: []*FunctionInfo{
+			{Name: "f1", Flags: []string{}},
+			{Name: "f2", Description: "d", Flags: []string{"no-writes"}},
+		},
+		Code: "code",
+	}}, libraries)
+	_, err = ObjArrToFunctionLibraryArrReply([]interface{}{[]interface{}{[]byte("library_name")}}, nil)
+	assert.NotNil(t, err)
+
+	stats, err := ObjArrToFunctionStatsReply([]interface{}{
+		[]byte("running_script"), []interface{}{
+			[]byte("name"), []byte("f1"),
+			[]byte("command"), []interface{}{[]byte("fcall"), []byte("f1"), []byte("0")},
+			[]byte("duration_ms"), int64(12),
+		},
+		[]byte("engines"), []interface{}{
+			[]byte("LUA"), []interface{}{[]byte("libraries_count"), int64(1), []byte("functions_count"), int64(2)},
+		},
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &FunctionStats{
+		RunningScript: &FunctionRunningScript{Name: "f1", Command: []string{"fcall", "f1", "0"}, Duration: 12},
+		Engines:       map[string]*FunctionEngineStats{"LUA": {Libraries: 1, Functions: 2}},
+	}, stats)
+	stats, err = ObjArrToFunctionStatsReply([]interface{}{[]byte("running_script"), nil, []byte("engines"), []interface{}{}}, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, stats.RunningScript)
+}
+
+func TestObjArrToACLUserReply(t *testing.T) {
+	user, err := ObjArrToACLUserReply([]interface{}{
+		[]byte("flags"), []interface{}{[]byte("on")},
+		[]byte("passwords"), []interface{}{[]byte("hash")},
+		[]byte("commands"), []byte("+@read"),
+		[]byte("keys"), []byte("~app:* %R~ro:*"),
+		[]byte("channels"), []byte("&news.*"),
+		[]byte("selectors"), []interface{}{
+			[]interface{}{[]byte("commands"), []byte("-@all +set"), []byte("keys"), []byte("%W~w:*"), []byte("channels"), []byte("")},
+		},
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &ACLUser{
+		Flags:     []string{"on"},
+		Passwords: []string{"hash"},
+		Commands:  "+@read",
+		Keys:      "~app:* %R~ro:*",
+		Channels:  "&news.*",
+		Selectors: []*ACLSelector{{Commands: "-@all +set", Keys: "%W~w:*"}},
+	}, user)
+
+	//redis 6.x replies the keys as an array
+	user, err = ObjArrToACLUserReply([]interface{}{[]byte("keys"), []interface{}{[]byte("a*"), []byte("b*")}}, nil)
+	require.Nil(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "a* b*", user.Keys)
+	user, err = ObjArrToACLUserReply([]interface{}{}, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, user)
+
+	entries, err := ObjArrToACLLogEntryArrReply([]interface{}{
+		[]interface{}{
+			[]byte("count"), int64(2), []byte("reason"), []byte("command"), []byte("context"), []byte("toplevel"),
+			[]byte("object"), []byte("get"), []byte("username"), []byte("u"), []byte("age-seconds"), []byte("1.5"),
+			[]byte("client-info"), []byte("id=3"), []byte("entry-id"), int64(0),
+			[]byte("timestamp-created"), int64(1700000000000), []byte("timestamp-last-updated"), int64(1700000001000),
+		},
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []*ACLLogEntry{{
+		Count: 2, Reason: "command", Context: "toplevel", Object: "get", Username: "u", AgeSeconds: 1.5,
+		ClientInfo: "id=3", TimestampCreated: 1700000000000, TimestampLastUpdated: 1700000001000,
+	}}, entries)
+	_, err = ObjArrToACLLogEntryArrReply([]interface{}{[]interface{}{[]byte("count")}}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestObjArrToXStreamArrReply(t *testing.T) {
+	reply := []interface{}{
+		[]interface{}{
+			[]byte("s"),
+			[]interface{}{
+				[]interface{}{[]byte("1-0"), []interface{}{[]byte("a"), []byte("1")}},
+				[]interface{}{[]byte("2-0"), nil},
+			},
+		},
+	}
+	streams, err := ObjArrToXStreamArrReply(reply, nil)
+	assert.Nil(t, err)
+	assert.Len(t, streams, 1)
+	assert.Equal(t, "s", streams[0].Key)
+	assert.Equal(t, []*StreamEntry{
+		{ID: "1-0", Fields: map[string]string{"a": "1"}},
+		{ID: "2-0"},
+	}, streams[0].Entries)
+
+	_, err = ObjArrToXStreamArrReply([]interface{}{[]byte("s")}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestObjArrToXInfoStreamReply(t *testing.T) {
+	reply := []interface{}{
+		[]byte("length"), int64(2),
+		[]byte("radix-tree-keys"), int64(1),
+		[]byte("radix-tree-nodes"), int64(2),
+		[]byte("last-generated-id"), []byte("2-0"),
+		[]byte("groups"), int64(0),
+		[]byte("first-entry"), []interface{}{[]byte("1-0"), []interface{}{[]byte("a"), []byte("1")}},
+		[]byte("last-entry"), []interface{}{[]byte("2-0"), []interface{}{[]byte("b"), []byte("2")}},
+	}
+	info, err := ObjArrToXInfoStreamReply(reply, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), info.Length)
+	assert.Equal(t, "2-0", info.LastGeneratedID)
+	assert.Equal(t, "1-0", info.FirstEntry.ID)
+	assert.Equal(t, map[string]string{"b": "2"}, info.LastEntry.Fields)
+}
+
+func TestObjArrToXPendingReply(t *testing.T) {
+	summary, err := ObjArrToXPendingSummaryReply([]interface{}{
+		int64(3), []byte("1-0"), []byte("3-0"),
+		[]interface{}{
+			[]interface{}{[]byte("a"), []byte("2")},
+			[]interface{}{[]byte("b"), []byte("1")},
+		},
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &XPendingSummary{Count: 3, Lowest: "1-0", Highest: "3-0", Consumers: map[string]int64{"a": 2, "b": 1}}, summary)
+
+	summary, err = ObjArrToXPendingSummaryReply([]interface{}{int64(0), nil, nil, nil}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), summary.Count)
+	assert.Empty(t, summary.Consumers)
+
+	entries, err := ObjArrToXPendingEntryArrReply([]interface{}{
+		[]interface{}{[]byte("1-0"), []byte("a"), int64(1500), int64(2)},
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []*XPendingEntry{{ID: "1-0", Consumer: "a", Idle: 1500 * time.Millisecond, DeliveryCount: 2}}, entries)
+
+	_, err = ObjArrToXPendingEntryArrReply([]interface{}{[]interface{}{[]byte("1-0")}}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestObjArrToXAutoClaimResultReply(t *testing.T) {
+	result, err := ObjArrToXAutoClaimResultReply([]interface{}{
+		[]byte("0-0"),
+		[]interface{}{[]interface{}{[]byte("1-0"), []interface{}{[]byte("a"), []byte("1")}}},
+		[]interface{}{[]byte("2-0")},
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "0-0", result.Next)
+	assert.Equal(t, []*StreamEntry{{ID: "1-0", Fields: map[string]string{"a": "1"}}}, result.Entries)
+	assert.Equal(t, []string{"2-0"}, result.DeletedIDs)
+
+	//redis 6.2 doesn't reply the deleted ids
+	result, err = ObjArrToXAutoClaimResultReply([]interface{}{[]byte("0-0"), []interface{}{}}, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, result.Entries)
+	assert.Empty(t, result.DeletedIDs)
+}
@@ -1,5 +1,10 @@
 package godis
 
+import (
+	"fmt"
+	"strings"
+)
+
 //RedisError basic redis error
 type RedisError struct {
 	Message string
@@ -97,6 +102,33 @@ func (e *ClusterError) Error() string {
 	return e.Message
 }
 
+//TryAgainError multi-key operation during resharding, the keys are split between the source and destination node,
+// the operation can be retried later
+type TryAgainError struct {
+	Message string
+}
+
+func newTryAgainError(message string) *TryAgainError {
+	return &TryAgainError{Message: message}
+}
+
+func (e *TryAgainError) Error() string {
+	return e.Message
+}
+
+//TxFailedError transaction is aborted by EXEC, because one of the watched keys was modified
+type TxFailedError struct {
+	Message string
+}
+
+func newTxFailedError(message string) *TxFailedError {
+	return &TxFailedError{Message: message}
+}
+
+func (e *TxFailedError) Error() string {
+	return e.Message
+}
+
 //BusyError operation is busy error
 type BusyError struct {
 	Message string
@@ -161,3 +193,39 @@ func newClusterOperationError(message string) *ClusterOperationError {
 func (e *ClusterOperationError) Error() string {
 	return e.Message
 }
+
+//ClusterNodeError the error of one node when a command is sent to multiple cluster nodes
+type ClusterNodeError struct {
+	Node string // host:port of the node
+	Err  error  // error returned by the node
+}
+
+func newClusterNodeError(node string, err error) *ClusterNodeError {
+	return &ClusterNodeError{Node: node, Err: err}
+}
+
+func (e *ClusterNodeError) Error() string {
+	return e.Node + ": " + e.Err.Error()
+}
+
+//ClusterFanOutError some nodes failed when a command is sent to multiple cluster nodes,
+// NodeErrors reports the error of every failed node
+type ClusterFanOutError struct {
+	Message    string
+	NodeErrors []*ClusterNodeError
+}
+
+func newClusterFanOutError(nodeErrors []*ClusterNodeError) *ClusterFanOutError {
+	msgs := make([]string, 0, len(nodeErrors))
+	for _, e := range nodeErrors {
+		msgs = append(msgs, e.Error())
+	}
+	return &ClusterFanOutError{
+		Message:    fmt.Sprintf("%d cluster node(s) failed: %s", len(nodeErrors), strings.Join(msgs, "; ")),
+		NodeErrors: nodeErrors,
+	}
+}
+
+func (e *ClusterFanOutError) Error() string {
+	return e.Message
+}
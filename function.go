@@ -0,0 +1,248 @@
+package godis
+
+import (
+	"fmt"
+)
+
+//FunctionInfo a function of a library
+type FunctionInfo struct {
+	Name        string
+	Description string
+	Flags       []string //like no-writes, allow-oom, allow-stale, no-cluster
+}
+
+//FunctionLibrary a library loaded by FUNCTION LOAD, Code is only set with WITHCODE
+type FunctionLibrary struct {
+	Name      string
+	Engine    string
+	Functions []*FunctionInfo
+	Code      string
+}
+
+//FunctionRunningScript the function running on the server
+type FunctionRunningScript struct {
+	Name     string
+	Command  []string
+	Duration int64 //the run time in milliseconds
+}
+
+//FunctionEngineStats the number of libraries and functions of an engine
+type FunctionEngineStats struct {
+	Libraries int64
+	Functions int64
+}
+
+//FunctionStats the reply of FUNCTION STATS, RunningScript is nil when no function is running
+type FunctionStats struct {
+	RunningScript *FunctionRunningScript
+	Engines       map[string]*FunctionEngineStats
+}
+
+//FunctionRestorePolicy how FUNCTION RESTORE handles the existing libraries
+type FunctionRestorePolicy struct {
+	name string // name of FunctionRestorePolicy
+}
+
+//getRaw get the name byte array
+func (p *FunctionRestorePolicy) getRaw() []byte {
+	return []byte(p.name)
+}
+
+func newFunctionRestorePolicy(name string) *FunctionRestorePolicy {
+	return &FunctionRestorePolicy{name}
+}
+
+var (
+	//FunctionRestoreAppend append the libraries, fail if a library already exists, it's the default policy
+	FunctionRestoreAppend = newFunctionRestorePolicy("APPEND")
+	//FunctionRestoreReplace append the libraries and replace the existing ones
+	FunctionRestoreReplace = newFunctionRestorePolicy("REPLACE")
+	//FunctionRestoreFlush delete all the existing libraries before restoring
+	FunctionRestoreFlush = newFunctionRestorePolicy("FLUSH")
+)
+
+//fcallParams the function, numkeys and the keys followed by the args of FCALL and FCALL_RO
+func fcallParams(function string, keys, args []string) [][]byte {
+	arr := [][]byte{[]byte(function)}
+	arr = append(arr, numKeysParams(keys)...)
+	return append(arr, StrArrToByteArrArr(args)...)
+}
+
+//parseFunctionInfo parse a function of FUNCTION LIST, it's an array of field and value
+func parseFunctionInfo(reply interface{}) (*FunctionInfo, error) {
+	fields, ok := reply.([]interface{})
+	if !ok || len(fields)%2 != 0 {
+		return nil, newDataError(fmt.Sprintf("malformed function: %v", reply))
+	}
+	info := &FunctionInfo{Flags: make([]string, 0)}
+	for i := 0; i < len(fields); i += 2 {
+		value := fields[i+1]
+		switch replyToString(fields[i]) {
+		case "name":
+			info.Name = replyToString(value)
+		case "description":
+			info.Description = replyToString(value)
+		case "flags":
+			flags, _ := value.([]interface{})
+			for _, flag := range flags {
+				info.Flags = append(info.Flags, replyToString(flag))
+			}
+		}
+	}
+	return info, nil
+}
+
+//ObjArrToFunctionLibraryArrReply convert object array reply of FUNCTION LIST to FunctionLibrary array reply
+func ObjArrToFunctionLibraryArrReply(reply []interface{}, err error) ([]*FunctionLibrary, error) {
+	if err != nil {
+		return nil, err
+	}
+	libraries := make([]*FunctionLibrary, 0, len(reply))
+	for _, item := range reply {
+		fields, ok := item.([]interface{})
+		if !ok || len(fields)%2 != 0 {
+			return nil, newDataError(fmt.Sprintf("malformed function library: %v", item))
+		}
+		library := &FunctionLibrary{Functions: make([]*FunctionInfo, 0)}
+		for i := 0; i < len(fields); i += 2 {
+			value := fields[i+1]
+			switch replyToString(fields[i]) {
+			case "library_name":
+				library.Name = replyToString(value)
+			case "engine":
+				library.Engine = replyToString(value)
+			case "library_code":
+				library.Code = replyToString(value)
+			case "functions":
+				functions, _ := value.([]interface{})
+				for _, function := range functions {
+					info, err := parseFunctionInfo(function)
+					if err != nil {
+						return nil, err
+					}
+					library.Functions = append(library.Functions, info)
+				}
+			}
+		}
+		libraries = append(libraries, library)
+	}
+	return libraries, nil
+}
+
+//ObjArrToFunctionStatsReply convert object array reply of FUNCTION STATS to FunctionStats reply
+func ObjArrToFunctionStatsReply(reply []interface{}, err error) (*FunctionStats, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(reply)%2 != 0 {
+		return nil, newDataError("malformed FUNCTION STATS reply")
+	}
+	stats := &FunctionStats{Engines: make(map[string]*FunctionEngineStats)}
+	for i := 0; i < len(reply); i += 2 {
+		value, _ := reply[i+1].([]interface{})
+		switch replyToString(reply[i]) {
+		case "running_script":
+			if value == nil {
+				continue
+			}
+			stats.RunningScript = &FunctionRunningScript{Command: make([]string, 0)}
+			for j := 0; j+1 < len(value); j += 2 {
+				switch replyToString(value[j]) {
+				case "name":
+					stats.RunningScript.Name = replyToString(value[j+1])
+				case "command":
+					command, _ := value[j+1].([]interface{})
+					for _, arg := range command {
+						stats.RunningScript.Command = append(stats.RunningScript.Command, replyToString(arg))
+					}
+				case "duration_ms":
+					stats.RunningScript.Duration, _ = value[j+1].(int64)
+				}
+			}
+		case "engines":
+			for j := 0; j+1 < len(value); j += 2 {
+				fields, _ := value[j+1].([]interface{})
+				engine := &FunctionEngineStats{}
+				for k := 0; k+1 < len(fields); k += 2 {
+					switch replyToString(fields[k]) {
+					case "libraries_count":
+						engine.Libraries, _ = fields[k+1].(int64)
+					case "functions_count":
+						engine.Functions, _ = fields[k+1].(int64)
+					}
+				}
+				stats.Engines[replyToString(value[j])] = engine
+			}
+		}
+	}
+	return stats, nil
+}
+
+//ToFunctionLibraryArrReply convert object reply to FunctionLibrary array reply
+func ToFunctionLibraryArrReply(reply interface{}, err error) ([]*FunctionLibrary, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.([]*FunctionLibrary), nil
+}
+
+//ToFunctionStatsReply convert object reply to FunctionStats reply
+func ToFunctionStatsReply(reply interface{}, err error) (*FunctionStats, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.(*FunctionStats), nil
+}
+
+var (
+	//FunctionLibraryArrBuilder convert interface to FunctionLibrary array
+	FunctionLibraryArrBuilder = newFunctionLibraryArrBuilder()
+	//FunctionStatsBuilder convert interface to FunctionStats
+	FunctionStatsBuilder = newFunctionStatsBuilder()
+	//EvalResultBuilder convert interface to the result of a script or a function, see ObjToEvalResult
+	EvalResultBuilder = newEvalResultBuilder()
+)
+
+type functionLibraryArrBuilder struct {
+}
+
+func newFunctionLibraryArrBuilder() *functionLibraryArrBuilder {
+	return &functionLibraryArrBuilder{}
+}
+
+func (b *functionLibraryArrBuilder) build(data interface{}) (interface{}, error) {
+	if data == nil {
+		return []*FunctionLibrary{}, nil
+	}
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToFunctionLibraryArrReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
+
+type functionStatsBuilder struct {
+}
+
+func newFunctionStatsBuilder() *functionStatsBuilder {
+	return &functionStatsBuilder{}
+}
+
+func (b *functionStatsBuilder) build(data interface{}) (interface{}, error) {
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToFunctionStatsReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
+
+type evalResultBuilder struct {
+}
+
+func newEvalResultBuilder() *evalResultBuilder {
+	return &evalResultBuilder{}
+}
+
+func (b *evalResultBuilder) build(data interface{}) (interface{}, error) {
+	return ObjToEvalResult(data, nil)
+}
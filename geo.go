@@ -0,0 +1,188 @@
+package godis
+
+import (
+	"fmt"
+)
+
+//GeoSearchQuery the query of GEOSEARCH and GEOSEARCHSTORE, it needs a center set by FromMember or FromLonLat,
+// and a shape set by ByRadius or ByBox, the later center or shape replaces the former one
+type GeoSearchQuery struct {
+	from      *keyword
+	member    string
+	longitude float64
+	latitude  float64
+	by        *keyword
+	radius    float64
+	width     float64
+	height    float64
+	unit      *GeoUnit
+	order     *keyword
+	count     int64
+	any       bool
+	withCoord bool
+	withDist  bool
+	withHash  bool
+}
+
+//NewGeoSearchQuery create the query of GEOSEARCH and GEOSEARCHSTORE
+func NewGeoSearchQuery() *GeoSearchQuery {
+	return &GeoSearchQuery{}
+}
+
+//FromMember use the position of the existing member as the center
+func (q *GeoSearchQuery) FromMember(member string) *GeoSearchQuery {
+	q.from, q.member = keywordFromMember, member
+	return q
+}
+
+//FromLonLat use the longitude and latitude as the center
+func (q *GeoSearchQuery) FromLonLat(longitude, latitude float64) *GeoSearchQuery {
+	q.from, q.longitude, q.latitude = keywordFromLonLat, longitude, latitude
+	return q
+}
+
+//ByRadius search inside the circle of the radius
+func (q *GeoSearchQuery) ByRadius(radius float64, unit *GeoUnit) *GeoSearchQuery {
+	q.by, q.radius, q.unit = keywordByRadius, radius, unit
+	return q
+}
+
+//ByBox search inside the axis-aligned rectangle of the width and the height
+func (q *GeoSearchQuery) ByBox(width, height float64, unit *GeoUnit) *GeoSearchQuery {
+	q.by, q.width, q.height, q.unit = keywordByBox, width, height, unit
+	return q
+}
+
+//Asc sort the members from the nearest to the farthest
+func (q *GeoSearchQuery) Asc() *GeoSearchQuery {
+	q.order = keywordAsc
+	return q
+}
+
+//Desc sort the members from the farthest to the nearest
+func (q *GeoSearchQuery) Desc() *GeoSearchQuery {
+	q.order = keywordDesc
+	return q
+}
+
+//Count return at most count members
+func (q *GeoSearchQuery) Count(count int64) *GeoSearchQuery {
+	q.count = count
+	return q
+}
+
+//Any return as soon as count members are found, so they may not be the nearest ones, it only works with Count
+func (q *GeoSearchQuery) Any() *GeoSearchQuery {
+	q.any = true
+	return q
+}
+
+//WithCoord fill the result with the coordinate, it's ignored by GEOSEARCHSTORE
+func (q *GeoSearchQuery) WithCoord() *GeoSearchQuery {
+	q.withCoord = true
+	return q
+}
+
+//WithDist fill the result with the distance from the center, it's ignored by GEOSEARCHSTORE
+func (q *GeoSearchQuery) WithDist() *GeoSearchQuery {
+	q.withDist = true
+	return q
+}
+
+//WithHash fill the result with the raw geohash-encoded sorted set score, it's ignored by GEOSEARCHSTORE
+func (q *GeoSearchQuery) WithHash() *GeoSearchQuery {
+	q.withHash = true
+	return q
+}
+
+//getParams the keys followed by the query, the WITH options are only sent when withOptions is true
+func (q *GeoSearchQuery) getParams(withOptions bool, keys ...string) ([][]byte, error) {
+	if q == nil || q.from == nil || q.by == nil || q.unit == nil {
+		return nil, newDataError("geo search query needs a center and a shape")
+	}
+	if q.any && q.count <= 0 {
+		return nil, newDataError("geo search query with ANY needs a positive COUNT")
+	}
+	arr := StrArrToByteArrArr(keys)
+	if q.from == keywordFromMember {
+		arr = append(arr, q.from.getRaw(), []byte(q.member))
+	} else {
+		arr = append(arr, q.from.getRaw(), Float64ToByteArr(q.longitude), Float64ToByteArr(q.latitude))
+	}
+	if q.by == keywordByRadius {
+		arr = append(arr, q.by.getRaw(), Float64ToByteArr(q.radius), q.unit.getRaw())
+	} else {
+		arr = append(arr, q.by.getRaw(), Float64ToByteArr(q.width), Float64ToByteArr(q.height), q.unit.getRaw())
+	}
+	if q.order != nil {
+		arr = append(arr, q.order.getRaw())
+	}
+	if q.count > 0 {
+		arr = append(arr, keywordCount.getRaw(), Int64ToByteArr(q.count))
+		if q.any {
+			arr = append(arr, keywordAny.getRaw())
+		}
+	}
+	if withOptions && q.withCoord {
+		arr = append(arr, keywordWithCoord.getRaw())
+	}
+	if withOptions && q.withDist {
+		arr = append(arr, keywordWithDist.getRaw())
+	}
+	if withOptions && q.withHash {
+		arr = append(arr, keywordWithHash.getRaw())
+	}
+	return arr, nil
+}
+
+//GeoRadiusStoreArgs the destination of GEORADIUS and GEORADIUSBYMEMBER with STORE or STOREDIST
+type GeoRadiusStoreArgs struct {
+	Key       string //the destination key
+	StoreDist bool   //store the distances from the center as the scores instead of the geohashes
+}
+
+//getParams append STORE or STOREDIST with the destination key to args
+func (a *GeoRadiusStoreArgs) getParams(args [][]byte) [][]byte {
+	if a.StoreDist {
+		return append(args, keywordStoreDist.getRaw(), []byte(a.Key))
+	}
+	return append(args, keywordStore.getRaw(), []byte(a.Key))
+}
+
+//geoRadiusParams append the params and the destination to args of GEORADIUS and GEORADIUSBYMEMBER
+func geoRadiusParams(args [][]byte, store *GeoRadiusStoreArgs, param []*GeoRadiusParams) [][]byte {
+	if len(param) > 0 && param[0] != nil {
+		args = param[0].getParams(args)
+	}
+	if store != nil {
+		args = store.getParams(args)
+	}
+	return args
+}
+
+var (
+	//GeoRadiusResponseArrBuilder convert interface to GeoRadiusResponse array
+	GeoRadiusResponseArrBuilder = newGeoRadiusResponseArrBuilder()
+)
+
+type geoRadiusResponseArrBuilder struct {
+}
+
+func newGeoRadiusResponseArrBuilder() *geoRadiusResponseArrBuilder {
+	return &geoRadiusResponseArrBuilder{}
+}
+
+func (b *geoRadiusResponseArrBuilder) build(data interface{}) (interface{}, error) {
+	if data == nil {
+		return []GeoRadiusResponse{}, nil
+	}
+	switch data.(type) {
+	case []interface{}:
+		arr, err := ObjArrToGeoRadiusResponseReply(data.([]interface{}), nil)
+		if arr == nil {
+			arr = []GeoRadiusResponse{}
+		}
+		return arr, err
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
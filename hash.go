@@ -0,0 +1,266 @@
+package godis
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//HashFieldValue a field and its value of a hash
+type HashFieldValue struct {
+	Field string
+	Value string
+}
+
+//HashFieldExpireStatus the per-field result of HEXPIRE, HPEXPIRE, HEXPIREAT and HPEXPIREAT
+type HashFieldExpireStatus int64
+
+const (
+	//HashFieldExpireNoField the field or the key doesn't exist
+	HashFieldExpireNoField HashFieldExpireStatus = -2
+	//HashFieldExpireNotSet the expiration is not set because of the condition
+	HashFieldExpireNotSet HashFieldExpireStatus = 0
+	//HashFieldExpireSet the expiration is set
+	HashFieldExpireSet HashFieldExpireStatus = 1
+	//HashFieldExpireDeleted the field is deleted because the expiration is 0 or in the past
+	HashFieldExpireDeleted HashFieldExpireStatus = 2
+)
+
+//HashFieldPersistStatus the per-field result of HPERSIST
+type HashFieldPersistStatus int64
+
+const (
+	//HashFieldPersistNoField the field or the key doesn't exist
+	HashFieldPersistNoField HashFieldPersistStatus = -2
+	//HashFieldPersistNoExpiration the field has no expiration
+	HashFieldPersistNoExpiration HashFieldPersistStatus = -1
+	//HashFieldPersisted the expiration of the field is removed
+	HashFieldPersisted HashFieldPersistStatus = 1
+)
+
+//HSetExArgs the options of HSETEX, the later condition or expiration replaces the former one
+type HSetExArgs struct {
+	condition       *keyword
+	expiration      *keyword
+	expirationValue int64
+}
+
+//NewHSetExArgs create the options of HSETEX
+func NewHSetExArgs() *HSetExArgs {
+	return &HSetExArgs{}
+}
+
+//FNX Only set the fields if none of them already exist.
+func (a *HSetExArgs) FNX() *HSetExArgs {
+	a.condition = keywordFnx
+	return a
+}
+
+//FXX Only set the fields if all of them already exist.
+func (a *HSetExArgs) FXX() *HSetExArgs {
+	a.condition = keywordFxx
+	return a
+}
+
+//EX Set the specified expire time of the fields in seconds, the duration is truncated to seconds.
+func (a *HSetExArgs) EX(expiration time.Duration) *HSetExArgs {
+	a.expiration, a.expirationValue = keywordEx, int64(expiration/time.Second)
+	return a
+}
+
+//PX Set the specified expire time of the fields in milliseconds, the duration is truncated to milliseconds.
+func (a *HSetExArgs) PX(expiration time.Duration) *HSetExArgs {
+	a.expiration, a.expirationValue = keywordPx, int64(expiration/time.Millisecond)
+	return a
+}
+
+//EXAT Set the specified Unix time at which the fields will expire, in seconds.
+func (a *HSetExArgs) EXAT(at time.Time) *HSetExArgs {
+	a.expiration, a.expirationValue = keywordExAt, at.Unix()
+	return a
+}
+
+//PXAT Set the specified Unix time at which the fields will expire, in milliseconds.
+func (a *HSetExArgs) PXAT(at time.Time) *HSetExArgs {
+	a.expiration, a.expirationValue = keywordPxAt, at.UnixNano()/int64(time.Millisecond)
+	return a
+}
+
+//KEEPTTL Retain the time to live associated with the fields.
+func (a *HSetExArgs) KEEPTTL() *HSetExArgs {
+	a.expiration, a.expirationValue = keywordKeepTTL, 0
+	return a
+}
+
+//getParams the key followed by the options and the fields with their values
+func (a *HSetExArgs) getParams(key string, hash map[string]string) [][]byte {
+	params := []string{key}
+	if a != nil {
+		if a.condition != nil {
+			params = append(params, a.condition.name)
+		}
+		params = append(params, expirationParams(a.expiration, a.expirationValue)...)
+	}
+	params = append(params, keywordFields.name, strconv.Itoa(len(hash)))
+	params = append(params, hashFieldValueParams(hash)...)
+	return StrArrToByteArrArr(params)
+}
+
+//hashFieldValueParams the fields and their values of the hash, sorted by the fields to send the same command for the same hash
+func hashFieldValueParams(hash map[string]string) []string {
+	fields := make([]string, 0, len(hash))
+	for field := range hash {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	params := make([]string, 0, len(hash)*2)
+	for _, field := range fields {
+		params = append(params, field, hash[field])
+	}
+	return params
+}
+
+//hashFieldsParams FIELDS numfields followed by the fields
+func hashFieldsParams(fields []string) [][]byte {
+	params := [][]byte{keywordFields.getRaw(), IntToByteArr(len(fields))}
+	return append(params, StrArrToByteArrArr(fields)...)
+}
+
+//hashExpireParams the key, the expiration and the condition followed by the fields of HEXPIRE and the like
+func hashExpireParams(key string, expiration int64, condition *ExpireCondition, fields []string) [][]byte {
+	params := [][]byte{[]byte(key), Int64ToByteArr(expiration)}
+	if condition != nil {
+		params = append(params, condition.getRaw())
+	}
+	return append(params, hashFieldsParams(fields)...)
+}
+
+//ObjArrToStrArrReply convert object array reply to string array reply, nil element is converted to empty string
+func ObjArrToStrArrReply(reply []interface{}, err error) ([]string, error) {
+	if err != nil {
+		return nil, err
+	}
+	arr := make([]string, 0, len(reply))
+	for _, item := range reply {
+		arr = append(arr, replyToString(item))
+	}
+	return arr, nil
+}
+
+//StrArrToHashFieldValueArrReply convert string array reply of fields and values to HashFieldValue array reply
+func StrArrToHashFieldValueArrReply(reply []string, err error) ([]HashFieldValue, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(reply)%2 != 0 {
+		return nil, newDataError(fmt.Sprintf("malformed field value reply: %v", reply))
+	}
+	arr := make([]HashFieldValue, 0, len(reply)/2)
+	for i := 0; i < len(reply); i += 2 {
+		arr = append(arr, HashFieldValue{Field: reply[i], Value: reply[i+1]})
+	}
+	return arr, nil
+}
+
+//Int64ArrToHashFieldExpireStatusArrReply convert int64 array reply to HashFieldExpireStatus array reply
+func Int64ArrToHashFieldExpireStatusArrReply(reply []int64, err error) ([]HashFieldExpireStatus, error) {
+	if err != nil {
+		return nil, err
+	}
+	arr := make([]HashFieldExpireStatus, 0, len(reply))
+	for _, status := range reply {
+		arr = append(arr, HashFieldExpireStatus(status))
+	}
+	return arr, nil
+}
+
+//Int64ArrToHashFieldPersistStatusArrReply convert int64 array reply to HashFieldPersistStatus array reply
+func Int64ArrToHashFieldPersistStatusArrReply(reply []int64, err error) ([]HashFieldPersistStatus, error) {
+	if err != nil {
+		return nil, err
+	}
+	arr := make([]HashFieldPersistStatus, 0, len(reply))
+	for _, status := range reply {
+		arr = append(arr, HashFieldPersistStatus(status))
+	}
+	return arr, nil
+}
+
+//ToHashFieldValueArrReply convert object reply to HashFieldValue array reply
+func ToHashFieldValueArrReply(reply interface{}, err error) ([]HashFieldValue, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.([]HashFieldValue), nil
+}
+
+//ToHashFieldExpireStatusArrReply convert object reply to HashFieldExpireStatus array reply
+func ToHashFieldExpireStatusArrReply(reply interface{}, err error) ([]HashFieldExpireStatus, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.([]HashFieldExpireStatus), nil
+}
+
+//ToHashFieldPersistStatusArrReply convert object reply to HashFieldPersistStatus array reply
+func ToHashFieldPersistStatusArrReply(reply interface{}, err error) ([]HashFieldPersistStatus, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.([]HashFieldPersistStatus), nil
+}
+
+var (
+	//HashFieldValueArrBuilder convert interface to HashFieldValue array
+	HashFieldValueArrBuilder = newHashFieldValueArrBuilder()
+	//HashFieldExpireStatusArrBuilder convert interface to HashFieldExpireStatus array
+	HashFieldExpireStatusArrBuilder = newHashFieldExpireStatusArrBuilder()
+	//HashFieldPersistStatusArrBuilder convert interface to HashFieldPersistStatus array
+	HashFieldPersistStatusArrBuilder = newHashFieldPersistStatusArrBuilder()
+)
+
+type hashFieldValueArrBuilder struct {
+}
+
+func newHashFieldValueArrBuilder() *hashFieldValueArrBuilder {
+	return &hashFieldValueArrBuilder{}
+}
+
+func (b *hashFieldValueArrBuilder) build(data interface{}) (interface{}, error) {
+	arr, err := StrArrBuilder.build(data)
+	if err != nil {
+		return nil, err
+	}
+	return StrArrToHashFieldValueArrReply(arr.([]string), nil)
+}
+
+type hashFieldExpireStatusArrBuilder struct {
+}
+
+func newHashFieldExpireStatusArrBuilder() *hashFieldExpireStatusArrBuilder {
+	return &hashFieldExpireStatusArrBuilder{}
+}
+
+func (b *hashFieldExpireStatusArrBuilder) build(data interface{}) (interface{}, error) {
+	arr, err := Int64ArrBuilder.build(data)
+	if err != nil {
+		return nil, err
+	}
+	return Int64ArrToHashFieldExpireStatusArrReply(arr.([]int64), nil)
+}
+
+type hashFieldPersistStatusArrBuilder struct {
+}
+
+func newHashFieldPersistStatusArrBuilder() *hashFieldPersistStatusArrBuilder {
+	return &hashFieldPersistStatusArrBuilder{}
+}
+
+func (b *hashFieldPersistStatusArrBuilder) build(data interface{}) (interface{}, error) {
+	arr, err := Int64ArrBuilder.build(data)
+	if err != nil {
+		return nil, err
+	}
+	return Int64ArrToHashFieldPersistStatusArrReply(arr.([]int64), nil)
+}
@@ -0,0 +1,54 @@
+package godis
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+//fakeServer a fake redis server, it accepts the connections one by one, serve is called with every connection
+func fakeServer(t *testing.T, serve func(index int, conn net.Conn, reader *bufio.Reader)) *Option {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		defer listener.Close()
+		for index := 0; ; index++ {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			serve(index, conn, bufio.NewReader(conn))
+		}
+	}()
+	addr := listener.Addr().(*net.TCPAddr)
+	return &Option{Host: "127.0.0.1", Port: addr.Port}
+}
+
+//readFakeCommand read a command sent by the client as an array of bulk strings
+func readFakeCommand(reader *bufio.Reader) []string {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		_, _ = reader.ReadString('\n')
+		arg, _ := reader.ReadString('\n')
+		args = append(args, strings.TrimSpace(arg))
+	}
+	return args
+}
+
+//byteArrArrToStrArr convert the params built for a command to strings to compare
+func byteArrArrToStrArr(arr [][]byte) []string {
+	strs := make([]string, 0, len(arr))
+	for _, b := range arr {
+		strs = append(strs, string(b))
+	}
+	return strs
+}
@@ -0,0 +1,189 @@
+package godis
+
+import (
+	"fmt"
+	"time"
+)
+
+//CopyArgs the options of COPY
+type CopyArgs struct {
+	db      int
+	setDB   bool
+	replace bool
+}
+
+//NewCopyArgs create the options of COPY, COPY without any option copies to the current db
+func NewCopyArgs() *CopyArgs {
+	return &CopyArgs{}
+}
+
+//DB copy to the db instead of the current db
+func (a *CopyArgs) DB(db int) *CopyArgs {
+	a.db, a.setDB = db, true
+	return a
+}
+
+//Replace remove the destination key before copying
+func (a *CopyArgs) Replace() *CopyArgs {
+	a.replace = true
+	return a
+}
+
+//getParams the keys followed by the options
+func (a *CopyArgs) getParams(srcKey, destKey string) [][]byte {
+	arr := [][]byte{[]byte(srcKey), []byte(destKey)}
+	if a != nil && a.setDB {
+		arr = append(arr, keywordDB.getRaw(), IntToByteArr(a.db))
+	}
+	if a != nil && a.replace {
+		arr = append(arr, keywordReplace.getRaw())
+	}
+	return arr
+}
+
+//RestoreArgs the options of RESTORE, IDLETIME and FREQ can't be both set
+type RestoreArgs struct {
+	replace  bool
+	idleTime time.Duration
+	freq     int
+	setFreq  bool
+}
+
+//NewRestoreArgs create the options of RESTORE
+func NewRestoreArgs() *RestoreArgs {
+	return &RestoreArgs{}
+}
+
+//Replace replace the existing key instead of failing with BUSYKEY
+func (a *RestoreArgs) Replace() *RestoreArgs {
+	a.replace = true
+	return a
+}
+
+//IdleTime set the idle time of the key for the LRU eviction, the duration is truncated to seconds
+func (a *RestoreArgs) IdleTime(idleTime time.Duration) *RestoreArgs {
+	a.idleTime = idleTime
+	return a
+}
+
+//Freq set the access frequency of the key for the LFU eviction
+func (a *RestoreArgs) Freq(freq int) *RestoreArgs {
+	a.freq, a.setFreq = freq, true
+	return a
+}
+
+//getParams the key, the ttl and the value followed by the options, absTTL sends ABSTTL
+func (a *RestoreArgs) getParams(key string, ttl int64, serializedValue []byte, absTTL bool) [][]byte {
+	arr := [][]byte{[]byte(key), Int64ToByteArr(ttl), serializedValue}
+	if a != nil && a.replace {
+		arr = append(arr, keywordReplace.getRaw())
+	}
+	if absTTL {
+		arr = append(arr, keywordAbsTTL.getRaw())
+	}
+	if a != nil && a.idleTime >= time.Second {
+		arr = append(arr, keywordIdleTime.getRaw(), Int64ToByteArr(int64(a.idleTime/time.Second)))
+	}
+	if a != nil && a.setFreq {
+		arr = append(arr, keywordFreq.getRaw(), IntToByteArr(a.freq))
+	}
+	return arr
+}
+
+//expireParams the key and the expiration followed by the condition of EXPIRE and the like
+func expireParams(key string, expiration int64, condition *ExpireCondition) [][]byte {
+	arr := [][]byte{[]byte(key), Int64ToByteArr(expiration)}
+	if condition != nil {
+		arr = append(arr, condition.getRaw())
+	}
+	return arr
+}
+
+//MigrateParams the options of MIGRATE
+type MigrateParams struct {
+	copy     bool
+	replace  bool
+	username string
+	password string
+}
+
+//NewMigrateParams create the options of MIGRATE, MIGRATE without any option removes the keys from the local instance
+func NewMigrateParams() *MigrateParams {
+	return &MigrateParams{}
+}
+
+//Copy do not remove the key from the local instance
+func (m *MigrateParams) Copy() *MigrateParams {
+	m.copy = true
+	return m
+}
+
+//Replace replace existing key on the remote instance
+func (m *MigrateParams) Replace() *MigrateParams {
+	m.replace = true
+	return m
+}
+
+//Auth authenticate with the given password to the remote instance
+func (m *MigrateParams) Auth(password string) *MigrateParams {
+	m.password = password
+	return m
+}
+
+//Auth2 authenticate with the given username and password to the remote instance,redis 6.0+
+func (m *MigrateParams) Auth2(username, password string) *MigrateParams {
+	m.username = username
+	m.password = password
+	return m
+}
+
+//getParams the options following the timeout of MIGRATE
+func (m *MigrateParams) getParams() [][]byte {
+	arr := make([][]byte, 0)
+	if m == nil {
+		return arr
+	}
+	if m.copy {
+		arr = append(arr, keywordCopy.getRaw())
+	}
+	if m.replace {
+		arr = append(arr, keywordReplace.getRaw())
+	}
+	if m.username != "" {
+		arr = append(arr, keywordAuth2.getRaw(), []byte(m.username), []byte(m.password))
+	} else if m.password != "" {
+		arr = append(arr, keywordAuth.getRaw(), []byte(m.password))
+	}
+	return arr
+}
+
+//ToByteArrReply convert object reply to byte array reply
+func ToByteArrReply(reply interface{}, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.([]byte), nil
+}
+
+var (
+	//ByteArrBuilder convert interface to byte array, nil is converted to empty byte array
+	ByteArrBuilder = newByteArrBuilder()
+)
+
+type byteArrBuilder struct {
+}
+
+func newByteArrBuilder() *byteArrBuilder {
+	return &byteArrBuilder{}
+}
+
+func (b *byteArrBuilder) build(data interface{}) (interface{}, error) {
+	if data == nil {
+		return []byte{}, nil
+	}
+	switch data.(type) {
+	case []byte:
+		return data.([]byte), nil
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
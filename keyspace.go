@@ -0,0 +1,333 @@
+package godis
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	keyspaceChannelPrefix = "__keyspace@"
+	keyeventChannelPrefix = "__keyevent@"
+)
+
+//KeyspaceEvent keyspace or keyevent notification, see https://redis.io/docs/manual/keyspace-notifications/
+type KeyspaceEvent struct {
+	Key   string //the key changed by the event
+	Event string //name of the event, like set, del, expired, hset
+	DB    int    //db of the key
+	Node  string //host:port of the node which sent the notification, empty for standalone redis
+	//Keyspace is true when the event is received from the __keyspace@<db>__:<key> channel,
+	// false when it's received from the __keyevent@<db>__:<event> channel
+	Keyspace bool
+}
+
+//parseKeyspaceEvent parse the channel and the message of the notification, false is returned if it's not a notification
+func parseKeyspaceEvent(channel, message string) (*KeyspaceEvent, bool) {
+	keyspace := strings.HasPrefix(channel, keyspaceChannelPrefix)
+	if !keyspace && !strings.HasPrefix(channel, keyeventChannelPrefix) {
+		return nil, false
+	}
+	rest := channel[len(keyspaceChannelPrefix):]
+	index := strings.Index(rest, "__:")
+	if index < 0 {
+		return nil, false
+	}
+	db, err := strconv.Atoi(rest[:index])
+	if err != nil {
+		return nil, false
+	}
+	event := &KeyspaceEvent{DB: db, Keyspace: keyspace}
+	if keyspace {
+		event.Key = rest[index+3:]
+		event.Event = message
+	} else {
+		event.Key = message
+		event.Event = rest[index+3:]
+	}
+	return event, true
+}
+
+//KeyspaceEventType type of the keyspace notification, flag is the class of notify-keyspace-events the event belongs to
+type KeyspaceEventType struct {
+	name string
+	flag string
+}
+
+func newKeyspaceEventType(name, flag string) *KeyspaceEventType {
+	return &KeyspaceEventType{name: name, flag: flag}
+}
+
+//Name name of the event in the notification
+func (k *KeyspaceEventType) Name() string {
+	return k.name
+}
+
+var (
+	//KeyspaceEventDel DEL, UNLINK
+	KeyspaceEventDel = newKeyspaceEventType("del", "g")
+	//KeyspaceEventExpire EXPIRE and its variants, SET with expire
+	KeyspaceEventExpire = newKeyspaceEventType("expire", "g")
+	//KeyspaceEventRenameFrom RENAME, the key is the source key
+	KeyspaceEventRenameFrom = newKeyspaceEventType("rename_from", "g")
+	//KeyspaceEventRenameTo RENAME, the key is the destination key
+	KeyspaceEventRenameTo = newKeyspaceEventType("rename_to", "g")
+	//KeyspaceEventSet SET and its variants
+	KeyspaceEventSet = newKeyspaceEventType("set", "$")
+	//KeyspaceEventIncrBy INCR, INCRBY, DECR, DECRBY
+	KeyspaceEventIncrBy = newKeyspaceEventType("incrby", "$")
+	//KeyspaceEventAppend APPEND
+	KeyspaceEventAppend = newKeyspaceEventType("append", "$")
+	//KeyspaceEventLPush LPUSH, LPUSHX
+	KeyspaceEventLPush = newKeyspaceEventType("lpush", "l")
+	//KeyspaceEventRPush RPUSH, RPUSHX
+	KeyspaceEventRPush = newKeyspaceEventType("rpush", "l")
+	//KeyspaceEventLPop LPOP, BLPOP
+	KeyspaceEventLPop = newKeyspaceEventType("lpop", "l")
+	//KeyspaceEventRPop RPOP, BRPOP
+	KeyspaceEventRPop = newKeyspaceEventType("rpop", "l")
+	//KeyspaceEventHSet HSET, HSETNX, HMSET
+	KeyspaceEventHSet = newKeyspaceEventType("hset", "h")
+	//KeyspaceEventHDel HDEL
+	KeyspaceEventHDel = newKeyspaceEventType("hdel", "h")
+	//KeyspaceEventHIncrBy HINCRBY
+	KeyspaceEventHIncrBy = newKeyspaceEventType("hincrby", "h")
+	//KeyspaceEventSAdd SADD
+	KeyspaceEventSAdd = newKeyspaceEventType("sadd", "s")
+	//KeyspaceEventSRem SREM
+	KeyspaceEventSRem = newKeyspaceEventType("srem", "s")
+	//KeyspaceEventZAdd ZADD
+	KeyspaceEventZAdd = newKeyspaceEventType("zadd", "z")
+	//KeyspaceEventZRem ZREM
+	KeyspaceEventZRem = newKeyspaceEventType("zrem", "z")
+	//KeyspaceEventZIncr ZINCRBY
+	KeyspaceEventZIncr = newKeyspaceEventType("zincr", "z")
+	//KeyspaceEventXAdd XADD
+	KeyspaceEventXAdd = newKeyspaceEventType("xadd", "t")
+	//KeyspaceEventExpired the key is expired
+	KeyspaceEventExpired = newKeyspaceEventType("expired", "x")
+	//KeyspaceEventEvicted the key is evicted by maxmemory policy
+	KeyspaceEventEvicted = newKeyspaceEventType("evicted", "e")
+	//KeyspaceEventNew a new key is added
+	KeyspaceEventNew = newKeyspaceEventType("new", "n")
+)
+
+//KeyspaceNotifierOption option of the keyspace notifier
+type KeyspaceNotifierOption struct {
+	DB        int  //db of the notifications
+	AllDB     bool //receive the notifications of all the dbs, DB is ignored
+	Configure bool //merge the flags required by the handlers into notify-keyspace-events of the server by CONFIG SET
+}
+
+//KeyspaceNotifier deliver the keyspace notifications of standalone redis to the handlers registered per event type and key pattern
+type KeyspaceNotifier struct {
+	redis  *Redis
+	pool   *Pool
+	option *KeyspaceNotifierOption
+
+	mu       sync.Mutex
+	handlers []*keyspaceHandler
+	pubSub   *RedisPubSub
+	conn     *Redis //the listening redis
+	closed   bool
+}
+
+type keyspaceHandler struct {
+	eventType *KeyspaceEventType
+	pattern   string //channel pattern of the key pattern
+	handle    func(event *KeyspaceEvent)
+}
+
+//NewKeyspaceNotifier create the notifier listening on the redis, the redis is dedicated to the notifier until Listen returns
+func (r *Redis) NewKeyspaceNotifier(option *KeyspaceNotifierOption) *KeyspaceNotifier {
+	return newKeyspaceNotifier(r, nil, option)
+}
+
+//NewKeyspaceNotifier create the notifier listening on a redis of the pool, the redis is destroyed when the notifier is closed
+func (p *Pool) NewKeyspaceNotifier(option *KeyspaceNotifierOption) *KeyspaceNotifier {
+	return newKeyspaceNotifier(nil, p, option)
+}
+
+func newKeyspaceNotifier(redis *Redis, pool *Pool, option *KeyspaceNotifierOption) *KeyspaceNotifier {
+	if option == nil {
+		option = &KeyspaceNotifierOption{}
+	}
+	return &KeyspaceNotifier{redis: redis, pool: pool, option: option}
+}
+
+//On register the handler of the events on the keys matching keyPattern, nil eventType means all the events,
+// empty keyPattern means all the keys. the handlers must be registered before Listen
+func (n *KeyspaceNotifier) On(eventType *KeyspaceEventType, keyPattern string, handler func(event *KeyspaceEvent)) *KeyspaceNotifier {
+	if keyPattern == "" {
+		keyPattern = "*"
+	}
+	db := strconv.Itoa(n.option.DB)
+	if n.option.AllDB {
+		db = "*"
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.handlers = append(n.handlers, &keyspaceHandler{
+		eventType: eventType,
+		pattern:   keyspaceChannelPrefix + db + "__:" + keyPattern,
+		handle:    handler,
+	})
+	return n
+}
+
+//Flags return the notify-keyspace-events flags required by the registered handlers
+func (n *KeyspaceNotifier) Flags() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	flags := "K"
+	for _, handler := range n.handlers {
+		flag := "A"
+		if handler.eventType != nil {
+			flag = handler.eventType.flag
+		}
+		flags = mergeKeyspaceFlags(flags, flag)
+	}
+	return flags
+}
+
+//Listen subscribe the notifications and deliver them to the handlers, it blocks until the notifier is closed
+func (n *KeyspaceNotifier) Listen() error {
+	n.mu.Lock()
+	if n.closed {
+		n.mu.Unlock()
+		return nil
+	}
+	if len(n.handlers) == 0 {
+		n.mu.Unlock()
+		return newDataError("no handler is registered to the keyspace notifier")
+	}
+	patterns := make([]string, 0, len(n.handlers))
+	seen := make(map[string]bool)
+	for _, handler := range n.handlers {
+		if !seen[handler.pattern] {
+			seen[handler.pattern] = true
+			patterns = append(patterns, handler.pattern)
+		}
+	}
+	n.pubSub = &RedisPubSub{
+		OnPMessage:     n.dispatch,
+		OnPSubscribe:   func(pattern string, subscribedChannels int) {},
+		OnPUnSubscribe: func(pattern string, subscribedChannels int) {},
+	}
+	n.mu.Unlock()
+
+	redis := n.redis
+	if n.pool != nil {
+		var err error
+		redis, err = n.pool.GetResource()
+		if err != nil {
+			return err
+		}
+		defer n.pool.returnBrokenResourceObject(redis)
+	}
+	if n.option.Configure {
+		if err := n.configure(redis); err != nil {
+			return err
+		}
+	}
+	client := redis.client
+	err := client.connection.setTimeoutInfinite()
+	defer client.connection.rollbackTimeout()
+	if err != nil {
+		return err
+	}
+	subscribed, err := n.subscribe(redis, patterns)
+	if !subscribed {
+		return err
+	}
+	err = n.pubSub.process(redis)
+	n.mu.Lock()
+	n.conn = nil
+	n.mu.Unlock()
+	//process invalidates the redis when it returns normally, the redis is still usable after unsubscribing all the patterns
+	redis.mu.Lock()
+	redis.client = client
+	redis.mu.Unlock()
+	return err
+}
+
+//subscribe send PSUBSCRIBE, Close is blocked until the patterns are sent, false is returned if the notifier is closed
+func (n *KeyspaceNotifier) subscribe(redis *Redis, patterns []string) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.closed {
+		return false, nil
+	}
+	n.pubSub.redis = redis
+	err := redis.client.psubscribe(patterns...)
+	if err != nil {
+		return false, err
+	}
+	err = redis.client.flush()
+	if err != nil {
+		return false, err
+	}
+	n.conn = redis
+	return true, nil
+}
+
+//Close unsubscribe the notifications, Listen returns after Close
+func (n *KeyspaceNotifier) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.closed = true
+	if n.conn == nil {
+		return nil
+	}
+	return n.pubSub.PUnSubscribe()
+}
+
+func (n *KeyspaceNotifier) configure(redis *Redis) error {
+	reply, err := redis.ConfigGet("notify-keyspace-events")
+	if err != nil {
+		return err
+	}
+	current := ""
+	if len(reply) == 2 {
+		current = reply[1]
+	}
+	flags := mergeKeyspaceFlags(current, n.Flags())
+	if flags == current {
+		return nil
+	}
+	_, err = redis.ConfigSet("notify-keyspace-events", flags)
+	return err
+}
+
+func (n *KeyspaceNotifier) dispatch(pattern string, channel, message string) {
+	event, ok := parseKeyspaceEvent(channel, message)
+	if !ok {
+		return
+	}
+	n.mu.Lock()
+	handlers := n.handlers
+	n.mu.Unlock()
+	for _, handler := range handlers {
+		if handler.pattern != pattern {
+			continue
+		}
+		if handler.eventType == nil || handler.eventType.name == event.Event {
+			handler.handle(event)
+		}
+	}
+}
+
+//mergeKeyspaceFlags merge the notify-keyspace-events flags, A is the alias of g$lshzxetd
+func mergeKeyspaceFlags(current, required string) string {
+	merged := current
+	for _, flag := range required {
+		if strings.ContainsRune(merged, flag) {
+			continue
+		}
+		if flag != 'K' && flag != 'E' && flag != 'A' && flag != 'm' && flag != 'n' && strings.ContainsRune(merged, 'A') {
+			continue
+		}
+		merged += string(flag)
+	}
+	return merged
+}
@@ -0,0 +1,81 @@
+package godis
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseKeyspaceEvent(t *testing.T) {
+	event, ok := parseKeyspaceEvent("__keyspace@0__:user:1", "expired")
+	assert.True(t, ok)
+	assert.Equal(t, &KeyspaceEvent{Key: "user:1", Event: "expired", DB: 0, Keyspace: true}, event)
+
+	event, ok = parseKeyspaceEvent("__keyevent@12__:hset", "user:__:1")
+	assert.True(t, ok)
+	assert.Equal(t, &KeyspaceEvent{Key: "user:__:1", Event: "hset", DB: 12}, event)
+
+	_, ok = parseKeyspaceEvent("__keyevent@x__:del", "godis")
+	assert.False(t, ok)
+	_, ok = parseKeyspaceEvent("news", "godis")
+	assert.False(t, ok)
+}
+
+func TestMergeKeyspaceFlags(t *testing.T) {
+	assert.Equal(t, "Kgx", mergeKeyspaceFlags("K", "gx"))
+	assert.Equal(t, "AK", mergeKeyspaceFlags("A", "Kgx"))
+	assert.Equal(t, "AKn", mergeKeyspaceFlags("AK", "Kn"))
+	assert.Equal(t, "Ex$K", mergeKeyspaceFlags("Ex", "$K"))
+}
+
+func TestKeyspaceNotifier_dispatch(t *testing.T) {
+	received := make([]string, 0)
+	notifier := NewRedis(option).NewKeyspaceNotifier(&KeyspaceNotifierOption{DB: 2})
+	notifier.On(KeyspaceEventExpired, "session:*", func(event *KeyspaceEvent) {
+		received = append(received, "expired "+event.Key)
+	}).On(KeyspaceEventHSet, "", func(event *KeyspaceEvent) {
+		received = append(received, "hset "+event.Key)
+	}).On(nil, "session:*", func(event *KeyspaceEvent) {
+		received = append(received, "any "+event.Event+" "+event.Key)
+	})
+	assert.Equal(t, "KxhA", notifier.Flags())
+
+	notifier.dispatch("__keyspace@2__:session:*", "__keyspace@2__:session:1", "expired")
+	notifier.dispatch("__keyspace@2__:*", "__keyspace@2__:session:1", "expired")
+	notifier.dispatch("__keyspace@2__:*", "__keyspace@2__:user:1", "hset")
+	notifier.dispatch("__keyspace@2__:*", "__keyspace@2__:user:1", "del")
+	assert.Equal(t, []string{"expired session:1", "any expired session:1", "hset user:1"}, received)
+}
+
+func TestKeyspaceNotifier_Listen(t *testing.T) {
+	pool := NewPool(nil, option)
+	defer pool.Destroy()
+	notifier := pool.NewKeyspaceNotifier(&KeyspaceNotifierOption{Configure: true})
+	received := make(chan *KeyspaceEvent, 10)
+	notifier.On(KeyspaceEventSet, "godis*", func(event *KeyspaceEvent) {
+		received <- event
+	})
+	done := make(chan error)
+	go func() {
+		done <- notifier.Listen()
+	}()
+	time.Sleep(500 * time.Millisecond)
+
+	redis, err := pool.GetResource()
+	assert.Nil(t, err)
+	defer redis.Close()
+	_, err = redis.Set("godis", "good")
+	assert.Nil(t, err)
+	_, err = redis.Set("other", "good")
+	assert.Nil(t, err)
+	select {
+	case event := <-received:
+		assert.Equal(t, "godis", event.Key)
+		assert.Equal(t, "set", event.Event)
+		assert.True(t, event.Keyspace)
+	case <-time.After(2 * time.Second):
+		t.Fatal("keyspace event timeout")
+	}
+	assert.Nil(t, notifier.Close())
+	assert.Nil(t, <-done)
+}
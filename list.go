@@ -0,0 +1,172 @@
+package godis
+
+import (
+	"fmt"
+	"strconv"
+)
+
+//ListDirection the side of a list, LEFT is the head and RIGHT is the tail
+type ListDirection struct {
+	name string // name of ListDirection
+}
+
+//getRaw get the name byte array
+func (d *ListDirection) getRaw() []byte {
+	return []byte(d.name)
+}
+
+func newListDirection(name string) *ListDirection {
+	return &ListDirection{name}
+}
+
+var (
+	//ListDirectionLeft the head of the list
+	ListDirectionLeft = newListDirection("LEFT")
+	//ListDirectionRight the tail of the list
+	ListDirectionRight = newListDirection("RIGHT")
+)
+
+//LPosArgs the options of LPOS
+type LPosArgs struct {
+	Rank   int64 //skip the first Rank-1 matches, negative Rank searches from the tail, 0 means the first match
+	MaxLen int64 //only compare the first MaxLen elements, 0 compares all the elements
+}
+
+//LMPopResult the elements popped by LMPOP or BLMPOP with the key they're popped from
+type LMPopResult struct {
+	Key      string
+	Elements []string
+}
+
+//getParams the key and the element followed by RANK, COUNT and MAXLEN, count < 0 sends no COUNT
+func (a *LPosArgs) getParams(key, element string, count int64) [][]byte {
+	params := []string{key, element}
+	if a != nil && a.Rank != 0 {
+		params = append(params, keywordRank.name, strconv.FormatInt(a.Rank, 10))
+	}
+	if count >= 0 {
+		params = append(params, keywordCount.name, strconv.FormatInt(count, 10))
+	}
+	if a != nil && a.MaxLen > 0 {
+		params = append(params, keywordMaxLen.name, strconv.FormatInt(a.MaxLen, 10))
+	}
+	return StrArrToByteArrArr(params)
+}
+
+//lmpopParams numkeys followed by the keys, the direction and COUNT of LMPOP and BLMPOP
+func lmpopParams(direction *ListDirection, count int64, keys []string) [][]byte {
+	arr := append(numKeysParams(keys), direction.getRaw())
+	if count > 0 {
+		arr = append(arr, keywordCount.getRaw(), Int64ToByteArr(count))
+	}
+	return arr
+}
+
+//ObjToLPosReply convert object reply of LPOS to int64 reply, -1 means the element is not found
+func ObjToLPosReply(reply interface{}, err error) (int64, error) {
+	if err != nil {
+		return 0, err
+	}
+	switch reply.(type) {
+	case nil:
+		return -1, nil
+	case int64:
+		return reply.(int64), nil
+	}
+	return 0, newDataError(fmt.Sprintf("malformed LPOS reply: %v", reply))
+}
+
+//ObjArrToLMPopResultReply convert object array reply of LMPOP and BLMPOP to LMPopResult reply,
+// nil means no element is popped
+func ObjArrToLMPopResultReply(reply []interface{}, err error) (*LMPopResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) == 0 {
+		return nil, nil
+	}
+	if len(reply) != 2 {
+		return nil, newDataError(fmt.Sprintf("malformed LMPOP reply: %v", reply))
+	}
+	items, ok := reply[1].([]interface{})
+	if !ok {
+		return nil, newDataError(fmt.Sprintf("malformed LMPOP reply: %v", reply))
+	}
+	result := &LMPopResult{Key: replyToString(reply[0]), Elements: make([]string, 0, len(items))}
+	for _, item := range items {
+		result.Elements = append(result.Elements, replyToString(item))
+	}
+	return result, nil
+}
+
+//ToLMPopResultReply convert object reply to LMPopResult reply
+func ToLMPopResultReply(reply interface{}, err error) (*LMPopResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.(*LMPopResult), nil
+}
+
+var (
+	//LPosBuilder convert interface to the index of LPOS, -1 means not found
+	LPosBuilder = newLPosBuilder()
+	//Int64ArrBuilder convert interface to int64 array
+	Int64ArrBuilder = newInt64ArrBuilder()
+	//LMPopResultBuilder convert interface to LMPopResult
+	LMPopResultBuilder = newLMPopResultBuilder()
+)
+
+type lposBuilder struct {
+}
+
+func newLPosBuilder() *lposBuilder {
+	return &lposBuilder{}
+}
+
+func (b *lposBuilder) build(data interface{}) (interface{}, error) {
+	return ObjToLPosReply(data, nil)
+}
+
+type int64ArrBuilder struct {
+}
+
+func newInt64ArrBuilder() *int64ArrBuilder {
+	return &int64ArrBuilder{}
+}
+
+func (b *int64ArrBuilder) build(data interface{}) (interface{}, error) {
+	if data == nil {
+		return []int64{}, nil
+	}
+	switch data.(type) {
+	case []interface{}:
+		arr := make([]int64, 0)
+		for _, item := range data.([]interface{}) {
+			i, ok := item.(int64)
+			if !ok {
+				return nil, fmt.Errorf("unexpected type:%T", item)
+			}
+			arr = append(arr, i)
+		}
+		return arr, nil
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
+
+type lmpopResultBuilder struct {
+}
+
+func newLMPopResultBuilder() *lmpopResultBuilder {
+	return &lmpopResultBuilder{}
+}
+
+func (b *lmpopResultBuilder) build(data interface{}) (interface{}, error) {
+	if data == nil {
+		return (*LMPopResult)(nil), nil
+	}
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToLMPopResultReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
@@ -1,6 +1,9 @@
 package godis
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
 //Response pipeline and transaction response,include replies from redis
 type Response struct {
@@ -147,6 +150,45 @@ func (t *Transaction) ExecGetResponse() ([]*Response, error) {
 	return result, nil
 }
 
+//execWatched execute transaction like Exec, but the redirect errors replied to the queued commands are returned
+// instead of EXECABORT, and TxFailedError is returned when EXEC is aborted because a watched key was modified
+func (t *Transaction) execWatched() ([]interface{}, error) {
+	queued := len(t.pipelinedResponses)
+	err := t.client.exec()
+	if err != nil {
+		return nil, err
+	}
+	all, err := t.client.getAll(1)
+	if err != nil {
+		return nil, err
+	}
+	t.inTransaction = false
+	var redirect error
+	for _, reply := range all.([]interface{}) {
+		switch reply.(type) {
+		case *MovedDataError, *AskDataError, *TryAgainError, *ClusterError:
+			redirect = reply.(error)
+		}
+	}
+	reply, err := t.client.getObjectMultiBulkReply()
+	if err != nil {
+		t.clean()
+		if redirect != nil {
+			return nil, redirect
+		}
+		return nil, err
+	}
+	if len(reply) == 0 && queued > 0 {
+		t.clean()
+		return nil, newTxFailedError("transaction failed, watched keys have been modified")
+	}
+	result := make([]interface{}, 0)
+	for _, r := range reply {
+		result = append(result, t.generateResponse(r))
+	}
+	return result, nil
+}
+
 //Discard  see redis command
 func (t *Transaction) Discard() (string, error) {
 	err := t.client.discard()
@@ -687,109 +729,1005 @@ func (p *multiKeyPipelineBase) PfCount(keys ...string) (*Response, error) {
 
 //</editor-fold>
 
-//<editor-fold desc="cluster pipeline">
+//<editor-fold desc="stream pipeline">
 
-//ClusterNodes see redis command
-func (p *multiKeyPipelineBase) ClusterNodes() (*Response, error) {
-	err := p.client.clusterNodes()
+//XAdd see redis command
+func (p *multiKeyPipelineBase) XAdd(key string, args *XAddArgs) (*Response, error) {
+	err := p.client.xadd(key, args)
 	if err != nil {
 		return nil, err
 	}
 	return p.getResponse(StrBuilder), nil
 }
 
-//ClusterMeet  see redis command
-func (p *multiKeyPipelineBase) ClusterMeet(ip string, port int) (*Response, error) {
-	err := p.client.clusterMeet(ip, port)
+//XLen see redis command
+func (p *multiKeyPipelineBase) XLen(key string) (*Response, error) {
+	err := p.client.xlen(key)
 	if err != nil {
 		return nil, err
 	}
-	return p.getResponse(StrBuilder), nil
+	return p.getResponse(Int64Builder), nil
 }
 
-//ClusterAddSlots  see redis command
-func (p *multiKeyPipelineBase) ClusterAddSlots(slots ...int) (*Response, error) {
-	err := p.client.clusterAddSlots(slots...)
+//XDel see redis command
+func (p *multiKeyPipelineBase) XDel(key string, ids ...string) (*Response, error) {
+	err := p.client.xdel(key, ids...)
 	if err != nil {
 		return nil, err
 	}
-	return p.getResponse(StrBuilder), nil
+	return p.getResponse(Int64Builder), nil
 }
 
-//ClusterDelSlots  see redis command
-func (p *multiKeyPipelineBase) ClusterDelSlots(slots ...int) (*Response, error) {
-	err := p.client.clusterDelSlots(slots...)
+//XTrim see redis command
+func (p *multiKeyPipelineBase) XTrim(key string, args *XTrimArgs) (*Response, error) {
+	err := p.client.xtrim(key, args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//XRange see redis command
+func (p *multiKeyPipelineBase) XRange(key, start, end string, count int64) (*Response, error) {
+	err := p.client.xrange(key, start, end, count)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StreamEntryArrBuilder), nil
+}
+
+//XRevRange see redis command
+func (p *multiKeyPipelineBase) XRevRange(key, end, start string, count int64) (*Response, error) {
+	err := p.client.xrevrange(key, end, start, count)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StreamEntryArrBuilder), nil
+}
+
+//XRead see redis command, the socket timeout isn't extended by Block in pipeline
+func (p *multiKeyPipelineBase) XRead(args *XReadArgs) (*Response, error) {
+	err := p.client.xread(args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(XStreamArrBuilder), nil
+}
+
+//XInfoStream see redis command
+func (p *multiKeyPipelineBase) XInfoStream(key string) (*Response, error) {
+	err := p.client.xinfoStream(key)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(XInfoStreamBuilder), nil
+}
+
+//XGroupCreate see redis command
+func (p *multiKeyPipelineBase) XGroupCreate(key, group, id string, mkStream bool) (*Response, error) {
+	err := p.client.xgroupCreate(key, group, id, mkStream)
 	if err != nil {
 		return nil, err
 	}
 	return p.getResponse(StrBuilder), nil
 }
 
-//ClusterInfo  see redis command
-func (p *multiKeyPipelineBase) ClusterInfo() (*Response, error) {
-	err := p.client.clusterInfo()
+//XGroupDestroy see redis command
+func (p *multiKeyPipelineBase) XGroupDestroy(key, group string) (*Response, error) {
+	err := p.client.xgroupDestroy(key, group)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//XGroupSetID see redis command
+func (p *multiKeyPipelineBase) XGroupSetID(key, group, id string) (*Response, error) {
+	err := p.client.xgroupSetID(key, group, id)
 	if err != nil {
 		return nil, err
 	}
 	return p.getResponse(StrBuilder), nil
 }
 
-//ClusterGetKeysInSlot  see redis command
-func (p *multiKeyPipelineBase) ClusterGetKeysInSlot(slot int, count int) (*Response, error) {
-	err := p.client.clusterGetKeysInSlot(slot, count)
+//XGroupCreateConsumer see redis command
+func (p *multiKeyPipelineBase) XGroupCreateConsumer(key, group, consumer string) (*Response, error) {
+	err := p.client.xgroupCreateConsumer(key, group, consumer)
 	if err != nil {
 		return nil, err
 	}
-	return p.getResponse(StrArrBuilder), nil
+	return p.getResponse(Int64Builder), nil
 }
 
-//ClusterSetSlotNode  see redis command
-func (p *multiKeyPipelineBase) ClusterSetSlotNode(slot int, nodeID string) (*Response, error) {
-	err := p.client.clusterSetSlotNode(slot, nodeID)
+//XGroupDelConsumer see redis command
+func (p *multiKeyPipelineBase) XGroupDelConsumer(key, group, consumer string) (*Response, error) {
+	err := p.client.xgroupDelConsumer(key, group, consumer)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//XReadGroup see redis command, the socket timeout isn't extended by Block in pipeline
+func (p *multiKeyPipelineBase) XReadGroup(args *XReadGroupArgs) (*Response, error) {
+	err := p.client.xreadGroup(args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(XStreamArrBuilder), nil
+}
+
+//XAck see redis command
+func (p *multiKeyPipelineBase) XAck(key, group string, ids ...string) (*Response, error) {
+	err := p.client.xack(key, group, ids...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//XPending see redis command
+func (p *multiKeyPipelineBase) XPending(key, group string) (*Response, error) {
+	err := p.client.xpending(key, group)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(XPendingSummaryBuilder), nil
+}
+
+//XPendingRange see redis command
+func (p *multiKeyPipelineBase) XPendingRange(key, group string, args *XPendingArgs) (*Response, error) {
+	err := p.client.xpendingRange(key, group, args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(XPendingEntryArrBuilder), nil
+}
+
+//XClaim see redis command
+func (p *multiKeyPipelineBase) XClaim(key string, args *XClaimArgs) (*Response, error) {
+	err := p.client.xclaim(key, args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StreamEntryArrBuilder), nil
+}
+
+//XAutoClaim see redis command
+func (p *multiKeyPipelineBase) XAutoClaim(key string, args *XAutoClaimArgs) (*Response, error) {
+	err := p.client.xautoclaim(key, args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(XAutoClaimResultBuilder), nil
+}
+
+//</editor-fold>
+
+//<editor-fold desc="string pipeline">
+
+//SetWithArgs see redis command
+func (p *multiKeyPipelineBase) SetWithArgs(key, value string, args *SetArgs) (*Response, error) {
+	err := p.client.setWithArgs(key, value, args)
 	if err != nil {
 		return nil, err
 	}
 	return p.getResponse(StrBuilder), nil
 }
 
-//ClusterSetSlotMigrating  see redis command
-func (p *multiKeyPipelineBase) ClusterSetSlotMigrating(slot int, nodeID string) (*Response, error) {
-	err := p.client.clusterSetSlotMigrating(slot, nodeID)
+//GetDel see redis command
+func (p *multiKeyPipelineBase) GetDel(key string) (*Response, error) {
+	err := p.client.getdel(key)
 	if err != nil {
 		return nil, err
 	}
 	return p.getResponse(StrBuilder), nil
 }
 
-//ClusterSetSlotImporting  see redis command
-func (p *multiKeyPipelineBase) ClusterSetSlotImporting(slot int, nodeID string) (*Response, error) {
-	err := p.client.clusterSetSlotImporting(slot, nodeID)
+//GetEx see redis command
+func (p *multiKeyPipelineBase) GetEx(key string, args *GetExArgs) (*Response, error) {
+	err := p.client.getex(key, args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//LCS see redis command
+func (p *multiKeyPipelineBase) LCS(key1, key2 string) (*Response, error) {
+	err := p.client.lcs(key1, key2)
 	if err != nil {
 		return nil, err
 	}
 	return p.getResponse(StrBuilder), nil
 }
 
+//LCSLen see redis command
+func (p *multiKeyPipelineBase) LCSLen(key1, key2 string) (*Response, error) {
+	err := p.client.lcsLen(key1, key2)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//LCSIdx see redis command
+func (p *multiKeyPipelineBase) LCSIdx(key1, key2 string, args *LCSIdxArgs) (*Response, error) {
+	err := p.client.lcsIdx(key1, key2, args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(LCSResultBuilder), nil
+}
+
 //</editor-fold>
 
-//<editor-fold desc="scripting pipeline">
+//<editor-fold desc="list pipeline">
 
-//Eval see redis command
-func (p *multiKeyPipelineBase) Eval(script string, keyCount int, params ...string) (*Response, error) {
-	err := p.getClient(script).eval(script, keyCount, params...)
+//LPos see redis command
+func (p *multiKeyPipelineBase) LPos(key, element string, args *LPosArgs) (*Response, error) {
+	err := p.client.lpos(key, element, -1, args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(LPosBuilder), nil
+}
+
+//LPosCount see redis command
+func (p *multiKeyPipelineBase) LPosCount(key, element string, count int64, args *LPosArgs) (*Response, error) {
+	err := p.client.lpos(key, element, count, args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64ArrBuilder), nil
+}
+
+//LMove see redis command
+func (p *multiKeyPipelineBase) LMove(srcKey, destKey string, from, to *ListDirection) (*Response, error) {
+	err := p.client.lmove(srcKey, destKey, from, to)
 	if err != nil {
 		return nil, err
 	}
 	return p.getResponse(StrBuilder), nil
 }
 
-//EvalSha  see redis command
-func (p *multiKeyPipelineBase) EvalSha(sha1 string, keyCount int, params ...string) (*Response, error) {
-	err := p.getClient(sha1).evalsha(sha1, keyCount, params...)
+//BLMove see redis command
+func (p *multiKeyPipelineBase) BLMove(srcKey, destKey string, from, to *ListDirection, timeout time.Duration) (*Response, error) {
+	err := p.client.blmove(srcKey, destKey, from, to, timeout)
 	if err != nil {
 		return nil, err
 	}
 	return p.getResponse(StrBuilder), nil
 }
 
+//LMPop see redis command
+func (p *multiKeyPipelineBase) LMPop(direction *ListDirection, count int64, keys ...string) (*Response, error) {
+	err := p.client.lmpop(direction, count, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(LMPopResultBuilder), nil
+}
+
+//BLMPop see redis command
+func (p *multiKeyPipelineBase) BLMPop(timeout time.Duration, direction *ListDirection, count int64, keys ...string) (*Response, error) {
+	err := p.client.blmpop(timeout, direction, count, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(LMPopResultBuilder), nil
+}
+
+//LPopCount see redis command
+func (p *multiKeyPipelineBase) LPopCount(key string, count int64) (*Response, error) {
+	err := p.client.popCount(cmdLPop, key, count)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrArrBuilder), nil
+}
+
+//RPopCount see redis command
+func (p *multiKeyPipelineBase) RPopCount(key string, count int64) (*Response, error) {
+	err := p.client.popCount(cmdRPop, key, count)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrArrBuilder), nil
+}
+
+//</editor-fold>
+
+//<editor-fold desc="sorted set pipeline">
+
+//ZPopMin see redis command
+func (p *multiKeyPipelineBase) ZPopMin(key string, count int64) (*Response, error) {
+	err := p.client.zpop(cmdZPopMin, key, count)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(TupleArrBuilder), nil
+}
+
+//ZPopMax see redis command
+func (p *multiKeyPipelineBase) ZPopMax(key string, count int64) (*Response, error) {
+	err := p.client.zpop(cmdZPopMax, key, count)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(TupleArrBuilder), nil
+}
+
+//BZPopMin see redis command
+func (p *multiKeyPipelineBase) BZPopMin(timeout time.Duration, keys ...string) (*Response, error) {
+	err := p.client.bzpop(cmdBZPopMin, timeout, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(KeyedTupleBuilder), nil
+}
+
+//BZPopMax see redis command
+func (p *multiKeyPipelineBase) BZPopMax(timeout time.Duration, keys ...string) (*Response, error) {
+	err := p.client.bzpop(cmdBZPopMax, timeout, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(KeyedTupleBuilder), nil
+}
+
+//ZMPop see redis command
+func (p *multiKeyPipelineBase) ZMPop(option *SortedSetOption, count int64, keys ...string) (*Response, error) {
+	err := p.client.zmpop(option, count, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(ZMPopResultBuilder), nil
+}
+
+//BZMPop see redis command
+func (p *multiKeyPipelineBase) BZMPop(timeout time.Duration, option *SortedSetOption, count int64, keys ...string) (*Response, error) {
+	err := p.client.bzmpop(timeout, option, count, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(ZMPopResultBuilder), nil
+}
+
+//ZRandMember see redis command
+func (p *multiKeyPipelineBase) ZRandMember(key string, count int64) (*Response, error) {
+	err := p.client.zrandmember(key, count, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrArrBuilder), nil
+}
+
+//ZRandMemberWithScores see redis command
+func (p *multiKeyPipelineBase) ZRandMemberWithScores(key string, count int64) (*Response, error) {
+	err := p.client.zrandmember(key, count, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(TupleArrBuilder), nil
+}
+
+//ZMScore see redis command
+func (p *multiKeyPipelineBase) ZMScore(key string, members ...string) (*Response, error) {
+	err := p.client.zmscore(key, members...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Float64PtrArrBuilder), nil
+}
+
+//ZRangeByParams see redis command
+func (p *multiKeyPipelineBase) ZRangeByParams(key string, params *ZRangeParams) (*Response, error) {
+	err := p.client.zrangeByParams(key, params, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrArrBuilder), nil
+}
+
+//ZRangeWithScoresByParams see redis command
+func (p *multiKeyPipelineBase) ZRangeWithScoresByParams(key string, params *ZRangeParams) (*Response, error) {
+	err := p.client.zrangeByParams(key, params, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(TupleArrBuilder), nil
+}
+
+//ZRangeStore see redis command
+func (p *multiKeyPipelineBase) ZRangeStore(destKey, srcKey string, params *ZRangeParams) (*Response, error) {
+	err := p.client.zrangestore(destKey, srcKey, params)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//ZDiff see redis command
+func (p *multiKeyPipelineBase) ZDiff(keys ...string) (*Response, error) {
+	err := p.client.zdiff(false, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrArrBuilder), nil
+}
+
+//ZDiffWithScores see redis command
+func (p *multiKeyPipelineBase) ZDiffWithScores(keys ...string) (*Response, error) {
+	err := p.client.zdiff(true, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(TupleArrBuilder), nil
+}
+
+//ZDiffStore see redis command
+func (p *multiKeyPipelineBase) ZDiffStore(destKey string, keys ...string) (*Response, error) {
+	err := p.client.zdiffstore(destKey, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//ZInter see redis command
+func (p *multiKeyPipelineBase) ZInter(params *ZParams, keys ...string) (*Response, error) {
+	err := p.client.zsetOperation(cmdZInter, params, false, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrArrBuilder), nil
+}
+
+//ZInterWithScores see redis command
+func (p *multiKeyPipelineBase) ZInterWithScores(params *ZParams, keys ...string) (*Response, error) {
+	err := p.client.zsetOperation(cmdZInter, params, true, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(TupleArrBuilder), nil
+}
+
+//ZUnion see redis command
+func (p *multiKeyPipelineBase) ZUnion(params *ZParams, keys ...string) (*Response, error) {
+	err := p.client.zsetOperation(cmdZUnion, params, false, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrArrBuilder), nil
+}
+
+//ZUnionWithScores see redis command
+func (p *multiKeyPipelineBase) ZUnionWithScores(params *ZParams, keys ...string) (*Response, error) {
+	err := p.client.zsetOperation(cmdZUnion, params, true, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(TupleArrBuilder), nil
+}
+
+//</editor-fold>
+
+//<editor-fold desc="hash pipeline">
+
+//HSetByMap see redis command
+func (p *multiKeyPipelineBase) HSetByMap(key string, hash map[string]string) (*Response, error) {
+	err := p.client.hsetByMap(key, hash)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//HStrLen see redis command
+func (p *multiKeyPipelineBase) HStrLen(key, field string) (*Response, error) {
+	err := p.client.hstrlen(key, field)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//HRandField see redis command
+func (p *multiKeyPipelineBase) HRandField(key string, count int64) (*Response, error) {
+	err := p.client.hrandfield(key, count, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrArrBuilder), nil
+}
+
+//HRandFieldWithValues see redis command
+func (p *multiKeyPipelineBase) HRandFieldWithValues(key string, count int64) (*Response, error) {
+	err := p.client.hrandfield(key, count, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(HashFieldValueArrBuilder), nil
+}
+
+//HExpire see redis command
+func (p *multiKeyPipelineBase) HExpire(key string, expiration time.Duration, condition *ExpireCondition, fields ...string) (*Response, error) {
+	err := p.client.hexpire(cmdHExpire, key, int64(expiration/time.Second), condition, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(HashFieldExpireStatusArrBuilder), nil
+}
+
+//HPExpire see redis command
+func (p *multiKeyPipelineBase) HPExpire(key string, expiration time.Duration, condition *ExpireCondition, fields ...string) (*Response, error) {
+	err := p.client.hexpire(cmdHPExpire, key, int64(expiration/time.Millisecond), condition, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(HashFieldExpireStatusArrBuilder), nil
+}
+
+//HExpireAt see redis command
+func (p *multiKeyPipelineBase) HExpireAt(key string, at time.Time, condition *ExpireCondition, fields ...string) (*Response, error) {
+	err := p.client.hexpire(cmdHExpireAt, key, at.Unix(), condition, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(HashFieldExpireStatusArrBuilder), nil
+}
+
+//HPExpireAt see redis command
+func (p *multiKeyPipelineBase) HPExpireAt(key string, at time.Time, condition *ExpireCondition, fields ...string) (*Response, error) {
+	err := p.client.hexpire(cmdHPExpireAt, key, at.UnixNano()/int64(time.Millisecond), condition, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(HashFieldExpireStatusArrBuilder), nil
+}
+
+//HTTL see redis command
+func (p *multiKeyPipelineBase) HTTL(key string, fields ...string) (*Response, error) {
+	err := p.client.hfields(cmdHTTL, key, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64ArrBuilder), nil
+}
+
+//HPTTL see redis command
+func (p *multiKeyPipelineBase) HPTTL(key string, fields ...string) (*Response, error) {
+	err := p.client.hfields(cmdHPTTL, key, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64ArrBuilder), nil
+}
+
+//HPersist see redis command
+func (p *multiKeyPipelineBase) HPersist(key string, fields ...string) (*Response, error) {
+	err := p.client.hfields(cmdHPersist, key, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(HashFieldPersistStatusArrBuilder), nil
+}
+
+//HGetDel see redis command
+func (p *multiKeyPipelineBase) HGetDel(key string, fields ...string) (*Response, error) {
+	err := p.client.hfields(cmdHGetDel, key, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrArrBuilder), nil
+}
+
+//HGetEx see redis command
+func (p *multiKeyPipelineBase) HGetEx(key string, args *GetExArgs, fields ...string) (*Response, error) {
+	err := p.client.hgetex(key, args, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrArrBuilder), nil
+}
+
+//HSetEx see redis command
+func (p *multiKeyPipelineBase) HSetEx(key string, args *HSetExArgs, hash map[string]string) (*Response, error) {
+	err := p.client.hsetex(key, args, hash)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//</editor-fold>
+
+//<editor-fold desc="geo pipeline">
+
+//GeoRadiusRO see redis command
+func (p *multiKeyPipelineBase) GeoRadiusRO(key string, longitude, latitude, radius float64, unit *GeoUnit, param ...*GeoRadiusParams) (*Response, error) {
+	err := p.client.georadius(cmdGeoRadiusRo, key, longitude, latitude, radius, unit, nil, param...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(GeoRadiusResponseArrBuilder), nil
+}
+
+//GeoRadiusByMemberRO see redis command
+func (p *multiKeyPipelineBase) GeoRadiusByMemberRO(key, member string, radius float64, unit *GeoUnit, param ...*GeoRadiusParams) (*Response, error) {
+	err := p.client.georadiusByMember(cmdGeoRadiusByMemberRo, key, member, radius, unit, nil, param...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(GeoRadiusResponseArrBuilder), nil
+}
+
+//GeoRadiusStore see redis command
+func (p *multiKeyPipelineBase) GeoRadiusStore(key string, longitude, latitude, radius float64, unit *GeoUnit, param *GeoRadiusParams, store *GeoRadiusStoreArgs) (*Response, error) {
+	err := p.client.georadius(cmdGeoRadius, key, longitude, latitude, radius, unit, store, param)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//GeoRadiusByMemberStore see redis command
+func (p *multiKeyPipelineBase) GeoRadiusByMemberStore(key, member string, radius float64, unit *GeoUnit, param *GeoRadiusParams, store *GeoRadiusStoreArgs) (*Response, error) {
+	err := p.client.georadiusByMember(cmdGeoRadiusByMember, key, member, radius, unit, store, param)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//GeoSearch see redis command
+func (p *multiKeyPipelineBase) GeoSearch(key string, query *GeoSearchQuery) (*Response, error) {
+	err := p.client.geosearch(key, query)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(GeoRadiusResponseArrBuilder), nil
+}
+
+//GeoSearchStore see redis command
+func (p *multiKeyPipelineBase) GeoSearchStore(destKey, srcKey string, query *GeoSearchQuery) (*Response, error) {
+	err := p.client.geosearchstore(destKey, srcKey, query, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//GeoSearchStoreDist see redis command
+func (p *multiKeyPipelineBase) GeoSearchStoreDist(destKey, srcKey string, query *GeoSearchQuery) (*Response, error) {
+	err := p.client.geosearchstore(destKey, srcKey, query, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//</editor-fold>
+
+//<editor-fold desc="key pipeline">
+
+//Copy see redis command
+func (p *multiKeyPipelineBase) Copy(srcKey, destKey string, args *CopyArgs) (*Response, error) {
+	err := p.client.copy(srcKey, destKey, args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//Touch see redis command
+func (p *multiKeyPipelineBase) Touch(keys ...string) (*Response, error) {
+	err := p.client.touch(keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//Unlink see redis command
+func (p *multiKeyPipelineBase) Unlink(keys ...string) (*Response, error) {
+	err := p.client.unlink(keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//Dump see redis command
+func (p *multiKeyPipelineBase) Dump(key string) (*Response, error) {
+	err := p.client.dump(key)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(ByteArrBuilder), nil
+}
+
+//Restore see redis command
+func (p *multiKeyPipelineBase) Restore(key string, ttl time.Duration, serializedValue []byte, args *RestoreArgs) (*Response, error) {
+	err := p.client.restore(key, int64(ttl/time.Millisecond), serializedValue, args, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//RestoreAt see redis command
+func (p *multiKeyPipelineBase) RestoreAt(key string, at time.Time, serializedValue []byte, args *RestoreArgs) (*Response, error) {
+	err := p.client.restore(key, at.UnixNano()/int64(time.Millisecond), serializedValue, args, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//Migrate see redis command
+func (p *multiKeyPipelineBase) Migrate(host string, port int, destinationDB int, timeout int, params *MigrateParams, keys ...string) (*Response, error) {
+	err := p.client.migrate(host, port, destinationDB, timeout, params, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//ExpireTime see redis command
+func (p *multiKeyPipelineBase) ExpireTime(key string) (*Response, error) {
+	err := p.client.expireTime(cmdExpireTime, key)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//PExpireTime see redis command
+func (p *multiKeyPipelineBase) PExpireTime(key string) (*Response, error) {
+	err := p.client.expireTime(cmdPExpireTime, key)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//ExpireWithCondition see redis command
+func (p *multiKeyPipelineBase) ExpireWithCondition(key string, expiration time.Duration, condition *ExpireCondition) (*Response, error) {
+	err := p.client.expireWithCondition(cmdExpire, key, int64(expiration/time.Second), condition)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//PExpireWithCondition see redis command
+func (p *multiKeyPipelineBase) PExpireWithCondition(key string, expiration time.Duration, condition *ExpireCondition) (*Response, error) {
+	err := p.client.expireWithCondition(cmdPExpire, key, int64(expiration/time.Millisecond), condition)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//ExpireAtWithCondition see redis command
+func (p *multiKeyPipelineBase) ExpireAtWithCondition(key string, at time.Time, condition *ExpireCondition) (*Response, error) {
+	err := p.client.expireWithCondition(cmdExpireAt, key, at.Unix(), condition)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//PExpireAtWithCondition see redis command
+func (p *multiKeyPipelineBase) PExpireAtWithCondition(key string, at time.Time, condition *ExpireCondition) (*Response, error) {
+	err := p.client.expireWithCondition(cmdPExpireAt, key, at.UnixNano()/int64(time.Millisecond), condition)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(Int64Builder), nil
+}
+
+//</editor-fold>
+
+//<editor-fold desc="cluster pipeline">
+
+//ClusterNodes see redis command
+func (p *multiKeyPipelineBase) ClusterNodes() (*Response, error) {
+	err := p.client.clusterNodes()
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//ClusterMeet  see redis command
+func (p *multiKeyPipelineBase) ClusterMeet(ip string, port int) (*Response, error) {
+	err := p.client.clusterMeet(ip, port)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//ClusterAddSlots  see redis command
+func (p *multiKeyPipelineBase) ClusterAddSlots(slots ...int) (*Response, error) {
+	err := p.client.clusterAddSlots(slots...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//ClusterDelSlots  see redis command
+func (p *multiKeyPipelineBase) ClusterDelSlots(slots ...int) (*Response, error) {
+	err := p.client.clusterDelSlots(slots...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//ClusterInfo  see redis command
+func (p *multiKeyPipelineBase) ClusterInfo() (*Response, error) {
+	err := p.client.clusterInfo()
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//ClusterGetKeysInSlot  see redis command
+func (p *multiKeyPipelineBase) ClusterGetKeysInSlot(slot int, count int) (*Response, error) {
+	err := p.client.clusterGetKeysInSlot(slot, count)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrArrBuilder), nil
+}
+
+//ClusterSetSlotNode  see redis command
+func (p *multiKeyPipelineBase) ClusterSetSlotNode(slot int, nodeID string) (*Response, error) {
+	err := p.client.clusterSetSlotNode(slot, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//ClusterSetSlotMigrating  see redis command
+func (p *multiKeyPipelineBase) ClusterSetSlotMigrating(slot int, nodeID string) (*Response, error) {
+	err := p.client.clusterSetSlotMigrating(slot, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//ClusterSetSlotImporting  see redis command
+func (p *multiKeyPipelineBase) ClusterSetSlotImporting(slot int, nodeID string) (*Response, error) {
+	err := p.client.clusterSetSlotImporting(slot, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//</editor-fold>
+
+//<editor-fold desc="scripting pipeline">
+
+//Eval see redis command
+func (p *multiKeyPipelineBase) Eval(script string, keyCount int, params ...string) (*Response, error) {
+	err := p.getClient(script).eval(script, keyCount, params...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//EvalSha  see redis command
+func (p *multiKeyPipelineBase) EvalSha(sha1 string, keyCount int, params ...string) (*Response, error) {
+	err := p.getClient(sha1).evalsha(sha1, keyCount, params...)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//</editor-fold>
+
+//<editor-fold desc="function pipeline">
+
+//FunctionLoad see redis command
+func (p *multiKeyPipelineBase) FunctionLoad(code string) (*Response, error) {
+	err := p.client.functionLoad(code, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//FunctionLoadReplace see redis command
+func (p *multiKeyPipelineBase) FunctionLoadReplace(code string) (*Response, error) {
+	err := p.client.functionLoad(code, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//FunctionDelete see redis command
+func (p *multiKeyPipelineBase) FunctionDelete(libraryName string) (*Response, error) {
+	err := p.client.functionDelete(libraryName)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//FunctionFlush see redis command
+func (p *multiKeyPipelineBase) FunctionFlush(mode *FlushMode) (*Response, error) {
+	err := p.client.functionFlush(mode)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//FunctionList see redis command
+func (p *multiKeyPipelineBase) FunctionList(libraryNamePattern string, withCode bool) (*Response, error) {
+	err := p.client.functionList(libraryNamePattern, withCode)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(FunctionLibraryArrBuilder), nil
+}
+
+//FunctionDump see redis command
+func (p *multiKeyPipelineBase) FunctionDump() (*Response, error) {
+	err := p.client.functionDump()
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(ByteArrBuilder), nil
+}
+
+//FunctionRestore see redis command
+func (p *multiKeyPipelineBase) FunctionRestore(serializedValue []byte, policy *FunctionRestorePolicy) (*Response, error) {
+	err := p.client.functionRestore(serializedValue, policy)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(StrBuilder), nil
+}
+
+//FunctionStats see redis command
+func (p *multiKeyPipelineBase) FunctionStats() (*Response, error) {
+	err := p.client.functionStats()
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(FunctionStatsBuilder), nil
+}
+
+//FCall see redis command
+func (p *multiKeyPipelineBase) FCall(function string, keys, args []string) (*Response, error) {
+	err := p.client.fcall(cmdFCall, function, keys, args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(EvalResultBuilder), nil
+}
+
+//FCallRO see redis command
+func (p *multiKeyPipelineBase) FCallRO(function string, keys, args []string) (*Response, error) {
+	err := p.client.fcall(cmdFCallRo, function, keys, args)
+	if err != nil {
+		return nil, err
+	}
+	return p.getResponse(EvalResultBuilder), nil
+}
+
 //</editor-fold>
@@ -508,6 +508,26 @@ func Test_multiKeyPipelineBase_Del(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func Test_multiKeyPipelineBase_Dump(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.Set("godis", "good")
+	assert.Nil(t, err)
+	p := redis.Pipelined()
+	dumpResp, err := p.Dump("godis")
+	assert.Nil(t, err)
+	unlinkResp, err := p.Unlink("godis")
+	assert.Nil(t, err)
+	assert.Nil(t, p.Sync())
+	value, err := ToByteArrReply(dumpResp.Get())
+	assert.Nil(t, err)
+	assert.NotEmpty(t, value)
+	unlinked, err := ToInt64Reply(unlinkResp.Get())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), unlinked)
+}
+
 func Test_multiKeyPipelineBase_Eval(t *testing.T) {
 	initDb()
 	redis := NewRedis(option)
@@ -591,6 +611,26 @@ func Test_multiKeyPipelineBase_Exists(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func Test_multiKeyPipelineBase_FCall(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.FunctionLoadReplace(testFunctionLibrary)
+	assert.Nil(t, err)
+	p := redis.Pipelined()
+	callResp, err := p.FCallRO("godis_echo", nil, []string{"a"})
+	assert.Nil(t, err)
+	listResp, err := p.FunctionList("godislib", false)
+	assert.Nil(t, err)
+	assert.Nil(t, p.Sync())
+	reply, err := callResp.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a"}, reply)
+	libraries, err := ToFunctionLibraryArrReply(listResp.Get())
+	assert.Nil(t, err)
+	assert.Len(t, libraries, 1)
+}
+
 func Test_multiKeyPipelineBase_FlushAll(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -655,6 +695,40 @@ func Test_multiKeyPipelineBase_FlushDB(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func Test_multiKeyPipelineBase_GeoSearch(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.GeoAdd("godis", 121, 37, "a")
+	assert.Nil(t, err)
+	p := redis.Pipelined()
+	searchResp, err := p.GeoSearch("godis", NewGeoSearchQuery().FromLonLat(121, 37).ByRadius(1, GeoUnitKm))
+	assert.Nil(t, err)
+	assert.Nil(t, p.Sync())
+	resp, err := ToGeoRespArrReply(searchResp.Get())
+	assert.Nil(t, err)
+	assert.Len(t, resp, 1)
+	assert.Equal(t, "a", resp[0].Member())
+}
+
+func Test_multiKeyPipelineBase_HSetByMap(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	p := redis.Pipelined()
+	setResp, err := p.HSetByMap("godis", map[string]string{"a": "1"})
+	assert.Nil(t, err)
+	ttlResp, err := p.HTTL("godis", "a")
+	assert.Nil(t, err)
+	assert.Nil(t, p.Sync())
+	added, err := ToInt64Reply(setResp.Get())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), added)
+	ttls, err := ToInt64ArrReply(ttlResp.Get())
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{-1}, ttls)
+}
+
 func Test_multiKeyPipelineBase_Info(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -720,6 +794,26 @@ func Test_multiKeyPipelineBase_Lastsave(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func Test_multiKeyPipelineBase_LPos(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.RPush("godis", "a", "b")
+	assert.Nil(t, err)
+	p := redis.Pipelined()
+	posResp, err := p.LPos("godis", "none", nil)
+	assert.Nil(t, err)
+	moveResp, err := p.LMove("godis", "godis", ListDirectionLeft, ListDirectionRight)
+	assert.Nil(t, err)
+	assert.Nil(t, p.Sync())
+	index, err := ToInt64Reply(posResp.Get())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), index)
+	element, err := ToStrReply(moveResp.Get())
+	assert.Nil(t, err)
+	assert.Equal(t, "a", element)
+}
+
 func Test_multiKeyPipelineBase_Mget(t *testing.T) {
 	initDb()
 	redis := NewRedis(option)
@@ -1030,6 +1124,24 @@ func Test_multiKeyPipelineBase_Select(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func Test_multiKeyPipelineBase_SetWithArgs(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	p := redis.Pipelined()
+	setResp, err := p.SetWithArgs("godis", "1", NewSetArgs().PX(time.Minute))
+	assert.Nil(t, err)
+	getResp, err := p.GetDel("godis")
+	assert.Nil(t, err)
+	assert.Nil(t, p.Sync())
+	reply, err := ToStrReply(setResp.Get())
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", reply)
+	reply, err = ToStrReply(getResp.Get())
+	assert.Nil(t, err)
+	assert.Equal(t, "1", reply)
+}
+
 func Test_multiKeyPipelineBase_Shutdown(t *testing.T) {
 	redis := NewRedis(&Option{Host: "localhost", Port: 9000})
 	defer redis.Close()
@@ -1150,6 +1262,26 @@ func Test_multiKeyPipelineBase_Watch(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func Test_multiKeyPipelineBase_XAdd(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	p := redis.Pipelined()
+	_, err := p.XAdd("godis", &XAddArgs{ID: "1-0", Fields: map[string]string{"a": "1"}})
+	assert.Nil(t, err)
+	rangeResp, err := p.XRange("godis", "-", "+", 0)
+	assert.Nil(t, err)
+	readResp, err := p.XRead(&XReadArgs{Keys: []string{"godis"}, IDs: []string{"0"}})
+	assert.Nil(t, err)
+	assert.Nil(t, p.Sync())
+	entries, err := ToStreamEntryArrReply(rangeResp.Get())
+	assert.Nil(t, err)
+	assert.Equal(t, "1-0", entries[0].ID)
+	streams, err := ToXStreamArrReply(readResp.Get())
+	assert.Nil(t, err)
+	assert.Equal(t, "godis", streams[0].Key)
+}
+
 func Test_multiKeyPipelineBase_Zinterstore(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -1203,6 +1335,31 @@ func Test_multiKeyPipelineBase_Zinterstore(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func Test_multiKeyPipelineBase_ZPopMin(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.ZAddByMap("godis", map[string]float64{"a": 1, "b": 2})
+	assert.Nil(t, err)
+	p := redis.Pipelined()
+	scoreResp, err := p.ZMScore("godis", "a", "none")
+	assert.Nil(t, err)
+	rangeResp, err := p.ZRangeWithScoresByParams("godis", NewZRangeParams(0, -1))
+	assert.Nil(t, err)
+	popResp, err := p.ZMPop(SortedSetOptionMin, 1, "godis")
+	assert.Nil(t, err)
+	assert.Nil(t, p.Sync())
+	scores, err := ToFloat64PtrArrReply(scoreResp.Get())
+	assert.Nil(t, err)
+	assert.Nil(t, scores[1])
+	tuples, err := ToTupleArrReply(rangeResp.Get())
+	assert.Nil(t, err)
+	assert.Len(t, tuples, 2)
+	result, err := ToZMPopResultReply(popResp.Get())
+	assert.Nil(t, err)
+	assert.Equal(t, "a", result.Tuples[0].Element())
+}
+
 func Test_Transaction(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -16,6 +16,7 @@ var (
 type Pool struct {
 	internalPool *pool.ObjectPool
 	ctx          context.Context
+	option       *Option
 }
 
 //PoolConfig redis pool config, see go-commons-pool ObjectPoolConfig
@@ -62,6 +63,7 @@ func NewPool(config *PoolConfig, option *Option) *Pool {
 	return &Pool{
 		ctx:          ctx,
 		internalPool: internalPool,
+		option:       option,
 	}
 }
 
@@ -13,6 +13,7 @@ const (
 	askPrefix         = "ASK "
 	movedPrefix       = "MOVED "
 	clusterDownPrefix = "CLUSTERDOWN "
+	tryAgainPrefix    = "TRYAGAIN "
 	busyPrefix        = "BUSY "
 	noscriptPrefix    = "NOSCRIPT "
 
@@ -170,8 +171,8 @@ func (r *redisOutputStream) flushBuffer() error {
 	if r.count <= 0 {
 		return nil
 	}
-	if err := r.c.socket.SetDeadline(time.Now().Add(r.c.soTimeout)); err != nil {
-		return newConnectError(err.Error())
+	if err := r.c.refreshWriteDeadline(); err != nil {
+		return err
 	}
 	_, err := r.Write(r.buf[0:r.count])
 	if err != nil {
@@ -263,9 +264,8 @@ func (r *redisInputStream) ensureFill() error {
 	if err != nil {
 		return newConnectError(err.Error())
 	}
-	err = r.c.socket.SetDeadline(time.Now().Add(r.c.soTimeout))
-	if err != nil {
-		return newConnectError(err.Error())
+	if err = r.c.refreshReadDeadline(); err != nil {
+		return err
 	}
 	r.count = 0
 	if r.limit == -1 {
@@ -557,6 +557,8 @@ func (p *protocol) processError() (interface{}, error) {
 		return nil, newAskDataError(msg, host, port, slot)
 	} else if strings.HasPrefix(msg, clusterDownPrefix) {
 		return nil, newClusterError(msg)
+	} else if strings.HasPrefix(msg, tryAgainPrefix) {
+		return nil, newTryAgainError(msg)
 	} else if strings.HasPrefix(msg, busyPrefix) {
 		return nil, newBusyError(msg)
 	} else if strings.HasPrefix(msg, noscriptPrefix) {
@@ -695,6 +697,9 @@ var (
 	cmdPSubscribe          = newProtocolCommand("PSUBSCRIBE")
 	cmdPUnSubscribe        = newProtocolCommand("PUNSUBSCRIBE")
 	cmdPubSub              = newProtocolCommand("PUBSUB")
+	cmdSSubscribe          = newProtocolCommand("SSUBSCRIBE")
+	cmdSUnSubscribe        = newProtocolCommand("SUNSUBSCRIBE")
+	cmdSPublish            = newProtocolCommand("SPUBLISH")
 	cmdZCount              = newProtocolCommand("ZCOUNT")
 	cmdZRangeByScore       = newProtocolCommand("ZRANGEBYSCORE")
 	cmdZRevRangeByScore    = newProtocolCommand("ZREVRANGEBYSCORE")
@@ -785,6 +790,49 @@ var (
 	cmdXReadGroup          = newProtocolCommand("XREADGROUP")
 	cmdXPending            = newProtocolCommand("XPENDING")
 	cmdXClaim              = newProtocolCommand("XCLAIM")
+	cmdXInfo               = newProtocolCommand("XINFO")
+	cmdXAutoClaim          = newProtocolCommand("XAUTOCLAIM")
+	cmdZPopMin             = newProtocolCommand("ZPOPMIN")
+	cmdZPopMax             = newProtocolCommand("ZPOPMAX")
+	cmdBZPopMin            = newProtocolCommand("BZPOPMIN")
+	cmdBZPopMax            = newProtocolCommand("BZPOPMAX")
+	cmdZMPop               = newProtocolCommand("ZMPOP")
+	cmdBZMPop              = newProtocolCommand("BZMPOP")
+	cmdZRandMember         = newProtocolCommand("ZRANDMEMBER")
+	cmdZMScore             = newProtocolCommand("ZMSCORE")
+	cmdZRangeStore         = newProtocolCommand("ZRANGESTORE")
+	cmdZDiff               = newProtocolCommand("ZDIFF")
+	cmdZDiffStore          = newProtocolCommand("ZDIFFSTORE")
+	cmdZInter              = newProtocolCommand("ZINTER")
+	cmdZUnion              = newProtocolCommand("ZUNION")
+	cmdGetDel              = newProtocolCommand("GETDEL")
+	cmdGetEx               = newProtocolCommand("GETEX")
+	cmdLcs                 = newProtocolCommand("LCS")
+	cmdLPos                = newProtocolCommand("LPOS")
+	cmdLMove               = newProtocolCommand("LMOVE")
+	cmdBLMove              = newProtocolCommand("BLMOVE")
+	cmdLMPop               = newProtocolCommand("LMPOP")
+	cmdBLMPop              = newProtocolCommand("BLMPOP")
+	cmdHRandField          = newProtocolCommand("HRANDFIELD")
+	cmdHExpire             = newProtocolCommand("HEXPIRE")
+	cmdHPExpire            = newProtocolCommand("HPEXPIRE")
+	cmdHExpireAt           = newProtocolCommand("HEXPIREAT")
+	cmdHPExpireAt          = newProtocolCommand("HPEXPIREAT")
+	cmdHTTL                = newProtocolCommand("HTTL")
+	cmdHPTTL               = newProtocolCommand("HPTTL")
+	cmdHPersist            = newProtocolCommand("HPERSIST")
+	cmdHGetDel             = newProtocolCommand("HGETDEL")
+	cmdHGetEx              = newProtocolCommand("HGETEX")
+	cmdHSetEx              = newProtocolCommand("HSETEX")
+	cmdGeoSearch           = newProtocolCommand("GEOSEARCH")
+	cmdGeoSearchStore      = newProtocolCommand("GEOSEARCHSTORE")
+	cmdCopy                = newProtocolCommand("COPY")
+	cmdExpireTime          = newProtocolCommand("EXPIRETIME")
+	cmdPExpireTime         = newProtocolCommand("PEXPIRETIME")
+	cmdFunction            = newProtocolCommand("FUNCTION")
+	cmdFCall               = newProtocolCommand("FCALL")
+	cmdFCallRo             = newProtocolCommand("FCALL_RO")
+	cmdACL                 = newProtocolCommand("ACL")
 )
 
 // redis keyword
@@ -822,6 +870,9 @@ var (
 	keywordStore        = newKeyword("STORE")
 	keywordSubscribe    = newKeyword("SUBSCRIBE")
 	keywordUnsubscribe  = newKeyword("UNSUBSCRIBE")
+	keywordSSubscribe   = newKeyword("SSUBSCRIBE")
+	keywordSUnsubscribe = newKeyword("SUNSUBSCRIBE")
+	keywordSMessage     = newKeyword("SMESSAGE")
 	keywordWeights      = newKeyword("WEIGHTS")
 	keywordWithScores   = newKeyword("WITHSCORES")
 	keywordResetStat    = newKeyword("RESETSTAT")
@@ -845,6 +896,9 @@ var (
 	keywordUnload       = newKeyword("UNLOAD")
 	keywordReplace      = newKeyword("REPLACE")
 	keywordKeys         = newKeyword("KEYS")
+	keywordCopy         = newKeyword("COPY")
+	keywordAuth         = newKeyword("AUTH")
+	keywordAuth2        = newKeyword("AUTH2")
 	keywordPause        = newKeyword("PAUSE")
 	keywordDoctor       = newKeyword("DOCTOR")
 	keywordBlock        = newKeyword("BLOCK")
@@ -862,4 +916,57 @@ var (
 	keywordTime         = newKeyword("TIME")
 	keywordRetryCount   = newKeyword("RETRYCOUNT")
 	keywordForce        = newKeyword("FORCE")
+	keywordType         = newKeyword("TYPE")
+	keywordMinID        = newKeyword("MINID")
+	keywordNoMkStream   = newKeyword("NOMKSTREAM")
+	keywordStream       = newKeyword("STREAM")
+
+	keywordCreateConsumer = newKeyword("CREATECONSUMER")
+	keywordByScore        = newKeyword("BYSCORE")
+	keywordByLex          = newKeyword("BYLEX")
+	keywordRev            = newKeyword("REV")
+	keywordNx             = newKeyword("NX")
+	keywordXx             = newKeyword("XX")
+	keywordEx             = newKeyword("EX")
+	keywordPx             = newKeyword("PX")
+	keywordExAt           = newKeyword("EXAT")
+	keywordPxAt           = newKeyword("PXAT")
+	keywordKeepTTL        = newKeyword("KEEPTTL")
+	keywordPersist        = newKeyword("PERSIST")
+	keywordIdx            = newKeyword("IDX")
+	keywordMinMatchLen    = newKeyword("MINMATCHLEN")
+	keywordWithMatchLen   = newKeyword("WITHMATCHLEN")
+	keywordRank           = newKeyword("RANK")
+	keywordFields         = newKeyword("FIELDS")
+	keywordWithValues     = newKeyword("WITHVALUES")
+	keywordFnx            = newKeyword("FNX")
+	keywordFxx            = newKeyword("FXX")
+	keywordFromMember     = newKeyword("FROMMEMBER")
+	keywordFromLonLat     = newKeyword("FROMLONLAT")
+	keywordByRadius       = newKeyword("BYRADIUS")
+	keywordByBox          = newKeyword("BYBOX")
+	keywordAny            = newKeyword("ANY")
+	keywordWithCoord      = newKeyword("WITHCOORD")
+	keywordWithDist       = newKeyword("WITHDIST")
+	keywordWithHash       = newKeyword("WITHHASH")
+	keywordStoreDist      = newKeyword("STOREDIST")
+	keywordDB             = newKeyword("DB")
+	keywordAbsTTL         = newKeyword("ABSTTL")
+	keywordFreq           = newKeyword("FREQ")
+	keywordDelete         = newKeyword("DELETE")
+	keywordDump           = newKeyword("DUMP")
+	keywordRestore        = newKeyword("RESTORE")
+	keywordStats          = newKeyword("STATS")
+	keywordLibraryName    = newKeyword("LIBRARYNAME")
+	keywordWithCode       = newKeyword("WITHCODE")
+	keywordSetUser        = newKeyword("SETUSER")
+	keywordGetUser        = newKeyword("GETUSER")
+	keywordDelUser        = newKeyword("DELUSER")
+	keywordUsers          = newKeyword("USERS")
+	keywordWhoAmI         = newKeyword("WHOAMI")
+	keywordCat            = newKeyword("CAT")
+	keywordLog            = newKeyword("LOG")
+	keywordGenPass        = newKeyword("GENPASS")
+	keywordDryRun         = newKeyword("DRYRUN")
+	keywordSave           = newKeyword("SAVE")
 )
@@ -0,0 +1,427 @@
+package godis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	pubSubRetryBackoff    = 100 * time.Millisecond
+	pubSubMaxRetryBackoff = 5 * time.Second
+)
+
+//Message message received by PubSub
+type Message struct {
+	Channel string //channel the message is published to
+	Pattern string //pattern matched by the channel, empty if the message is received by a channel subscription
+	Payload string
+}
+
+//PubSubOverflow policy of PubSub when the buffer of the message channel is full
+type PubSubOverflow struct {
+	name string
+}
+
+func newPubSubOverflow(name string) *PubSubOverflow {
+	return &PubSubOverflow{name}
+}
+
+var (
+	//PubSubOverflowBlock stop reading the connection until the consumer catches up,
+	// the pending messages are buffered by the socket and the output buffer of the server
+	PubSubOverflowBlock = newPubSubOverflow("BLOCK")
+	//PubSubOverflowDropNewest drop the received message
+	PubSubOverflowDropNewest = newPubSubOverflow("DROP_NEWEST")
+	//PubSubOverflowDropOldest drop the oldest message in the buffer to make room for the received message
+	PubSubOverflowDropOldest = newPubSubOverflow("DROP_OLDEST")
+)
+
+//PubSubOption option of PubSub
+type PubSubOption struct {
+	BufferSize int                    //buffer size of the message channel, default is 100
+	Overflow   *PubSubOverflow        //policy when the buffer is full, default is PubSubOverflowBlock
+	OnDrop     func(message *Message) //called when a message is dropped by the overflow policy
+
+	Reconnect           bool            //reconnect with backoff and resubscribe when the connection is broken
+	MaxReconnectRetries int             //max retries of one reconnection, 0 means retry until success or Close
+	OnReconnect         func(err error) //the broken connection is reconnected and resubscribed, messages may be missed meanwhile
+
+	HealthCheckInterval time.Duration //send PING in the interval, the connection is broken if the pong isn't received in time, 0 disables the health check
+	HealthCheckTimeout  time.Duration //max wait time of the pong, default is the read timeout of the redis
+}
+
+//PubSub subscriber delivering the messages to a go channel, the channels and patterns can be subscribed and unsubscribed at runtime
+type PubSub struct {
+	redis  *Redis
+	option *PubSubOption
+
+	mu       sync.Mutex
+	channels map[string]bool
+	patterns map[string]bool
+	started  bool
+	closed   bool
+	err      error
+	pinging  bool //a PING is waiting for the pong
+
+	messages chan *Message
+	done     chan struct{}
+}
+
+//NewPubSub create PubSub on the redis, the redis is dedicated to the PubSub and is closed by PubSub.Close
+func (r *Redis) NewPubSub(option *PubSubOption) *PubSub {
+	if option == nil {
+		option = &PubSubOption{}
+	}
+	if option.BufferSize <= 0 {
+		option.BufferSize = 100
+	}
+	if option.Overflow == nil {
+		option.Overflow = PubSubOverflowBlock
+	}
+	return &PubSub{
+		redis:    r,
+		option:   option,
+		channels: make(map[string]bool),
+		patterns: make(map[string]bool),
+		messages: make(chan *Message, option.BufferSize),
+		done:     make(chan struct{}),
+	}
+}
+
+//NewPubSub create PubSub with a dedicated connection, the connection doesn't count against the pool
+func (p *Pool) NewPubSub(option *PubSubOption) *PubSub {
+	return NewRedis(p.option).NewPubSub(option)
+}
+
+//Channel return the channel of the received messages, it's closed after the PubSub is closed
+func (p *PubSub) Channel() <-chan *Message {
+	return p.messages
+}
+
+//Receive wait for the next message until ctx is done, it shares the messages with Channel
+func (p *PubSub) Receive(ctx context.Context) (*Message, error) {
+	select {
+	case message, ok := <-p.messages:
+		if !ok {
+			return nil, p.closedError()
+		}
+		return message, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+//Subscribe subscribe some channels
+func (p *PubSub) Subscribe(channels ...string) error {
+	if len(channels) == 0 {
+		return newDataError("at least one channel is required")
+	}
+	return p.send(cmdSubscribe, channels, func() {
+		for _, channel := range channels {
+			p.channels[channel] = true
+		}
+	})
+}
+
+//Unsubscribe unsubscribe some channels, unsubscribe all the channels if channels is empty
+func (p *PubSub) Unsubscribe(channels ...string) error {
+	return p.send(cmdUnSubscribe, channels, func() {
+		if len(channels) == 0 {
+			p.channels = make(map[string]bool)
+		}
+		for _, channel := range channels {
+			delete(p.channels, channel)
+		}
+	})
+}
+
+//PSubscribe subscribe some patterns
+func (p *PubSub) PSubscribe(patterns ...string) error {
+	if len(patterns) == 0 {
+		return newDataError("at least one pattern is required")
+	}
+	return p.send(cmdPSubscribe, patterns, func() {
+		for _, pattern := range patterns {
+			p.patterns[pattern] = true
+		}
+	})
+}
+
+//PUnsubscribe unsubscribe some patterns, unsubscribe all the patterns if patterns is empty
+func (p *PubSub) PUnsubscribe(patterns ...string) error {
+	return p.send(cmdPUnSubscribe, patterns, func() {
+		if len(patterns) == 0 {
+			p.patterns = make(map[string]bool)
+		}
+		for _, pattern := range patterns {
+			delete(p.patterns, pattern)
+		}
+	})
+}
+
+//Err return the error which closed the PubSub, nil if it's closed by Close or still running
+func (p *PubSub) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+//Close close the connection, Receive returns and the message channel is closed after the pending messages
+func (p *PubSub) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.done)
+	started := p.started
+	p.mu.Unlock()
+	if !started {
+		close(p.messages)
+		return nil
+	}
+	return p.redis.client.close()
+}
+
+//send the command to the connection, the connection is set up and the receiving goroutine is started on the first call
+func (p *PubSub) send(cmd protocolCommand, args []string, track func()) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return p.closedErrorLocked()
+	}
+	if !p.started {
+		if err := p.redis.Connect(); err != nil {
+			return err
+		}
+		if err := p.redis.client.connection.setTimeoutInfinite(); err != nil {
+			return err
+		}
+		p.started = true
+		go p.receive()
+		if p.option.HealthCheckInterval > 0 {
+			go p.healthCheck()
+		}
+	}
+	err := p.redis.client.sendCommand(cmd, StrArrToByteArrArr(args)...)
+	if err != nil {
+		return err
+	}
+	err = p.redis.client.flush()
+	if err != nil {
+		return err
+	}
+	track()
+	return nil
+}
+
+func (p *PubSub) receive() {
+	defer close(p.messages)
+	for {
+		reply, err := p.redis.client.connection.getRawObjectMultiBulkReply()
+		if err != nil {
+			if _, ok := err.(*ConnectError); ok && p.option.Reconnect && p.reconnect(err) {
+				continue
+			}
+			p.fail(err)
+			return
+		}
+		if len(reply) < 2 {
+			p.fail(fmt.Errorf("unknown message type: %v", reply))
+			return
+		}
+		switch strings.ToUpper(pubSubReplyString(reply[0])) {
+		case keywordPong.name:
+			p.pong()
+		case keywordMessage.name:
+			if len(reply) < 3 {
+				p.fail(fmt.Errorf("malformed message: %v", reply))
+				return
+			}
+			p.deliver(&Message{Channel: pubSubReplyString(reply[1]), Payload: pubSubReplyString(reply[2])})
+		case keywordPMessage.name:
+			if len(reply) < 4 {
+				p.fail(fmt.Errorf("malformed message: %v", reply))
+				return
+			}
+			p.deliver(&Message{Pattern: pubSubReplyString(reply[1]), Channel: pubSubReplyString(reply[2]), Payload: pubSubReplyString(reply[3])})
+		}
+	}
+}
+
+//fail close the PubSub because of the error, the error is ignored if the PubSub is closed by Close
+func (p *PubSub) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	p.err = err
+	close(p.done)
+	_ = p.redis.client.close()
+}
+
+//healthCheck send PING periodically until the PubSub is closed
+func (p *PubSub) healthCheck() {
+	ticker := time.NewTicker(p.option.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.ping()
+		}
+	}
+}
+
+//ping send PING with a read deadline, the receiving goroutine fails on the deadline if the pong is missed
+func (p *PubSub) ping() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	connection := p.redis.client.connection
+	//PING is replied by a status reply without subscriptions, which can't be read by the receiving goroutine
+	if p.closed || p.pinging || !connection.isConnected() || len(p.channels)+len(p.patterns) == 0 {
+		return
+	}
+	timeout := p.option.HealthCheckTimeout
+	if timeout <= 0 {
+		timeout = connection.soTimeout
+	}
+	//the deadline is set before sending, so it can't override the clearing by a fast pong
+	err := connection.setReadDeadline(time.Now().Add(timeout))
+	if err == nil {
+		err = p.redis.client.ping()
+	}
+	if err == nil {
+		err = p.redis.client.flush()
+	}
+	if err != nil {
+		//unblock the receiving goroutine, it reconnects or fails
+		_ = p.redis.client.close()
+		return
+	}
+	p.pinging = true
+}
+
+//pong clear the read deadline set by ping
+func (p *PubSub) pong() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pinging = false
+	if p.redis.client.connection.isConnected() {
+		_ = p.redis.client.connection.setReadDeadline(time.Time{})
+	}
+}
+
+//reconnect retry with backoff until the subscriptions are restored,
+// false is returned if the PubSub is closed or MaxReconnectRetries is reached
+func (p *PubSub) reconnect(cause error) bool {
+	err := cause
+	for retries := 0; p.option.MaxReconnectRetries <= 0 || retries < p.option.MaxReconnectRetries; retries++ {
+		select {
+		case <-p.done:
+			return false
+		case <-time.After(pubSubBackoff(retries)):
+		}
+		if err = p.resubscribe(); err == nil {
+			if p.option.OnReconnect != nil {
+				p.option.OnReconnect(cause)
+			}
+			return true
+		}
+	}
+	p.fail(err)
+	return false
+}
+
+func (p *PubSub) resubscribe() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return p.closedErrorLocked()
+	}
+	p.pinging = false
+	client := p.redis.client
+	_ = client.close()
+	client.resetPipelinedCount()
+	if err := client.connect(); err != nil {
+		return err
+	}
+	if err := client.connection.setTimeoutInfinite(); err != nil {
+		return err
+	}
+	if len(p.channels) > 0 {
+		if err := client.sendCommand(cmdSubscribe, StrArrToByteArrArr(subscriptionNames(p.channels))...); err != nil {
+			return err
+		}
+	}
+	if len(p.patterns) > 0 {
+		if err := client.sendCommand(cmdPSubscribe, StrArrToByteArrArr(subscriptionNames(p.patterns))...); err != nil {
+			return err
+		}
+	}
+	return client.flush()
+}
+
+func (p *PubSub) deliver(message *Message) {
+	switch p.option.Overflow {
+	case PubSubOverflowDropNewest:
+		select {
+		case p.messages <- message:
+		default:
+			p.drop(message)
+		}
+	case PubSubOverflowDropOldest:
+		for {
+			select {
+			case p.messages <- message:
+				return
+			default:
+			}
+			select {
+			case oldest := <-p.messages:
+				p.drop(oldest)
+			default:
+			}
+		}
+	default:
+		select {
+		case p.messages <- message:
+		case <-p.done:
+		}
+	}
+}
+
+func (p *PubSub) drop(message *Message) {
+	if p.option.OnDrop != nil {
+		p.option.OnDrop(message)
+	}
+}
+
+func (p *PubSub) closedError() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closedErrorLocked()
+}
+
+func (p *PubSub) closedErrorLocked() error {
+	if p.err != nil {
+		return p.err
+	}
+	return newConnectError("pubsub is closed")
+}
+
+//pubSubBackoff sleep time before the retry of reconnection, it doubles on every retry and is limited to pubSubMaxRetryBackoff
+func pubSubBackoff(retries int) time.Duration {
+	sleep := pubSubRetryBackoff << uint(retries)
+	if sleep <= 0 || sleep > pubSubMaxRetryBackoff {
+		sleep = pubSubMaxRetryBackoff
+	}
+	return sleep
+}
@@ -0,0 +1,236 @@
+package godis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestPubSub_overflow(t *testing.T) {
+	dropped := make([]string, 0)
+	onDrop := func(message *Message) {
+		dropped = append(dropped, message.Payload)
+	}
+	pubSub := NewRedis(option).NewPubSub(&PubSubOption{BufferSize: 2, Overflow: PubSubOverflowDropNewest, OnDrop: onDrop})
+	for _, payload := range []string{"1", "2", "3"} {
+		pubSub.deliver(&Message{Channel: "godis", Payload: payload})
+	}
+	assert.Equal(t, []string{"3"}, dropped)
+	assert.Equal(t, "1", (<-pubSub.Channel()).Payload)
+
+	dropped = dropped[:0]
+	pubSub = NewRedis(option).NewPubSub(&PubSubOption{BufferSize: 2, Overflow: PubSubOverflowDropOldest, OnDrop: onDrop})
+	for _, payload := range []string{"1", "2", "3"} {
+		pubSub.deliver(&Message{Channel: "godis", Payload: payload})
+	}
+	assert.Equal(t, []string{"1"}, dropped)
+	assert.Equal(t, "2", (<-pubSub.Channel()).Payload)
+	assert.Equal(t, "3", (<-pubSub.Channel()).Payload)
+
+	pubSub = NewRedis(option).NewPubSub(&PubSubOption{BufferSize: 1})
+	//the messages are delivered by the receiving goroutine only after the PubSub is started
+	pubSub.started = true
+	pubSub.deliver(&Message{Channel: "godis", Payload: "1"})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = pubSub.Close()
+	}()
+	//blocked until the PubSub is closed
+	pubSub.deliver(&Message{Channel: "godis", Payload: "2"})
+}
+
+func TestPubSub_Close(t *testing.T) {
+	pubSub := NewRedis(option).NewPubSub(nil)
+	assert.Nil(t, pubSub.Close())
+	assert.Nil(t, pubSub.Close())
+	_, ok := <-pubSub.Channel()
+	assert.False(t, ok)
+	_, err := pubSub.Receive(context.Background())
+	assert.NotNil(t, err)
+	assert.NotNil(t, pubSub.Subscribe("godis"))
+
+	pubSub = NewRedis(option).NewPubSub(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = pubSub.Receive(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestPubSub_Receive(t *testing.T) {
+	pubSub := NewRedis(option).NewPubSub(nil)
+	defer pubSub.Close()
+	assert.Nil(t, pubSub.Subscribe("godis"))
+	assert.Nil(t, pubSub.PSubscribe("godis.*"))
+	time.Sleep(100 * time.Millisecond)
+
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.Publish("godis", "good")
+	assert.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	message, err := pubSub.Receive(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, &Message{Channel: "godis", Payload: "good"}, message)
+
+	_, err = redis.Publish("godis.news", "bad")
+	assert.Nil(t, err)
+	message, err = pubSub.Receive(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, &Message{Pattern: "godis.*", Channel: "godis.news", Payload: "bad"}, message)
+
+	assert.Nil(t, pubSub.Unsubscribe("godis"))
+	assert.Nil(t, pubSub.Close())
+	_, ok := <-pubSub.Channel()
+	assert.False(t, ok)
+	assert.Nil(t, pubSub.Err())
+}
+
+func writeFakePush(conn net.Conn, kind, channel string, payload string) {
+	_, _ = fmt.Fprintf(conn, "*3\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n",
+		len(kind), kind, len(channel), channel, len(payload), payload)
+}
+
+func writeFakeSubscribed(conn net.Conn, kind, channel string, count int) {
+	_, _ = fmt.Fprintf(conn, "*3\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n:%d\r\n", len(kind), kind, len(channel), channel, count)
+}
+
+func TestPubSub_reconnect(t *testing.T) {
+	commands := make(chan []string, 10)
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		command := readFakeCommand(reader)
+		commands <- command
+		writeFakeSubscribed(conn, "subscribe", command[1], 1)
+		if index == 0 {
+			//drop the first connection
+			_ = conn.Close()
+			return
+		}
+		writeFakePush(conn, "message", "godis", "after reconnect")
+	})
+	reconnected := make(chan error, 1)
+	pubSub := NewRedis(serverOption).NewPubSub(&PubSubOption{
+		Reconnect: true,
+		OnReconnect: func(err error) {
+			reconnected <- err
+		},
+	})
+	defer pubSub.Close()
+	assert.Nil(t, pubSub.Subscribe("godis"))
+
+	assert.Equal(t, []string{"SUBSCRIBE", "godis"}, <-commands)
+	assert.Equal(t, []string{"SUBSCRIBE", "godis"}, <-commands)
+	assert.NotNil(t, <-reconnected)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	message, err := pubSub.Receive(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "after reconnect", message.Payload)
+}
+
+func TestRedisPubSub_reconnect(t *testing.T) {
+	commands := make(chan []string, 10)
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		command := readFakeCommand(reader)
+		commands <- command
+		writeFakeSubscribed(conn, "psubscribe", command[1], 1)
+		if index == 0 {
+			_ = conn.Close()
+			return
+		}
+		writeFakeSubscribed(conn, "punsubscribe", command[1], 0)
+	})
+	reconnects := 0
+	pubSub := &RedisPubSub{
+		Reconnect:      true,
+		OnReconnect:    func(err error) { reconnects++ },
+		OnPSubscribe:   func(pattern string, subscribedChannels int) {},
+		OnPUnSubscribe: func(pattern string, subscribedChannels int) {},
+	}
+	redis := NewRedis(serverOption)
+	assert.Nil(t, redis.Connect())
+	//the pattern is unsubscribed by the fake server after reconnecting, then PSubscribe returns
+	assert.Nil(t, redis.PSubscribe(pubSub, "godis*"))
+	assert.Equal(t, 1, reconnects)
+	assert.Equal(t, []string{"PSUBSCRIBE", "godis*"}, <-commands)
+	assert.Equal(t, []string{"PSUBSCRIBE", "godis*"}, <-commands)
+}
+
+func writeFakePong(conn net.Conn) {
+	_, _ = fmt.Fprint(conn, "*2\r\n$4\r\npong\r\n$0\r\n\r\n")
+}
+
+func TestPubSub_healthCheck(t *testing.T) {
+	commands := make(chan []string, 10)
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		command := readFakeCommand(reader)
+		commands <- command
+		writeFakeSubscribed(conn, "subscribe", command[1], 1)
+		commands <- readFakeCommand(reader)
+		if index == 0 {
+			//the first connection is half-open, the PING is never answered
+			return
+		}
+		writeFakePong(conn)
+		writeFakePush(conn, "message", "godis", "after pong")
+	})
+	reconnected := make(chan error, 1)
+	pubSub := NewRedis(serverOption).NewPubSub(&PubSubOption{
+		Reconnect: true,
+		OnReconnect: func(err error) {
+			reconnected <- err
+		},
+		HealthCheckInterval: 50 * time.Millisecond,
+		HealthCheckTimeout:  100 * time.Millisecond,
+	})
+	defer pubSub.Close()
+	assert.Nil(t, pubSub.Subscribe("godis"))
+
+	assert.Equal(t, []string{"SUBSCRIBE", "godis"}, <-commands)
+	assert.Equal(t, []string{"PING"}, <-commands)
+	select {
+	case err := <-reconnected:
+		assert.NotNil(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("missed pong doesn't reconnect")
+	}
+	assert.Equal(t, []string{"SUBSCRIBE", "godis"}, <-commands)
+	assert.Equal(t, []string{"PING"}, <-commands)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	message, err := pubSub.Receive(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "after pong", message.Payload)
+}
+
+func TestRedisPubSub_healthCheck(t *testing.T) {
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		command := readFakeCommand(reader)
+		writeFakeSubscribed(conn, "psubscribe", command[1], 1)
+		readFakeCommand(reader)
+		if index == 0 {
+			return
+		}
+		writeFakePong(conn)
+		writeFakeSubscribed(conn, "punsubscribe", command[1], 0)
+	})
+	reconnects, pongs := 0, 0
+	pubSub := &RedisPubSub{
+		Reconnect:           true,
+		OnReconnect:         func(err error) { reconnects++ },
+		OnPong:              func(channel string) { pongs++ },
+		OnPSubscribe:        func(pattern string, subscribedChannels int) {},
+		OnPUnSubscribe:      func(pattern string, subscribedChannels int) {},
+		HealthCheckInterval: 50 * time.Millisecond,
+		HealthCheckTimeout:  100 * time.Millisecond,
+	}
+	redis := NewRedis(serverOption)
+	assert.Nil(t, redis.Connect())
+	assert.Nil(t, redis.PSubscribe(pubSub, "godis*"))
+	assert.Equal(t, 1, reconnects)
+	assert.Equal(t, 1, pongs)
+}
@@ -13,6 +13,7 @@ type Option struct {
 	SoTimeout         time.Duration // read timeout
 	Password          string        // redis password,if empty,then without auth
 	Db                int           // which db to connect
+	readOnly          bool          // send READONLY after connected, for the connections to the replicas of cluster
 }
 
 // Redis redis client tool
@@ -111,6 +112,8 @@ func (r *Redis) Set(key, value string) (string, error) {
 // param expx EX|PX, expire time units: EX = seconds; PX = milliseconds
 // param time expire time in the units of <code>expx</code>
 //return Status code reply
+//
+//Deprecated: use SetWithArgs, NewSetArgs().NX().EX(expiration) replaces the strings of nxxx and expx
 func (r *Redis) SetWithParamsAndTime(key, value, nxxx, expx string, time int64) (string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
@@ -560,6 +563,8 @@ func (r *Redis) HSetNx(key, field, value string) (int64, error) {
 //If key does not exist, a new key holding a hash is created.
 //
 //return Return OK or Exception if hash is empty
+//
+//Deprecated: use HSetByMap
 func (r *Redis) HMSet(key string, hash map[string]string) (string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
@@ -1280,17 +1285,24 @@ func (r *Redis) ZRem(key string, members ...string) (int64, error) {
 //
 //For an introduction to sorted sets check the Introduction to Redis data types page.
 //
+//With params it's sent as ZADD with INCR, so the increment is applied with the conditions of the params.
+//
 //return The new score
 func (r *Redis) ZIncrBy(key string, increment float64, member string, params ...*ZAddParams) (float64, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
 		return 0, err
 	}
-	err = r.client.zIncrBy(key, increment, member)
+	err = r.client.zIncrBy(key, increment, member, params...)
 	if err != nil {
 		return 0, err
 	}
-	return StrToFloat64Reply(r.client.getBulkReply())
+	reply, err := r.client.getBulkReply()
+	if err == nil && reply == "" {
+		//ZADD with INCR replies nil when the update is aborted by the NX, XX, GT or LT of the params
+		return 0, newDataError("the score is not updated because of the ZAddParams conditions")
+	}
+	return StrToFloat64Reply(reply, err)
 }
 
 //ZRank Return the rank (or index) or member in the sorted set at key, with scores being ordered from
@@ -1663,6 +1675,8 @@ func (r *Redis) Echo(string string) (string, error) {
 }
 
 //SetWithParams see SetWithParamsAndTime(key, value, nxxx, expx string, time int64)
+//
+//Deprecated: use SetWithArgs with NewSetArgs().NX() or NewSetArgs().XX()
 func (r *Redis) SetWithParams(key, value, nxxx string) (string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
@@ -1836,6 +1850,8 @@ func (r *Redis) ZRevRangeWithScores(key string, start, end int64) ([]Tuple, erro
 }
 
 //ZRangeByScoreBatch see ZRange()
+//
+//Deprecated: use ZRangeByParams with NewZRangeByScoreParams(min, max).Limit(offset, count)
 func (r *Redis) ZRangeByScoreBatch(key string, min, max float64, offset, count int) ([]string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
@@ -1849,6 +1865,8 @@ func (r *Redis) ZRangeByScoreBatch(key string, min, max float64, offset, count i
 }
 
 //ZRangeByScoreWithScoresBatch see ZRange()
+//
+//Deprecated: use ZRangeWithScoresByParams with NewZRangeByScoreParams(min, max).Limit(offset, count)
 func (r *Redis) ZRangeByScoreWithScoresBatch(key string, min, max float64, offset, count int) ([]Tuple, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
@@ -1862,6 +1880,8 @@ func (r *Redis) ZRangeByScoreWithScoresBatch(key string, min, max float64, offse
 }
 
 //ZRevRangeByScoreWithScoresBatch see ZRevRange()
+//
+//Deprecated: use ZRangeWithScoresByParams with NewZRangeByScoreParams(max, min).Rev().Limit(offset, count)
 func (r *Redis) ZRevRangeByScoreWithScoresBatch(key string, max, min float64, offset, count int) ([]Tuple, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
@@ -1941,6 +1961,8 @@ func (r *Redis) ZRangeByLex(key, min, max string) ([]string, error) {
 }
 
 //ZRangeByLexBatch see ZRangeByLex()
+//
+//Deprecated: use ZRangeByParams with NewZRangeByLexParams(min, max).Limit(offset, count)
 func (r *Redis) ZRangeByLexBatch(key, min, max string, offset, count int) ([]string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
@@ -1974,6 +1996,8 @@ func (r *Redis) ZRevRangeByLex(key, max, min string) ([]string, error) {
 }
 
 //ZRevRangeByLexBatch see ZRevRangeByLex()
+//
+//Deprecated: use ZRangeByParams with NewZRangeByLexParams(max, min).Rev().Limit(offset, count)
 func (r *Redis) ZRevRangeByLexBatch(key, max, min string, offset, count int) ([]string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
@@ -2214,12 +2238,14 @@ func (r *Redis) GeoPos(key string, members ...string) ([]*GeoCoordinate, error)
 }
 
 //GeoRadius get members in certain range
+//
+//Deprecated: use GeoSearch with NewGeoSearchQuery().FromLonLat(longitude, latitude).ByRadius(radius, unit)
 func (r *Redis) GeoRadius(key string, longitude, latitude, radius float64, unit *GeoUnit, param ...*GeoRadiusParams) ([]GeoRadiusResponse, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
 		return nil, err
 	}
-	err = r.client.georadius(key, longitude, latitude, radius, unit, param...)
+	err = r.client.georadius(cmdGeoRadius, key, longitude, latitude, radius, unit, nil, param...)
 	if err != nil {
 		return nil, err
 	}
@@ -2227,12 +2253,14 @@ func (r *Redis) GeoRadius(key string, longitude, latitude, radius float64, unit
 }
 
 //GeoRadiusByMember get members in certain range
+//
+//Deprecated: use GeoSearch with NewGeoSearchQuery().FromMember(member).ByRadius(radius, unit)
 func (r *Redis) GeoRadiusByMember(key, member string, radius float64, unit *GeoUnit, param ...*GeoRadiusParams) ([]GeoRadiusResponse, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
 		return nil, err
 	}
-	err = r.client.georadiusByMember(key, member, radius, unit, param...)
+	err = r.client.georadiusByMember(cmdGeoRadiusByMember, key, member, radius, unit, nil, param...)
 	if err != nil {
 		return nil, err
 	}
@@ -2432,6 +2460,8 @@ func (r *Redis) MSetNx(kvs ...string) (int64, error) {
 //from the list and pusing it as first element of the list, so it's a "list rotation" command.
 //
 //return Bulk reply
+//
+//Deprecated: use LMove with ListDirectionRight and ListDirectionLeft
 func (r *Redis) RPopLPush(srcKey, destKey string) (string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
@@ -2694,6 +2724,8 @@ func (r *Redis) ZUnionStoreWithParams(destKey string, params *ZParams, srcKeys .
 }
 
 //BRPopLPush ...
+//
+//Deprecated: use BLMove with ListDirectionRight and ListDirectionLeft
 func (r *Redis) BRPopLPush(srcKey, destKey string, timeout int) (string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
@@ -2751,6 +2783,33 @@ func (r *Redis) PSubscribe(redisPubSub *RedisPubSub, patterns ...string) error {
 	return nil
 }
 
+//SPublish publish the message to the shard channel
+func (r *Redis) SPublish(channel, message string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.spublish(channel, message)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//SSubscribe subscribe shard channels, it blocks until all the shard channels are unsubscribed
+func (r *Redis) SSubscribe(redisPubSub *RedisPubSub, channels ...string) error {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return err
+	}
+	err = r.client.connection.setTimeoutInfinite()
+	defer r.client.connection.rollbackTimeout()
+	if err != nil {
+		return err
+	}
+	return redisPubSub.proceedWithShardChannels(r, channels...)
+}
+
 //RandomKey ...
 func (r *Redis) RandomKey() (string, error) {
 	err := r.checkIsInMultiOrPipeline()
@@ -3011,211 +3070,1832 @@ func (r *Redis) ScriptLoad(script string) (string, error) {
 	return r.client.getBulkReply()
 }
 
+//ScriptFlush Flush the Lua scripts cache.
+//Return value
+//Simple string reply
+func (r *Redis) ScriptFlush() (string, error) {
+	err := r.client.scriptFlush()
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
 //</editor-fold>
 
-//<editor-fold desc="basiccommands">
+//<editor-fold desc="functioncommands">
 
-// Quit Ask the server to close the connection.
-// The connection is closed as soon as all pending replies have been written to the client.
+//FunctionLoad load the library of the code (redis 7.0+), the code starts with a shebang like #!lua name=mylib
 //
-// Return value
-// Simple string reply: always OK.
-func (r *Redis) Quit() (string, error) {
+//return the name of the library, error if the library already exists
+func (r *Redis) FunctionLoad(code string) (string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
 		return "", err
 	}
-	err = r.client.quit()
+	err = r.client.functionLoad(code, false)
 	if err != nil {
 		return "", err
 	}
-	return r.client.getStatusCodeReply()
+	return r.client.getBulkReply()
 }
 
-//Ping send ping command to redis server
-// if the sever is running well,then it will return PONG.
-func (r *Redis) Ping() (string, error) {
+//FunctionLoadReplace load the library of the code and replace the existing one, see FunctionLoad()
+func (r *Redis) FunctionLoadReplace(code string) (string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
 		return "", err
 	}
-	err = r.client.ping()
+	err = r.client.functionLoad(code, true)
 	if err != nil {
 		return "", err
 	}
-	return r.client.getStatusCodeReply()
+	return r.client.getBulkReply()
 }
 
-//Select send select command to change current db,normally redis server has 16 db [0,15]
-func (r *Redis) Select(index int) (string, error) {
+//FunctionDelete delete the library and all its functions (redis 7.0+)
+//
+//return OK, error if the library doesn't exist
+func (r *Redis) FunctionDelete(libraryName string) (string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
 		return "", err
 	}
-	err = r.client.selectDb(index)
+	err = r.client.functionDelete(libraryName)
 	if err != nil {
 		return "", err
 	}
 	return r.client.getStatusCodeReply()
 }
 
-//FlushDB it will clear whole keys in current db
-func (r *Redis) FlushDB() (string, error) {
+//FunctionFlush delete all the libraries (redis 7.0+), mode can be nil to use the lazyfree-lazy-user-flush config
+func (r *Redis) FunctionFlush(mode *FlushMode) (string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
 		return "", err
 	}
-	err = r.client.flushDB()
+	err = r.client.functionFlush(mode)
 	if err != nil {
 		return "", err
 	}
 	return r.client.getStatusCodeReply()
 }
 
-//DbSize return key count of current db
-func (r *Redis) DbSize() (int64, error) {
+//FunctionList return the libraries whose names match the pattern (redis 7.0+),
+// empty pattern returns all the libraries, withCode returns the code of the libraries too
+func (r *Redis) FunctionList(libraryNamePattern string, withCode bool) ([]*FunctionLibrary, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	err = r.client.dbSize()
+	err = r.client.functionList(libraryNamePattern, withCode)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return r.client.getIntegerReply()
+	return ObjArrToFunctionLibraryArrReply(r.client.getObjectMultiBulkReply())
 }
 
-//FlushAll it will clear whole keys in whole db
-func (r *Redis) FlushAll() (string, error) {
+//FunctionDump serialize all the libraries, they can be restored by FunctionRestore (redis 7.0+)
+func (r *Redis) FunctionDump() ([]byte, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.functionDump()
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getBinaryBulkReply()
+}
+
+//FunctionRestore restore the libraries serialized by FunctionDump (redis 7.0+), nil policy is FunctionRestoreAppend
+func (r *Redis) FunctionRestore(serializedValue []byte, policy *FunctionRestorePolicy) (string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
 		return "", err
 	}
-	err = r.client.flushAll()
+	err = r.client.functionRestore(serializedValue, policy)
 	if err != nil {
 		return "", err
 	}
 	return r.client.getStatusCodeReply()
 }
 
-//Auth when server is set password,then you need to auth password.
-func (r *Redis) Auth(password string) (string, error) {
+//FunctionKill kill the running function which has not written yet (redis 7.0+)
+func (r *Redis) FunctionKill() (string, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
 		return "", err
 	}
-	err = r.client.auth(password)
+	err = r.client.functionKill()
 	if err != nil {
 		return "", err
 	}
 	return r.client.getStatusCodeReply()
 }
 
-//Save ...
-func (r *Redis) Save() (string, error) {
-	err := r.client.save()
+//FunctionStats return the running function and the number of libraries and functions of every engine (redis 7.0+)
+func (r *Redis) FunctionStats() (*FunctionStats, error) {
+	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return r.client.getStatusCodeReply()
+	err = r.client.functionStats()
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToFunctionStatsReply(r.client.getObjectMultiBulkReply())
 }
 
-//BgSave ...
-func (r *Redis) BgSave() (string, error) {
-	err := r.client.bgsave()
+//FCall invoke the function with the keys and the args (redis 7.0+), the reply is converted like Eval
+func (r *Redis) FCall(function string, keys, args []string) (interface{}, error) {
+	err := r.client.connection.setTimeoutInfinite()
+	defer r.client.connection.rollbackTimeout()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return r.client.getStatusCodeReply()
+	err = r.client.fcall(cmdFCall, function, keys, args)
+	if err != nil {
+		return nil, err
+	}
+	return ObjToEvalResult(r.client.getOne())
 }
 
-//BgRewriteAof ...
-func (r *Redis) BgRewriteAof() (string, error) {
-	err := r.client.bgrewriteaof()
+//FCallRO invoke the read-only function which has the no-writes flag, it can be sent to replicas, see FCall()
+func (r *Redis) FCallRO(function string, keys, args []string) (interface{}, error) {
+	err := r.client.connection.setTimeoutInfinite()
+	defer r.client.connection.rollbackTimeout()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.fcall(cmdFCallRo, function, keys, args)
+	if err != nil {
+		return nil, err
+	}
+	return ObjToEvalResult(r.client.getOne())
+}
+
+//</editor-fold>
+
+//<editor-fold desc="streamcommands">
+
+//XAdd append an entry to the stream, the stream is created if it doesn't exist unless NoMkStream is set,
+// the stream is trimmed after adding when MaxLen or MinID is set.
+//
+//return the id of the added entry, empty string if the stream doesn't exist and NoMkStream is set
+func (r *Redis) XAdd(key string, args *XAddArgs) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
 		return "", err
 	}
-	return r.client.getStatusCodeReply()
+	err = r.client.xadd(key, args)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getBulkReply()
 }
 
-//LastSave ...
-func (r *Redis) LastSave() (int64, error) {
-	err := r.client.lastsave()
+//XLen return the number of entries in the stream, 0 if the stream doesn't exist
+func (r *Redis) XLen(key string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.xlen(key)
 	if err != nil {
 		return 0, err
 	}
 	return r.client.getIntegerReply()
 }
 
-//Shutdown ...
-func (r *Redis) Shutdown() (string, error) {
-	err := r.client.shutdown()
+//XDel remove the entries by ids from the stream
+//
+//return the number of entries deleted
+func (r *Redis) XDel(key string, ids ...string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
-		return "", err
+		return 0, err
 	}
-	return r.client.getStatusCodeReply()
+	err = r.client.xdel(key, ids...)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
 }
 
-//Info ...
-func (r *Redis) Info(section ...string) (string, error) {
-	err := r.client.info(section...)
+//XTrim trim the stream by MaxLen or MinID
+//
+//return the number of entries evicted
+func (r *Redis) XTrim(key string, args *XTrimArgs) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
-		return "", err
+		return 0, err
 	}
-	return r.client.getBulkReply()
+	err = r.client.xtrim(key, args)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
 }
 
-//SlaveOf ...
-func (r *Redis) SlaveOf(host string, port int) (string, error) {
-	err := r.client.slaveof(host, port)
+//XRange return the entries with ids between start and end, - and + mean the minimum and maximum ids,
+// an id prefixed by ( is exclusive. count limits the entries returned, 0 means no limit
+func (r *Redis) XRange(key, start, end string, count int64) ([]*StreamEntry, error) {
+	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return r.client.getStatusCodeReply()
+	err = r.client.xrange(key, start, end, count)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToStreamEntryArrReply(r.client.getObjectMultiBulkReply())
 }
 
-//SlaveOfNoOne ...
-func (r *Redis) SlaveOfNoOne() (string, error) {
-	err := r.client.slaveofNoOne()
+//XRevRange same as XRange but the entries are returned in reverse order, end is before start
+func (r *Redis) XRevRange(key, end, start string, count int64) ([]*StreamEntry, error) {
+	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return r.client.getStatusCodeReply()
+	err = r.client.xrevrange(key, end, start, count)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToStreamEntryArrReply(r.client.getObjectMultiBulkReply())
 }
 
-//Debug ...
-func (r *Redis) Debug(params DebugParams) (string, error) {
-	err := r.client.debug(params)
+//XRead read the entries after the ids from one or more streams, the socket timeout is extended by Block when blocking.
+//
+//return the streams which have entries, empty if Block is timed out
+func (r *Redis) XRead(args *XReadArgs) ([]*XStream, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	if args != nil && args.Block != 0 {
+		err = r.client.connection.setBlockingTimeout(args.Block)
+		defer r.client.connection.rollbackTimeout()
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = r.client.xread(args)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToXStreamArrReply(r.client.getObjectMultiBulkReply())
+}
+
+//XInfoStream return the information of the stream
+func (r *Redis) XInfoStream(key string) (*XInfoStream, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.xinfoStream(key)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToXInfoStreamReply(r.client.getObjectMultiBulkReply())
+}
+
+//XGroupCreate create the consumer group of the stream, the group starts reading after id, $ means the last entry.
+// the stream is created if it doesn't exist and mkStream is true
+func (r *Redis) XGroupCreate(key, group, id string, mkStream bool) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.xgroupCreate(key, group, id, mkStream)
 	if err != nil {
 		return "", err
 	}
 	return r.client.getStatusCodeReply()
 }
 
-//ConfigResetStat ...
-func (r *Redis) ConfigResetStat() (string, error) {
-	err := r.client.configResetStat()
+//XGroupDestroy destroy the consumer group, the pending entries of the group are removed too
+//
+//return the number of destroyed groups, 0 or 1
+func (r *Redis) XGroupDestroy(key, group string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.xgroupDestroy(key, group)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//XGroupSetID set the last delivered id of the consumer group
+func (r *Redis) XGroupSetID(key, group, id string) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.xgroupSetID(key, group, id)
 	if err != nil {
 		return "", err
 	}
 	return r.client.getStatusCodeReply()
 }
 
-//WaitReplicas Synchronous replication of Redis as described here: http://antirez.com/news/66 Since Java
-// Object class has implemented "wait" method, we cannot use it, so I had to change the name of
-// the method. Sorry :S
-func (r *Redis) WaitReplicas(replicas int, timeout int64) (int64, error) {
+//XGroupCreateConsumer create the consumer in the consumer group
+//
+//return the number of created consumers, 0 or 1
+func (r *Redis) XGroupCreateConsumer(key, group, consumer string) (int64, error) {
 	err := r.checkIsInMultiOrPipeline()
 	if err != nil {
 		return 0, err
 	}
-	err = r.client.waitReplicas(replicas, timeout)
+	err = r.client.xgroupCreateConsumer(key, group, consumer)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//XGroupDelConsumer delete the consumer from the consumer group, its pending entries are removed too
+//
+//return the number of pending entries the consumer had
+func (r *Redis) XGroupDelConsumer(key, group, consumer string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.xgroupDelConsumer(key, group, consumer)
 	if err != nil {
 		return 0, err
 	}
 	return r.client.getIntegerReply()
 }
 
+//XReadGroup read the entries of the streams as a consumer of the group, the socket timeout is extended by Block when blocking.
+//
+//return the streams which have entries, empty if Block is timed out
+func (r *Redis) XReadGroup(args *XReadGroupArgs) ([]*XStream, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	if args != nil && args.Block != 0 {
+		err = r.client.connection.setBlockingTimeout(args.Block)
+		defer r.client.connection.rollbackTimeout()
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = r.client.xreadGroup(args)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToXStreamArrReply(r.client.getObjectMultiBulkReply())
+}
+
+//XAck acknowledge the entries, they are removed from the pending entries of the consumer group
+//
+//return the number of acknowledged entries
+func (r *Redis) XAck(key, group string, ids ...string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.xack(key, group, ids...)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//XPending return the summary of the pending entries of the consumer group
+func (r *Redis) XPending(key, group string) (*XPendingSummary, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.xpending(key, group)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToXPendingSummaryReply(r.client.getObjectMultiBulkReply())
+}
+
+//XPendingRange return the pending entries of the consumer group with their delivery counts, args can be nil
+func (r *Redis) XPendingRange(key, group string, args *XPendingArgs) ([]*XPendingEntry, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.xpendingRange(key, group, args)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToXPendingEntryArrReply(r.client.getObjectMultiBulkReply())
+}
+
+//XClaim change the owner of the pending entries idle for at least MinIdle to the consumer
+//
+//return the claimed entries
+func (r *Redis) XClaim(key string, args *XClaimArgs) ([]*StreamEntry, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.xclaim(key, args)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToStreamEntryArrReply(r.client.getObjectMultiBulkReply())
+}
+
+//XAutoClaim scan the pending entries from Start, and claim the entries idle for at least MinIdle to the consumer
+func (r *Redis) XAutoClaim(key string, args *XAutoClaimArgs) (*XAutoClaimResult, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.xautoclaim(key, args)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToXAutoClaimResultReply(r.client.getObjectMultiBulkReply())
+}
+
+//</editor-fold>
+
+//<editor-fold desc="stringcommands">
+
+//SetWithArgs Set the string value as value of the key with the options of SetArgs.
+//
+//return OK if the key is set, empty string if it's not set because of NX or XX,
+// with GET the old string stored at key is returned instead, empty string if the key did not exist
+func (r *Redis) SetWithArgs(key, value string, args *SetArgs) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.setWithArgs(key, value, args)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getBulkReply()
+}
+
+//GetDel Get the value of key and delete the key (redis 6.2+).
+//
+//return the value of key, empty string when key does not exist
+func (r *Redis) GetDel(key string) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.getdel(key)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getBulkReply()
+}
+
+//GetEx Get the value of key and optionally set its expiration with the options of GetExArgs (redis 6.2+).
+//
+//return the value of key, empty string when key does not exist
+func (r *Redis) GetEx(key string, args *GetExArgs) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.getex(key, args)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getBulkReply()
+}
+
+//LCS return the longest common subsequence of the strings stored at key1 and key2 (redis 7.0+)
+func (r *Redis) LCS(key1, key2 string) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.lcs(key1, key2)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getBulkReply()
+}
+
+//LCSLen return the length of the longest common subsequence of the strings stored at key1 and key2 (redis 7.0+)
+func (r *Redis) LCSLen(key1, key2 string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.lcsLen(key1, key2)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//LCSIdx return the positions of the matches of the longest common subsequence of the strings stored at key1 and key2,
+// the matches are ordered from the last one, args can be nil (redis 7.0+)
+func (r *Redis) LCSIdx(key1, key2 string, args *LCSIdxArgs) (*LCSResult, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.lcsIdx(key1, key2, args)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToLCSResultReply(r.client.getObjectMultiBulkReply())
+}
+
+//</editor-fold>
+
+//<editor-fold desc="listcommands">
+
+//LPos return the index of the first matching element in the list stored at key (redis 6.0.6+),
+// args of RANK and MAXLEN can be nil.
+//
+//return the index of the element, -1 if it's not found
+func (r *Redis) LPos(key, element string, args *LPosArgs) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.lpos(key, element, -1, args)
+	if err != nil {
+		return 0, err
+	}
+	return ObjToLPosReply(r.client.getOne())
+}
+
+//LPosCount return the indexes of up to count matching elements in the list stored at key, count 0 returns all the matches,
+// see LPos()
+func (r *Redis) LPosCount(key, element string, count int64, args *LPosArgs) ([]int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.lpos(key, element, count, args)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getIntegerMultiBulkReply()
+}
+
+//LMove atomically pop an element from the from side of the list stored at srcKey,
+// and push it to the to side of the list stored at destKey (redis 6.2+).
+//
+//return the moved element, empty string if srcKey doesn't exist
+func (r *Redis) LMove(srcKey, destKey string, from, to *ListDirection) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.lmove(srcKey, destKey, from, to)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getBulkReply()
+}
+
+//BLMove the blocking version of LMove, it blocks until an element is available or the timeout elapses,
+// timeout 0 blocks forever.
+//
+//return the moved element, empty string when the timeout elapses
+func (r *Redis) BLMove(srcKey, destKey string, from, to *ListDirection, timeout time.Duration) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.connection.setCommandBlockingTimeout(timeout)
+	defer r.client.connection.rollbackTimeout()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.blmove(srcKey, destKey, from, to, timeout)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getBulkReply()
+}
+
+//LMPop pop up to count elements from the direction side of the first non-empty list of the keys (redis 7.0+),
+// count <= 0 pops one element.
+//
+//return the popped elements with the key they're popped from, nil when all the lists are empty
+func (r *Redis) LMPop(direction *ListDirection, count int64, keys ...string) (*LMPopResult, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.lmpop(direction, count, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToLMPopResultReply(r.client.getObjectMultiBulkReply())
+}
+
+//BLMPop the blocking version of LMPop, it blocks until an element is available or the timeout elapses, timeout 0 blocks forever.
+//
+//return the popped elements with the key they're popped from, nil when the timeout elapses
+func (r *Redis) BLMPop(timeout time.Duration, direction *ListDirection, count int64, keys ...string) (*LMPopResult, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.connection.setCommandBlockingTimeout(timeout)
+	defer r.client.connection.rollbackTimeout()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.blmpop(timeout, direction, count, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToLMPopResultReply(r.client.getObjectMultiBulkReply())
+}
+
+//LPopCount remove and return up to count elements from the head of the list stored at key (redis 6.2+)
+func (r *Redis) LPopCount(key string, count int64) ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.popCount(cmdLPop, key, count)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getMultiBulkReply()
+}
+
+//RPopCount remove and return up to count elements from the tail of the list stored at key (redis 6.2+)
+func (r *Redis) RPopCount(key string, count int64) ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.popCount(cmdRPop, key, count)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getMultiBulkReply()
+}
+
+//</editor-fold>
+
+//<editor-fold desc="sortedsetcommands">
+
+//ZPopMin remove and return up to count members with the lowest scores in the sorted set stored at key,
+// count <= 0 pops one member.
+//
+//return the popped members and their scores, ordered from the lowest score
+func (r *Redis) ZPopMin(key string, count int64) ([]Tuple, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zpop(cmdZPopMin, key, count)
+	if err != nil {
+		return nil, err
+	}
+	return StrArrToTupleReply(r.client.getMultiBulkReply())
+}
+
+//ZPopMax remove and return up to count members with the highest scores in the sorted set stored at key,
+// count <= 0 pops one member.
+//
+//return the popped members and their scores, ordered from the highest score
+func (r *Redis) ZPopMax(key string, count int64) ([]Tuple, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zpop(cmdZPopMax, key, count)
+	if err != nil {
+		return nil, err
+	}
+	return StrArrToTupleReply(r.client.getMultiBulkReply())
+}
+
+//BZPopMin the blocking version of ZPopMin, pop the member with the lowest score from the first non-empty sorted set of the keys,
+// it blocks until a member is available or the timeout elapses, timeout 0 blocks forever.
+//
+//return the popped member with the key it's popped from, nil when the timeout elapses
+func (r *Redis) BZPopMin(timeout time.Duration, keys ...string) (*KeyedTuple, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.connection.setCommandBlockingTimeout(timeout)
+	defer r.client.connection.rollbackTimeout()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.bzpop(cmdBZPopMin, timeout, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToKeyedTupleReply(r.client.getObjectMultiBulkReply())
+}
+
+//BZPopMax the blocking version of ZPopMax, pop the member with the highest score from the first non-empty sorted set of the keys,
+// it blocks until a member is available or the timeout elapses, timeout 0 blocks forever.
+//
+//return the popped member with the key it's popped from, nil when the timeout elapses
+func (r *Redis) BZPopMax(timeout time.Duration, keys ...string) (*KeyedTuple, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.connection.setCommandBlockingTimeout(timeout)
+	defer r.client.connection.rollbackTimeout()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.bzpop(cmdBZPopMax, timeout, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToKeyedTupleReply(r.client.getObjectMultiBulkReply())
+}
+
+//ZMPop pop up to count members with the lowest or the highest scores from the first non-empty sorted set of the keys (redis 7.0+),
+// count <= 0 pops one member.
+//
+//return the popped members with the key they're popped from, nil when all the sorted sets are empty
+func (r *Redis) ZMPop(option *SortedSetOption, count int64, keys ...string) (*ZMPopResult, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zmpop(option, count, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToZMPopResultReply(r.client.getObjectMultiBulkReply())
+}
+
+//BZMPop the blocking version of ZMPop, it blocks until a member is available or the timeout elapses, timeout 0 blocks forever.
+//
+//return the popped members with the key they're popped from, nil when the timeout elapses
+func (r *Redis) BZMPop(timeout time.Duration, option *SortedSetOption, count int64, keys ...string) (*ZMPopResult, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.connection.setCommandBlockingTimeout(timeout)
+	defer r.client.connection.rollbackTimeout()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.bzmpop(timeout, option, count, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToZMPopResultReply(r.client.getObjectMultiBulkReply())
+}
+
+//ZRandMember return up to count distinct random members of the sorted set stored at key,
+// negative count allows the same member multiple times and returns exactly -count members.
+func (r *Redis) ZRandMember(key string, count int64) ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zrandmember(key, count, false)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getMultiBulkReply()
+}
+
+//ZRandMemberWithScores see ZRandMember(), the scores of the members are returned too
+func (r *Redis) ZRandMemberWithScores(key string, count int64) ([]Tuple, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zrandmember(key, count, true)
+	if err != nil {
+		return nil, err
+	}
+	return StrArrToTupleReply(r.client.getMultiBulkReply())
+}
+
+//ZMScore return the scores of the members in the sorted set stored at key.
+//
+//return the scores in the order of the members, the score is nil if the member doesn't exist
+func (r *Redis) ZMScore(key string, members ...string) ([]*float64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zmscore(key, members...)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToFloat64PtrArrReply(r.client.getObjectMultiBulkReply())
+}
+
+//ZRangeByParams return the members in the range of the sorted set stored at key by the unified ZRANGE (redis 6.2+),
+// the range is by the indexes, the scores or the members, and can be reversed and limited, see ZRangeParams.
+func (r *Redis) ZRangeByParams(key string, params *ZRangeParams) ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zrangeByParams(key, params, false)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getMultiBulkReply()
+}
+
+//ZRangeWithScoresByParams see ZRangeByParams(), the scores of the members are returned too
+func (r *Redis) ZRangeWithScoresByParams(key string, params *ZRangeParams) ([]Tuple, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zrangeByParams(key, params, true)
+	if err != nil {
+		return nil, err
+	}
+	return StrArrToTupleReply(r.client.getMultiBulkReply())
+}
+
+//ZRangeStore store the members in the range of the sorted set stored at srcKey to destKey, see ZRangeByParams().
+//
+//return the number of the members in the resulting sorted set
+func (r *Redis) ZRangeStore(destKey, srcKey string, params *ZRangeParams) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.zrangestore(destKey, srcKey, params)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//ZDiff return the members of the first sorted set which don't exist in the other sorted sets of the keys
+func (r *Redis) ZDiff(keys ...string) ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zdiff(false, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getMultiBulkReply()
+}
+
+//ZDiffWithScores see ZDiff(), the scores of the members are returned too
+func (r *Redis) ZDiffWithScores(keys ...string) ([]Tuple, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zdiff(true, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return StrArrToTupleReply(r.client.getMultiBulkReply())
+}
+
+//ZDiffStore store the difference of the sorted sets of the keys to destKey, see ZDiff().
+//
+//return the number of the members in the resulting sorted set
+func (r *Redis) ZDiffStore(destKey string, keys ...string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.zdiffstore(destKey, keys...)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//ZInter return the intersection of the sorted sets of the keys like ZInterStoreWithParams() without storing it,
+// params of the weights and the aggregate can be nil
+func (r *Redis) ZInter(params *ZParams, keys ...string) ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zsetOperation(cmdZInter, params, false, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getMultiBulkReply()
+}
+
+//ZInterWithScores see ZInter(), the scores of the members are returned too
+func (r *Redis) ZInterWithScores(params *ZParams, keys ...string) ([]Tuple, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zsetOperation(cmdZInter, params, true, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return StrArrToTupleReply(r.client.getMultiBulkReply())
+}
+
+//ZUnion return the union of the sorted sets of the keys like ZUnionStoreWithParams() without storing it,
+// params of the weights and the aggregate can be nil
+func (r *Redis) ZUnion(params *ZParams, keys ...string) ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zsetOperation(cmdZUnion, params, false, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getMultiBulkReply()
+}
+
+//ZUnionWithScores see ZUnion(), the scores of the members are returned too
+func (r *Redis) ZUnionWithScores(params *ZParams, keys ...string) ([]Tuple, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.zsetOperation(cmdZUnion, params, true, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return StrArrToTupleReply(r.client.getMultiBulkReply())
+}
+
+//</editor-fold>
+
+//<editor-fold desc="hashcommands">
+
+//HSetByMap set the respective fields to the respective values of the hash stored at key,
+// it's the variadic HSET (redis 4.0+) which replaces HMSET.
+//
+//return the number of fields that were added
+func (r *Redis) HSetByMap(key string, hash map[string]string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.hsetByMap(key, hash)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//HStrLen return the string length of the value associated with field in the hash stored at key (redis 3.2+)
+//
+//return the string length, 0 if the field or the key doesn't exist
+func (r *Redis) HStrLen(key, field string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.hstrlen(key, field)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//HRandField return up to count random fields of the hash stored at key (redis 6.2+),
+// negative count allows the same field to be returned multiple times and returns exactly -count fields.
+func (r *Redis) HRandField(key string, count int64) ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.hrandfield(key, count, false)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getMultiBulkReply()
+}
+
+//HRandFieldWithValues return up to count random fields with their values of the hash stored at key,
+// see HRandField()
+func (r *Redis) HRandFieldWithValues(key string, count int64) ([]HashFieldValue, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.hrandfield(key, count, true)
+	if err != nil {
+		return nil, err
+	}
+	return StrArrToHashFieldValueArrReply(r.client.getMultiBulkReply())
+}
+
+//HExpire set the expiration of the fields of the hash stored at key in seconds (redis 7.4+),
+// the duration is truncated to seconds, condition can be nil.
+//
+//return the status of every field in the order of the fields
+func (r *Redis) HExpire(key string, expiration time.Duration, condition *ExpireCondition, fields ...string) ([]HashFieldExpireStatus, error) {
+	return r.hexpire(cmdHExpire, key, int64(expiration/time.Second), condition, fields...)
+}
+
+//HPExpire set the expiration of the fields of the hash stored at key in milliseconds, see HExpire()
+func (r *Redis) HPExpire(key string, expiration time.Duration, condition *ExpireCondition, fields ...string) ([]HashFieldExpireStatus, error) {
+	return r.hexpire(cmdHPExpire, key, int64(expiration/time.Millisecond), condition, fields...)
+}
+
+//HExpireAt set the Unix time in seconds at which the fields of the hash stored at key will expire, see HExpire()
+func (r *Redis) HExpireAt(key string, at time.Time, condition *ExpireCondition, fields ...string) ([]HashFieldExpireStatus, error) {
+	return r.hexpire(cmdHExpireAt, key, at.Unix(), condition, fields...)
+}
+
+//HPExpireAt set the Unix time in milliseconds at which the fields of the hash stored at key will expire, see HExpire()
+func (r *Redis) HPExpireAt(key string, at time.Time, condition *ExpireCondition, fields ...string) ([]HashFieldExpireStatus, error) {
+	return r.hexpire(cmdHPExpireAt, key, at.UnixNano()/int64(time.Millisecond), condition, fields...)
+}
+
+func (r *Redis) hexpire(cmd protocolCommand, key string, expiration int64, condition *ExpireCondition, fields ...string) ([]HashFieldExpireStatus, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.hexpire(cmd, key, expiration, condition, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return Int64ArrToHashFieldExpireStatusArrReply(r.client.getIntegerMultiBulkReply())
+}
+
+//HTTL return the remaining time to live of the fields of the hash stored at key in seconds (redis 7.4+)
+//
+//return the ttl of every field, -1 if the field has no expiration, -2 if the field or the key doesn't exist
+func (r *Redis) HTTL(key string, fields ...string) ([]int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.hfields(cmdHTTL, key, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getIntegerMultiBulkReply()
+}
+
+//HPTTL return the remaining time to live of the fields of the hash stored at key in milliseconds, see HTTL()
+func (r *Redis) HPTTL(key string, fields ...string) ([]int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.hfields(cmdHPTTL, key, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getIntegerMultiBulkReply()
+}
+
+//HPersist remove the expiration of the fields of the hash stored at key (redis 7.4+)
+//
+//return the status of every field in the order of the fields
+func (r *Redis) HPersist(key string, fields ...string) ([]HashFieldPersistStatus, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.hfields(cmdHPersist, key, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return Int64ArrToHashFieldPersistStatusArrReply(r.client.getIntegerMultiBulkReply())
+}
+
+//HGetDel get and delete the fields of the hash stored at key, the key is deleted when no field remains (redis 8.0+)
+//
+//return the value of every field, empty string if the field doesn't exist
+func (r *Redis) HGetDel(key string, fields ...string) ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.hfields(cmdHGetDel, key, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToStrArrReply(r.client.getObjectMultiBulkReply())
+}
+
+//HGetEx get the fields of the hash stored at key and optionally set or remove their expiration (redis 8.0+),
+// args can be nil.
+//
+//return the value of every field, empty string if the field doesn't exist
+func (r *Redis) HGetEx(key string, args *GetExArgs, fields ...string) ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.hgetex(key, args, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToStrArrReply(r.client.getObjectMultiBulkReply())
+}
+
+//HSetEx set the fields of the hash stored at key and optionally their expiration (redis 8.0+),
+// args can be nil.
+//
+//return 1 if all the fields are set, 0 if none is set because of FNX or FXX
+func (r *Redis) HSetEx(key string, args *HSetExArgs, hash map[string]string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.hsetex(key, args, hash)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//</editor-fold>
+
+//<editor-fold desc="geocommands">
+
+//GeoRadiusRO the read-only variant of GeoRadius (redis 3.2.10+), it can be sent to replicas
+func (r *Redis) GeoRadiusRO(key string, longitude, latitude, radius float64, unit *GeoUnit, param ...*GeoRadiusParams) ([]GeoRadiusResponse, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.georadius(cmdGeoRadiusRo, key, longitude, latitude, radius, unit, nil, param...)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToGeoRadiusResponseReply(r.client.getObjectMultiBulkReply())
+}
+
+//GeoRadiusByMemberRO the read-only variant of GeoRadiusByMember (redis 3.2.10+), it can be sent to replicas
+func (r *Redis) GeoRadiusByMemberRO(key, member string, radius float64, unit *GeoUnit, param ...*GeoRadiusParams) ([]GeoRadiusResponse, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.georadiusByMember(cmdGeoRadiusByMemberRo, key, member, radius, unit, nil, param...)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToGeoRadiusResponseReply(r.client.getObjectMultiBulkReply())
+}
+
+//GeoRadiusStore store the members in certain range to the sorted set of store.Key,
+// param can be nil and its WITHCOORD and WITHDIST are not allowed, store can't be nil.
+//
+//return the number of members stored
+func (r *Redis) GeoRadiusStore(key string, longitude, latitude, radius float64, unit *GeoUnit, param *GeoRadiusParams, store *GeoRadiusStoreArgs) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.georadius(cmdGeoRadius, key, longitude, latitude, radius, unit, store, param)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//GeoRadiusByMemberStore store the members in certain range of member to the sorted set of store.Key,
+// see GeoRadiusStore()
+func (r *Redis) GeoRadiusByMemberStore(key, member string, radius float64, unit *GeoUnit, param *GeoRadiusParams, store *GeoRadiusStoreArgs) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.georadiusByMember(cmdGeoRadiusByMember, key, member, radius, unit, store, param)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//GeoSearch return the members inside the shape of the query around its center (redis 6.2+),
+// it replaces GEORADIUS and GEORADIUSBYMEMBER.
+//
+//return the members, with the coordinate, the distance and the hash if the query asks for them
+func (r *Redis) GeoSearch(key string, query *GeoSearchQuery) ([]GeoRadiusResponse, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.geosearch(key, query)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToGeoRadiusResponseReply(r.client.getObjectMultiBulkReply())
+}
+
+//GeoSearchStore store the members found by the query in srcKey to the geo set of destKey (redis 6.2+),
+// the WITH options of the query are ignored.
+//
+//return the number of members stored
+func (r *Redis) GeoSearchStore(destKey, srcKey string, query *GeoSearchQuery) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.geosearchstore(destKey, srcKey, query, false)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//GeoSearchStoreDist store the members found by the query in srcKey to the sorted set of destKey
+// with the distances from the center as the scores, see GeoSearchStore()
+func (r *Redis) GeoSearchStoreDist(destKey, srcKey string, query *GeoSearchQuery) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.geosearchstore(destKey, srcKey, query, true)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//</editor-fold>
+
+//<editor-fold desc="keycommands">
+
+//Copy copy the value stored at srcKey to destKey (redis 6.2+), args can be nil.
+//
+//return 1 if the value is copied, 0 if srcKey doesn't exist or destKey exists without REPLACE
+func (r *Redis) Copy(srcKey, destKey string, args *CopyArgs) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.copy(srcKey, destKey, args)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//Touch alter the last access time of the keys (redis 3.2.1+)
+//
+//return the number of keys that were touched
+func (r *Redis) Touch(keys ...string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.touch(keys...)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//Unlink remove the keys like Del, but reclaim the memory in another thread (redis 4.0+)
+//
+//return the number of keys that were unlinked
+func (r *Redis) Unlink(keys ...string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.unlink(keys...)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//Dump serialize the value stored at key in a Redis-specific format, it can be restored by Restore
+//
+//return the serialized value, empty if the key doesn't exist
+func (r *Redis) Dump(key string) ([]byte, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.dump(key)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getBinaryBulkReply()
+}
+
+//Restore create key with the value serialized by Dump, the ttl is truncated to milliseconds,
+// 0 ttl creates the key without expiration, args can be nil.
+//
+//return OK, or error if the key exists without REPLACE
+func (r *Redis) Restore(key string, ttl time.Duration, serializedValue []byte, args *RestoreArgs) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.restore(key, int64(ttl/time.Millisecond), serializedValue, args, false)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//RestoreAt create key with the value serialized by Dump, it expires at the time (ABSTTL, redis 5.0+),
+// see Restore()
+func (r *Redis) RestoreAt(key string, at time.Time, serializedValue []byte, args *RestoreArgs) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.restore(key, at.UnixNano()/int64(time.Millisecond), serializedValue, args, true)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//Migrate Atomically transfer keys from a source Redis instance to a destination Redis instance.
+// On success the keys are deleted from the original instance and is guaranteed to exist in the target instance.
+// when more than one key is given, the keys are transferred in a single command with the KEYS option.
+// timeout is the maximum idle time in milliseconds in any moment of the communication with the destination instance.
+//Return value
+//Simple string reply: The command returns OK on success, or NOKEY if no keys were found in the source instance.
+func (r *Redis) Migrate(host string, port int, destinationDB int, timeout int, params *MigrateParams, keys ...string) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.migrate(host, port, destinationDB, timeout, params, keys...)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//ExpireTime return the Unix time in seconds at which key will expire (redis 7.0+)
+//
+//return the Unix time, -1 if the key has no expiration, -2 if the key doesn't exist
+func (r *Redis) ExpireTime(key string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.expireTime(cmdExpireTime, key)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//PExpireTime return the Unix time in milliseconds at which key will expire, see ExpireTime()
+func (r *Redis) PExpireTime(key string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.expireTime(cmdPExpireTime, key)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//ExpireWithCondition set the expiration of key in seconds only when the condition is met (redis 7.0+),
+// the duration is truncated to seconds, nil condition is the same as Expire().
+//
+//return 1 if the expiration is set, 0 if the key doesn't exist or the condition is not met
+func (r *Redis) ExpireWithCondition(key string, expiration time.Duration, condition *ExpireCondition) (int64, error) {
+	return r.expireWithCondition(cmdExpire, key, int64(expiration/time.Second), condition)
+}
+
+//PExpireWithCondition set the expiration of key in milliseconds only when the condition is met,
+// see ExpireWithCondition()
+func (r *Redis) PExpireWithCondition(key string, expiration time.Duration, condition *ExpireCondition) (int64, error) {
+	return r.expireWithCondition(cmdPExpire, key, int64(expiration/time.Millisecond), condition)
+}
+
+//ExpireAtWithCondition set the Unix time in seconds at which key will expire only when the condition is met,
+// see ExpireWithCondition()
+func (r *Redis) ExpireAtWithCondition(key string, at time.Time, condition *ExpireCondition) (int64, error) {
+	return r.expireWithCondition(cmdExpireAt, key, at.Unix(), condition)
+}
+
+//PExpireAtWithCondition set the Unix time in milliseconds at which key will expire only when the condition is met,
+// see ExpireWithCondition()
+func (r *Redis) PExpireAtWithCondition(key string, at time.Time, condition *ExpireCondition) (int64, error) {
+	return r.expireWithCondition(cmdPExpireAt, key, at.UnixNano()/int64(time.Millisecond), condition)
+}
+
+func (r *Redis) expireWithCondition(cmd protocolCommand, key string, expiration int64, condition *ExpireCondition) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.expireWithCondition(cmd, key, expiration, condition)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//</editor-fold>
+
+//<editor-fold desc="basiccommands">
+
+// Quit Ask the server to close the connection.
+// The connection is closed as soon as all pending replies have been written to the client.
+//
+// Return value
+// Simple string reply: always OK.
+func (r *Redis) Quit() (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.quit()
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//Ping send ping command to redis server
+// if the sever is running well,then it will return PONG.
+func (r *Redis) Ping() (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.ping()
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//Select send select command to change current db,normally redis server has 16 db [0,15]
+func (r *Redis) Select(index int) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.selectDb(index)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//FlushDB it will clear whole keys in current db
+func (r *Redis) FlushDB() (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.flushDB()
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//DbSize return key count of current db
+func (r *Redis) DbSize() (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.dbSize()
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//FlushAll it will clear whole keys in whole db
+func (r *Redis) FlushAll() (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.flushAll()
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//Auth when server is set password,then you need to auth password.
+func (r *Redis) Auth(password string) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.auth(password)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//Save ...
+func (r *Redis) Save() (string, error) {
+	err := r.client.save()
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//BgSave ...
+func (r *Redis) BgSave() (string, error) {
+	err := r.client.bgsave()
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//BgRewriteAof ...
+func (r *Redis) BgRewriteAof() (string, error) {
+	err := r.client.bgrewriteaof()
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//LastSave ...
+func (r *Redis) LastSave() (int64, error) {
+	err := r.client.lastsave()
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//Shutdown ...
+func (r *Redis) Shutdown() (string, error) {
+	err := r.client.shutdown()
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//Info ...
+func (r *Redis) Info(section ...string) (string, error) {
+	err := r.client.info(section...)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getBulkReply()
+}
+
+//SlaveOf ...
+func (r *Redis) SlaveOf(host string, port int) (string, error) {
+	err := r.client.slaveof(host, port)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//SlaveOfNoOne ...
+func (r *Redis) SlaveOfNoOne() (string, error) {
+	err := r.client.slaveofNoOne()
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//Debug ...
+func (r *Redis) Debug(params DebugParams) (string, error) {
+	err := r.client.debug(params)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//ConfigResetStat ...
+func (r *Redis) ConfigResetStat() (string, error) {
+	err := r.client.configResetStat()
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//WaitReplicas Synchronous replication of Redis as described here: http://antirez.com/news/66 Since Java
+// Object class has implemented "wait" method, we cannot use it, so I had to change the name of
+// the method. Sorry :S
+func (r *Redis) WaitReplicas(replicas int, timeout int64) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.waitReplicas(replicas, timeout)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//</editor-fold>
+
+//<editor-fold desc="aclcommands">
+
+//ACLSetUser create the user or modify the rules of the existing user (redis 6.0+),
+// the rules are applied on top of the existing ones, nil rules create a disabled user with no privileges
+func (r *Redis) ACLSetUser(username string, rules *ACLRules) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.aclSetUser(username, rules)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//ACLGetUser return the rules of the user (redis 6.0+)
+//
+//return the user, nil if the user doesn't exist
+func (r *Redis) ACLGetUser(username string) (*ACLUser, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.aclGetUser(username)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToACLUserReply(r.client.getObjectMultiBulkReply())
+}
+
+//ACLDelUser delete the users and disconnect their connections, the default user can't be deleted (redis 6.0+)
+//
+//return the number of users that were deleted
+func (r *Redis) ACLDelUser(usernames ...string) (int64, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return 0, err
+	}
+	err = r.client.aclDelUser(usernames...)
+	if err != nil {
+		return 0, err
+	}
+	return r.client.getIntegerReply()
+}
+
+//ACLList return the rules of every user in the format of the ACL file (redis 6.0+)
+func (r *Redis) ACLList() ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.acl(keywordList)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getMultiBulkReply()
+}
+
+//ACLUsers return the names of all the users (redis 6.0+)
+func (r *Redis) ACLUsers() ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.acl(keywordUsers)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getMultiBulkReply()
+}
+
+//ACLWhoAmI return the username of the current connection (redis 6.0+)
+func (r *Redis) ACLWhoAmI() (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.acl(keywordWhoAmI)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getBulkReply()
+}
+
+//ACLCat return the commands of the category, empty category returns all the categories (redis 6.0+)
+func (r *Redis) ACLCat(category string) ([]string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.aclCat(category)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.getMultiBulkReply()
+}
+
+//ACLLog return the latest count security events, count <= 0 returns the latest 10 events (redis 6.0+)
+func (r *Redis) ACLLog(count int) ([]*ACLLogEntry, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return nil, err
+	}
+	err = r.client.aclLog(count)
+	if err != nil {
+		return nil, err
+	}
+	return ObjArrToACLLogEntryArrReply(r.client.getObjectMultiBulkReply())
+}
+
+//ACLLogReset clear the security events (redis 6.0+)
+func (r *Redis) ACLLogReset() (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.acl(keywordLog, keywordReset.getRaw())
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//ACLGenPass return a random password of the bits in hex, bits <= 0 returns a password of 256 bits (redis 6.0+)
+func (r *Redis) ACLGenPass(bits int) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.aclGenPass(bits)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getBulkReply()
+}
+
+//ACLDryRun check whether the user can call the command with the args without calling it (redis 7.0+)
+//
+//return OK if the user can call the command, otherwise the reason why it's denied
+func (r *Redis) ACLDryRun(username, command string, args ...string) (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.aclDryRun(username, command, args...)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getBulkReply()
+}
+
+//ACLLoad reload the users from the ACL file, the users are not changed if the file is invalid (redis 6.0+)
+func (r *Redis) ACLLoad() (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.acl(keywordLoad)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
+//ACLSave save the users to the ACL file (redis 6.0+)
+func (r *Redis) ACLSave() (string, error) {
+	err := r.checkIsInMultiOrPipeline()
+	if err != nil {
+		return "", err
+	}
+	err = r.client.acl(keywordSave)
+	if err != nil {
+		return "", err
+	}
+	return r.client.getStatusCodeReply()
+}
+
 //</editor-fold>
 
 //<editor-fold desc="clustercommands">
@@ -1,7 +1,10 @@
 package godis
 
 import (
+	"bufio"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
 	"testing"
 	"time"
 )
@@ -446,3 +449,80 @@ func TestRedis_WaitReplicas(t *testing.T) {
 	_, err = redisBroken.WaitReplicas(1, 1)
 	assert.NotNil(t, err)
 }
+
+func TestACLRules_getParams(t *testing.T) {
+	assert.Equal(t, []string{"SETUSER", "u"}, byteArrArrToStrArr((*ACLRules)(nil).getParams("u")))
+	rules := NewACLRules().Reset().On().AddPasswords("p1", "p2").RemovePasswords("p3").
+		Keys("app:*").ReadKeys("ro:*").Channels("news.*").
+		AllowCategories("read").DenyCommands("keys", "config|set").
+		Selector(NewACLRules().AllowCommands("set").WriteKeys("w:*"))
+	assert.Equal(t, []string{"SETUSER", "u", "reset", "on", ">p1", ">p2", "<p3",
+		"~app:*", "%R~ro:*", "&news.*", "+@read", "-keys", "-config|set", "(+set %W~w:*)"},
+		byteArrArrToStrArr(rules.getParams("u")))
+}
+
+func TestRedis_ACLDryRun_command(t *testing.T) {
+	commands := make(chan []string, 1)
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		commands <- readFakeCommand(reader)
+		_, _ = conn.Write([]byte("+OK\r\n"))
+	})
+	redis := NewRedis(serverOption)
+	defer redis.Close()
+	reply, err := redis.ACLDryRun("u", "get", "k")
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", reply)
+	assert.Equal(t, []string{"ACL", "DRYRUN", "u", "get", "k"}, <-commands)
+}
+
+func TestRedis_ACLSetUser(t *testing.T) {
+	redis := NewRedis(option)
+	defer redis.Close()
+	reply, err := redis.ACLSetUser("godis", NewACLRules().Reset().On().AddPasswords("secret").Keys("godis:*").AllowCategories("read"))
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", reply)
+	defer redis.ACLDelUser("godis")
+
+	user, err := redis.ACLGetUser("godis")
+	require.Nil(t, err)
+	require.NotNil(t, user)
+	assert.Contains(t, user.Flags, "on")
+	assert.Len(t, user.Passwords, 1)
+	assert.Equal(t, "~godis:*", user.Keys)
+	user, err = redis.ACLGetUser("none")
+	assert.Nil(t, err)
+	assert.Nil(t, user)
+
+	users, err := redis.ACLUsers()
+	assert.Nil(t, err)
+	assert.Contains(t, users, "godis")
+	list, err := redis.ACLList()
+	assert.Nil(t, err)
+	assert.Len(t, list, len(users))
+	name, err := redis.ACLWhoAmI()
+	assert.Nil(t, err)
+	assert.Equal(t, "default", name)
+
+	result, err := redis.ACLDryRun("godis", "get", "godis:a")
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", result)
+	result, err = redis.ACLDryRun("godis", "set", "godis:a", "1")
+	assert.Nil(t, err)
+	assert.NotEqual(t, "OK", result)
+
+	categories, err := redis.ACLCat("")
+	assert.Nil(t, err)
+	assert.Contains(t, categories, "read")
+	pass, err := redis.ACLGenPass(128)
+	assert.Nil(t, err)
+	assert.Len(t, pass, 32)
+	_, err = redis.ACLLogReset()
+	assert.Nil(t, err)
+	entries, err := redis.ACLLog(0)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 0)
+
+	deleted, err := redis.ACLDelUser("godis", "none")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
@@ -1,7 +1,9 @@
 package godis
 
 import (
+	"bufio"
 	"github.com/stretchr/testify/assert"
+	"net"
 	"testing"
 )
 
@@ -54,6 +56,73 @@ func TestRedis_EvalByKeyArgs(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+const testFunctionLibrary = `#!lua name=godislib
+redis.register_function('godis_get', function(keys, args) return redis.call('GET', keys[1]) end)
+redis.register_function{function_name='godis_echo', callback=function(keys, args) return args end, flags={'no-writes'}}
+`
+
+func TestRedis_FCallRO_command(t *testing.T) {
+	commands := make(chan []string, 1)
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		commands <- readFakeCommand(reader)
+		_, _ = conn.Write([]byte("*2\r\n$1\r\na\r\n:1\r\n"))
+	})
+	redis := NewRedis(serverOption)
+	defer redis.Close()
+	reply, err := redis.FCallRO("f", []string{"k"}, []string{"a"})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", int64(1)}, reply)
+	assert.Equal(t, []string{"FCALL_RO", "f", "1", "k", "a"}, <-commands)
+}
+
+func TestRedis_FunctionLoad(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.FunctionFlush(FlushModeSync)
+	assert.Nil(t, err)
+	name, err := redis.FunctionLoad(testFunctionLibrary)
+	assert.Nil(t, err)
+	assert.Equal(t, "godislib", name)
+	_, err = redis.FunctionLoad(testFunctionLibrary)
+	assert.NotNil(t, err)
+	name, err = redis.FunctionLoadReplace(testFunctionLibrary)
+	assert.Nil(t, err)
+	assert.Equal(t, "godislib", name)
+
+	libraries, err := redis.FunctionList("godis*", true)
+	assert.Nil(t, err)
+	assert.Len(t, libraries, 1)
+	assert.Equal(t, "LUA", libraries[0].Engine)
+	assert.Len(t, libraries[0].Functions, 2)
+	assert.Equal(t, testFunctionLibrary, libraries[0].Code)
+	stats, err := redis.FunctionStats()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), stats.Engines["LUA"].Libraries)
+
+	_, err = redis.Set("godis", "good")
+	assert.Nil(t, err)
+	reply, err := redis.FCall("godis_get", []string{"godis"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "good", reply)
+	reply, err = redis.FCallRO("godis_echo", nil, []string{"a", "b"})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, reply)
+	_, err = redis.FCallRO("godis_get", []string{"godis"}, nil)
+	assert.NotNil(t, err)
+
+	dump, err := redis.FunctionDump()
+	assert.Nil(t, err)
+	_, err = redis.FunctionDelete("godislib")
+	assert.Nil(t, err)
+	_, err = redis.FunctionRestore(dump, FunctionRestoreFlush)
+	assert.Nil(t, err)
+	libraries, err = redis.FunctionList("", false)
+	assert.Nil(t, err)
+	assert.Len(t, libraries, 1)
+	assert.Equal(t, "", libraries[0].Code)
+}
+
 func TestRedis_ScriptLoad(t *testing.T) {
 	initDb()
 	redis := NewRedis(option)
@@ -1,8 +1,10 @@
 package godis
 
 import (
+	"bufio"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"net"
 	"sync"
 	"testing"
 	"time"
@@ -157,6 +159,65 @@ func TestRedis_Bitpos(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestRedis_BLMove_blockingTimeout(t *testing.T) {
+	commands := make(chan []string, 1)
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		commands <- readFakeCommand(reader)
+		//reply after the socket timeout, but before the timeout of the command
+		time.Sleep(200 * time.Millisecond)
+		_, _ = conn.Write([]byte("$1\r\na\r\n"))
+	})
+	serverOption.SoTimeout = 100 * time.Millisecond
+	redis := NewRedis(serverOption)
+	defer redis.Close()
+	element, err := redis.BLMove("src", "dst", ListDirectionRight, ListDirectionLeft, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "a", element)
+	assert.Equal(t, []string{"BLMOVE", "src", "dst", "RIGHT", "LEFT", "1"}, <-commands)
+}
+
+func TestRedis_BZPopMin_blockingTimeout(t *testing.T) {
+	commands := make(chan []string, 1)
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		commands <- readFakeCommand(reader)
+		//reply after the socket timeout, but before the timeout of the command
+		time.Sleep(200 * time.Millisecond)
+		_, _ = conn.Write([]byte("*3\r\n$1\r\nz\r\n$1\r\na\r\n$1\r\n1\r\n"))
+	})
+	serverOption.SoTimeout = 100 * time.Millisecond
+	redis := NewRedis(serverOption)
+	defer redis.Close()
+	tuple, err := redis.BZPopMin(1500*time.Millisecond, "z")
+	assert.Nil(t, err)
+	assert.Equal(t, "z", tuple.Key)
+	assert.Equal(t, "a", tuple.Element())
+	assert.Equal(t, []string{"BZPOPMIN", "z", "1.5"}, <-commands)
+}
+
+func TestRedis_Copy(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.Set("godis", "1")
+	assert.Nil(t, err)
+	copied, err := redis.Copy("godis", "dst", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), copied)
+	copied, err = redis.Copy("godis", "dst", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), copied)
+	copied, err = redis.Copy("godis", "dst", NewCopyArgs().Replace())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), copied)
+
+	touched, err := redis.Touch("godis", "dst", "none")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), touched)
+	unlinked, err := redis.Unlink("dst", "none")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), unlinked)
+}
+
 func TestRedis_Decr(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -205,6 +266,32 @@ func TestRedis_DecrBy(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestRedis_Dump(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.Set("godis", "good")
+	assert.Nil(t, err)
+	value, err := redis.Dump("godis")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, value)
+	reply, err := redis.Restore("dst", time.Minute, value, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", reply)
+	_, err = redis.Restore("dst", 0, value, nil)
+	assert.NotNil(t, err)
+	at := time.Now().Add(time.Hour)
+	reply, err = redis.RestoreAt("dst", at, value, NewRestoreArgs().Replace())
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", reply)
+	expireAt, err := redis.ExpireTime("dst")
+	assert.Nil(t, err)
+	assert.Equal(t, at.Unix(), expireAt)
+	got, err := redis.Get("dst")
+	assert.Nil(t, err)
+	assert.Equal(t, "good", got)
+}
+
 func TestRedis_Echo(t *testing.T) {
 	redis := NewRedis(option)
 	defer redis.Close()
@@ -272,6 +359,32 @@ func TestRedis_ExpireAt(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestRedis_ExpireWithCondition(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.Set("godis", "1")
+	assert.Nil(t, err)
+	expireAt, err := redis.PExpireTime("godis")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), expireAt)
+	set, err := redis.ExpireWithCondition("godis", time.Minute, ExpireConditionXX)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), set)
+	set, err = redis.ExpireWithCondition("godis", time.Minute, ExpireConditionNX)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), set)
+	set, err = redis.PExpireWithCondition("godis", time.Hour, ExpireConditionLT)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), set)
+	set, err = redis.ExpireAtWithCondition("godis", time.Now().Add(time.Hour), ExpireConditionGT)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), set)
+	expireAt, err = redis.ExpireTime("none")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), expireAt)
+}
+
 func TestRedis_Geo(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -382,6 +495,38 @@ func TestRedis_Geo2(t *testing.T) {
 	assert.Equal(t, []string{"e", "d", "c"}, arr)
 }
 
+func TestRedis_GeoSearch(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.GeoAddByMap("godis", map[string]GeoCoordinate{
+		"a": NewGeoCoordinate(121, 37),
+		"b": NewGeoCoordinate(122, 37),
+		"c": NewGeoCoordinate(130, 37),
+	})
+	assert.Nil(t, err)
+	resp, err := redis.GeoSearch("godis", NewGeoSearchQuery().FromMember("a").ByRadius(100, GeoUnitKm).Asc().WithDist())
+	assert.Nil(t, err)
+	assert.Len(t, resp, 2)
+	assert.Equal(t, "a", resp[0].Member())
+	assert.Equal(t, "b", resp[1].Member())
+	assert.True(t, resp[1].Distance() > 80)
+
+	count, err := redis.GeoSearchStore("dst", "godis", NewGeoSearchQuery().FromLonLat(121, 37).ByBox(400, 400, GeoUnitKm))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+	count, err = redis.GeoRadiusStore("godis", 121, 37, 100, GeoUnitKm, nil, &GeoRadiusStoreArgs{Key: "dist", StoreDist: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+	score, err := redis.ZScore("dist", "a")
+	assert.Nil(t, err)
+	assert.True(t, score < 1)
+
+	resp, err = redis.GeoRadiusByMemberRO("godis", "a", 100, GeoUnitKm)
+	assert.Nil(t, err)
+	assert.Len(t, resp, 2)
+}
+
 func TestRedis_Get(t *testing.T) {
 	initDb()
 	redis := NewRedis(option)
@@ -520,6 +665,44 @@ func TestRedis_Hexists(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestRedis_HExpire(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.HSetByMap("godis", map[string]string{"a": "1", "b": "2"})
+	assert.Nil(t, err)
+	statuses, err := redis.HExpire("godis", time.Minute, nil, "a", "none")
+	assert.Nil(t, err)
+	assert.Equal(t, []HashFieldExpireStatus{HashFieldExpireSet, HashFieldExpireNoField}, statuses)
+	statuses, err = redis.HExpire("godis", time.Minute, ExpireConditionNX, "a")
+	assert.Nil(t, err)
+	assert.Equal(t, []HashFieldExpireStatus{HashFieldExpireNotSet}, statuses)
+	ttls, err := redis.HTTL("godis", "a", "b", "none")
+	assert.Nil(t, err)
+	assert.True(t, ttls[0] > 0)
+	assert.Equal(t, []int64{-1, -2}, ttls[1:])
+	persisted, err := redis.HPersist("godis", "a", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, []HashFieldPersistStatus{HashFieldPersisted, HashFieldPersistNoExpiration}, persisted)
+	statuses, err = redis.HPExpireAt("godis", time.Now().Add(-time.Minute), nil, "b")
+	assert.Nil(t, err)
+	assert.Equal(t, []HashFieldExpireStatus{HashFieldExpireDeleted}, statuses)
+}
+
+func TestRedis_HExpire_command(t *testing.T) {
+	commands := make(chan []string, 1)
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		commands <- readFakeCommand(reader)
+		_, _ = conn.Write([]byte("*2\r\n:1\r\n:-2\r\n"))
+	})
+	redis := NewRedis(serverOption)
+	defer redis.Close()
+	statuses, err := redis.HPExpire("h", 1500*time.Millisecond, ExpireConditionGT, "a", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, []HashFieldExpireStatus{HashFieldExpireSet, HashFieldExpireNoField}, statuses)
+	assert.Equal(t, []string{"HPEXPIRE", "h", "1500", "GT", "FIELDS", "2", "a", "b"}, <-commands)
+}
+
 func TestRedis_Hget(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -564,6 +747,30 @@ func TestRedis_HgetAll(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestRedis_HGetEx(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	set, err := redis.HSetEx("godis", NewHSetExArgs().FNX().EX(time.Minute), map[string]string{"a": "1", "b": "2"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), set)
+	set, err = redis.HSetEx("godis", NewHSetExArgs().FNX(), map[string]string{"a": "3"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), set)
+	values, err := redis.HGetEx("godis", NewGetExArgs().PERSIST(), "a", "none")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", ""}, values)
+	ttls, err := redis.HPTTL("godis", "a")
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{-1}, ttls)
+	values, err = redis.HGetDel("godis", "a", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, values)
+	exists, err := redis.Exists("godis")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), exists)
+}
+
 func TestRedis_HincrBy(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -768,6 +975,24 @@ func TestRedis_Hset(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestRedis_HSetByMap(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	added, err := redis.HSetByMap("godis", map[string]string{"a": "1", "b": "22"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), added)
+	length, err := redis.HStrLen("godis", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), length)
+	fields, err := redis.HRandField("godis", 5)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"a", "b"}, fields)
+	values, err := redis.HRandFieldWithValues("godis", 5)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []HashFieldValue{{Field: "a", Value: "1"}, {Field: "b", Value: "22"}}, values)
+}
+
 func TestRedis_Hsetnx(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -924,6 +1149,24 @@ func TestRedis_IncrByFloat(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestRedis_LCS(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.MSet("key1", "ohmytext", "key2", "mynewtext")
+	assert.Nil(t, err)
+	lcs, err := redis.LCS("key1", "key2")
+	assert.Nil(t, err)
+	assert.Equal(t, "mytext", lcs)
+	length, err := redis.LCSLen("key1", "key2")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), length)
+	result, err := redis.LCSIdx("key1", "key2", &LCSIdxArgs{MinMatchLen: 4, WithMatchLen: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), result.Len)
+	assert.Equal(t, []*LCSMatch{{Key1: LCSRange{Start: 4, End: 7}, Key2: LCSRange{Start: 5, End: 8}, Len: 4}}, result.Matches)
+}
+
 func TestRedis_Lindex(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -1111,6 +1354,72 @@ func TestRedis_List(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestRedis_LMove(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.RPush("godis", "a", "b", "c", "b")
+	assert.Nil(t, err)
+	index, err := redis.LPos("godis", "b", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), index)
+	index, err = redis.LPos("godis", "b", &LPosArgs{Rank: -1})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), index)
+	index, err = redis.LPos("godis", "none", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), index)
+	indexes, err := redis.LPosCount("godis", "b", 0, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1, 3}, indexes)
+
+	element, err := redis.LMove("godis", "dst", ListDirectionLeft, ListDirectionRight)
+	assert.Nil(t, err)
+	assert.Equal(t, "a", element)
+	element, err = redis.BLMove("none", "dst", ListDirectionLeft, ListDirectionRight, 100*time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, "", element)
+
+	elements, err := redis.RPopCount("godis", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b", "c"}, elements)
+	elements, err = redis.LPopCount("godis", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b"}, elements)
+
+	result, err := redis.LMPop(ListDirectionLeft, 5, "none", "dst")
+	assert.Nil(t, err)
+	assert.Equal(t, &LMPopResult{Key: "dst", Elements: []string{"a"}}, result)
+	result, err = redis.BLMPop(100*time.Millisecond, ListDirectionLeft, 1, "dst")
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestRedis_Migrate_command(t *testing.T) {
+	commands := make(chan []string, 2)
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		for command := readFakeCommand(reader); command != nil; command = readFakeCommand(reader) {
+			commands <- command
+			_, _ = conn.Write([]byte("+NOKEY\r\n"))
+		}
+	})
+	redis := NewRedis(serverOption)
+	defer redis.Close()
+	reply, err := redis.Migrate("127.0.0.1", 7000, 0, 1000, nil, "a")
+	assert.Nil(t, err)
+	assert.Equal(t, "NOKEY", reply)
+	assert.Equal(t, []string{"MIGRATE", "127.0.0.1", "7000", "a", "0", "1000"}, <-commands)
+
+	//more than one key is transferred with KEYS, the key argument is empty
+	reply, err = redis.Migrate("127.0.0.1", 7000, 1, 1000, NewMigrateParams().Copy().Replace().Auth2("u", "p"), "a", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, "NOKEY", reply)
+	assert.Equal(t, []string{"MIGRATE", "127.0.0.1", "7000", "", "1", "1000", "COPY", "REPLACE", "AUTH2", "u", "p", "KEYS", "a", "b"}, <-commands)
+
+	_, err = redis.Migrate("127.0.0.1", 7000, 0, 1000, nil)
+	assert.IsType(t, &DataError{}, err)
+}
+
 func TestRedis_Move(t *testing.T) {
 	initDb()
 	redis := NewRedis(option)
@@ -1215,6 +1524,20 @@ func TestRedis_PexpireAt(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestRedis_PExpireWithCondition_command(t *testing.T) {
+	commands := make(chan []string, 1)
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		commands <- readFakeCommand(reader)
+		_, _ = conn.Write([]byte(":0\r\n"))
+	})
+	redis := NewRedis(serverOption)
+	defer redis.Close()
+	reply, err := redis.PExpireWithCondition("k", 1500*time.Millisecond, ExpireConditionNX)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), reply)
+	assert.Equal(t, []string{"PEXPIRE", "k", "1500", "NX"}, <-commands)
+}
+
 func TestRedis_Pfadd(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -1410,6 +1733,37 @@ func TestRedis_Set(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestRedis_SetWithArgs(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	reply, err := redis.SetWithArgs("godis", "1", NewSetArgs().NX().EX(10*time.Second))
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", reply)
+	reply, err = redis.SetWithArgs("godis", "2", NewSetArgs().NX())
+	assert.Nil(t, err)
+	assert.Equal(t, "", reply)
+	reply, err = redis.SetWithArgs("godis", "2", NewSetArgs().XX().KEEPTTL().GET())
+	assert.Nil(t, err)
+	assert.Equal(t, "1", reply)
+	ttl, err := redis.TTL("godis")
+	assert.Nil(t, err)
+	assert.True(t, ttl > 0)
+
+	value, err := redis.GetEx("godis", NewGetExArgs().PERSIST())
+	assert.Nil(t, err)
+	assert.Equal(t, "2", value)
+	ttl, err = redis.TTL("godis")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), ttl)
+	value, err = redis.GetDel("godis")
+	assert.Nil(t, err)
+	assert.Equal(t, "2", value)
+	exists, err := redis.Exists("godis")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), exists)
+}
+
 func TestRedis_SetWithParams(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -1761,6 +2115,121 @@ func TestRedis_Type(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestRedis_XAdd(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	id, err := redis.XAdd("godis", &XAddArgs{ID: "1-0", Fields: map[string]string{"a": "1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "1-0", id)
+	_, err = redis.XAdd("godis", &XAddArgs{Fields: map[string]string{"b": "2"}})
+	assert.Nil(t, err)
+	id, err = redis.XAdd("none", &XAddArgs{Fields: map[string]string{"a": "1"}, NoMkStream: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "", id)
+
+	length, err := redis.XLen("godis")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), length)
+
+	entries, err := redis.XRange("godis", "-", "+", 0)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, map[string]string{"a": "1"}, entries[0].Fields)
+	entries, err = redis.XRevRange("godis", "+", "-", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"b": "2"}, entries[0].Fields)
+
+	info, err := redis.XInfoStream("godis")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), info.Length)
+	assert.Equal(t, "1-0", info.FirstEntry.ID)
+
+	streams, err := redis.XRead(&XReadArgs{Keys: []string{"godis"}, IDs: []string{"0"}, Count: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, "1-0", streams[0].Entries[0].ID)
+	streams, err = redis.XRead(&XReadArgs{Keys: []string{"godis"}, IDs: []string{"$"}, Block: 100 * time.Millisecond})
+	assert.Nil(t, err)
+	assert.Empty(t, streams)
+
+	n, err := redis.XDel("godis", "1-0")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+	n, err = redis.XTrim("godis", &XTrimArgs{MaxLen: 0})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+}
+
+func TestRedis_XRead_blockingTimeout(t *testing.T) {
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		readFakeCommand(reader)
+		//reply after the socket timeout, but before the block time
+		time.Sleep(200 * time.Millisecond)
+		_, _ = conn.Write([]byte("*1\r\n*2\r\n$1\r\ns\r\n*1\r\n*2\r\n$3\r\n1-0\r\n*2\r\n$1\r\na\r\n$1\r\n1\r\n"))
+	})
+	serverOption.SoTimeout = 100 * time.Millisecond
+	redis := NewRedis(serverOption)
+	defer redis.Close()
+	streams, err := redis.XRead(&XReadArgs{Keys: []string{"s"}, IDs: []string{"$"}, Block: time.Second})
+	assert.Nil(t, err)
+	assert.Len(t, streams, 1)
+	assert.Equal(t, "1-0", streams[0].Entries[0].ID)
+}
+
+func TestRedis_XReadGroup(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.XGroupCreate("godis", "g", "$", false)
+	assert.NotNil(t, err)
+	status, err := redis.XGroupCreate("godis", "g", "$", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", status)
+	_, err = redis.XGroupCreate("godis", "g", "$", true)
+	assert.NotNil(t, err)
+	n, err := redis.XGroupCreateConsumer("godis", "g", "a")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+
+	_, err = redis.XAdd("godis", &XAddArgs{ID: "1-0", Fields: map[string]string{"a": "1"}})
+	assert.Nil(t, err)
+	_, err = redis.XAdd("godis", &XAddArgs{ID: "2-0", Fields: map[string]string{"b": "2"}})
+	assert.Nil(t, err)
+	streams, err := redis.XReadGroup(&XReadGroupArgs{Group: "g", Consumer: "a", Keys: []string{"godis"}, IDs: []string{">"}})
+	assert.Nil(t, err)
+	assert.Len(t, streams[0].Entries, 2)
+
+	summary, err := redis.XPending("godis", "g")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), summary.Count)
+	assert.Equal(t, map[string]int64{"a": 2}, summary.Consumers)
+	pending, err := redis.XPendingRange("godis", "g", &XPendingArgs{Consumer: "a"})
+	assert.Nil(t, err)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, int64(1), pending[0].DeliveryCount)
+
+	entries, err := redis.XClaim("godis", &XClaimArgs{Group: "g", Consumer: "b", IDs: []string{"1-0"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "1-0", entries[0].ID)
+	result, err := redis.XAutoClaim("godis", &XAutoClaimArgs{Group: "g", Consumer: "b"})
+	assert.Nil(t, err)
+	assert.Equal(t, "0-0", result.Next)
+	assert.Len(t, result.Entries, 2)
+
+	n, err = redis.XAck("godis", "g", "1-0", "2-0")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+	n, err = redis.XGroupDelConsumer("godis", "g", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+	status, err = redis.XGroupSetID("godis", "g", "0")
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", status)
+	n, err = redis.XGroupDestroy("godis", "g")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+}
+
 func TestRedis_Zadd(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -2063,6 +2532,106 @@ func TestRedis_Zadd2(t *testing.T) {
 	assert.Equal(t, []string{"a", "c"}, arr)
 }
 
+func TestRedis_ZIncrBy_params(t *testing.T) {
+	commands := make(chan []string, 2)
+	serverOption := fakeServer(t, func(index int, conn net.Conn, reader *bufio.Reader) {
+		commands <- readFakeCommand(reader)
+		_, _ = conn.Write([]byte("$3\r\n1.5\r\n"))
+		commands <- readFakeCommand(reader)
+		_, _ = conn.Write([]byte("$-1\r\n"))
+	})
+	redis := NewRedis(serverOption)
+	defer redis.Close()
+	score, err := redis.ZIncrBy("z", 1.5, "a", NewZAddParams().GT())
+	assert.Nil(t, err)
+	assert.Equal(t, 1.5, score)
+	assert.Equal(t, []string{"ZADD", "z", "GT", "INCR", "1.5", "a"}, <-commands)
+	_, err = redis.ZIncrBy("z", 1, "a", NewZAddParams().XX().INCR())
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"ZADD", "z", "XX", "INCR", "1", "a"}, <-commands)
+
+	_, err = redis.ZAdd("z", 1, "a", NewZAddParams().INCR())
+	assert.NotNil(t, err)
+}
+
+func TestRedis_ZPopMin(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.ZAddByMap("godis", map[string]float64{"a": 1, "b": 2, "c": 3, "d": 4})
+	assert.Nil(t, err)
+	tuples, err := redis.ZPopMin("godis", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []Tuple{{element: "a", score: 1}, {element: "b", score: 2}}, tuples)
+	tuples, err = redis.ZPopMax("godis", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []Tuple{{element: "d", score: 4}}, tuples)
+
+	tuple, err := redis.BZPopMin(100*time.Millisecond, "none", "godis")
+	assert.Nil(t, err)
+	assert.Equal(t, &KeyedTuple{Key: "godis", Tuple: Tuple{element: "c", score: 3}}, tuple)
+	tuple, err = redis.BZPopMax(100*time.Millisecond, "godis")
+	assert.Nil(t, err)
+	assert.Nil(t, tuple)
+
+	_, err = redis.ZAddByMap("godis", map[string]float64{"a": 1, "b": 2})
+	assert.Nil(t, err)
+	result, err := redis.ZMPop(SortedSetOptionMax, 5, "none", "godis")
+	assert.Nil(t, err)
+	assert.Equal(t, &ZMPopResult{Key: "godis", Tuples: []Tuple{{element: "b", score: 2}, {element: "a", score: 1}}}, result)
+	result, err = redis.BZMPop(100*time.Millisecond, SortedSetOptionMin, 1, "godis")
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestRedis_ZRangeByParams(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.ZAddByMap("godis", map[string]float64{"a": 1, "b": 2, "c": 3})
+	assert.Nil(t, err)
+	n, err := redis.ZAdd("godis", 0, "a", NewZAddParams().GT().CH())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+
+	members, err := redis.ZRangeByParams("godis", NewZRangeByScoreParams("(1", "+inf"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b", "c"}, members)
+	tuples, err := redis.ZRangeWithScoresByParams("godis", NewZRangeByScoreParams("+inf", "-inf").Rev().Limit(0, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, []Tuple{{element: "c", score: 3}}, tuples)
+	members, err = redis.ZRangeByParams("godis", NewZRangeByLexParams("[b", "+"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b", "c"}, members)
+	n, err = redis.ZRangeStore("dst", "godis", NewZRangeParams(0, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+
+	scores, err := redis.ZMScore("godis", "a", "none")
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, *scores[0])
+	assert.Nil(t, scores[1])
+	members, err = redis.ZRandMember("godis", 5)
+	assert.Nil(t, err)
+	assert.Len(t, members, 3)
+	tuples, err = redis.ZRandMemberWithScores("godis", -5)
+	assert.Nil(t, err)
+	assert.Len(t, tuples, 5)
+
+	members, err = redis.ZDiff("godis", "dst")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"c"}, members)
+	n, err = redis.ZDiffStore("diff", "godis", "dst")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+	tuples, err = redis.ZInterWithScores((&ZParams{}).Aggregate(AggregateMax), "godis", "dst")
+	assert.Nil(t, err)
+	assert.Equal(t, []Tuple{{element: "a", score: 1}, {element: "b", score: 2}}, tuples)
+	tuples, err = redis.ZUnionWithScores(nil, "godis", "dst")
+	assert.Nil(t, err)
+	assert.Equal(t, []Tuple{{element: "a", score: 2}, {element: "c", score: 3}, {element: "b", score: 4}}, tuples)
+}
+
 func TestRedis_Zscan(t *testing.T) {
 	flushAll()
 	redis := NewRedis(option)
@@ -2099,3 +2668,198 @@ func TestRedis_Zscan(t *testing.T) {
 	_, err = redisBroken.ZScan("godis", cursor, params)
 	assert.NotNil(t, err)
 }
+
+func TestSetArgs_getParams(t *testing.T) {
+	assert.Equal(t, []string{"k", "v"}, byteArrArrToStrArr((*SetArgs)(nil).getParams("k", "v")))
+	params := NewSetArgs().NX().GET().EX(1500*time.Millisecond).getParams("k", "v")
+	assert.Equal(t, []string{"k", "v", "NX", "GET", "EX", "1"}, byteArrArrToStrArr(params))
+	//the later condition and expiration replace the former ones
+	params = NewSetArgs().NX().XX().PX(time.Second).KEEPTTL().getParams("k", "v")
+	assert.Equal(t, []string{"k", "v", "XX", "KEEPTTL"}, byteArrArrToStrArr(params))
+	at := time.Unix(1700000000, 500*int64(time.Millisecond))
+	params = NewSetArgs().PXAT(at).getParams("k", "v")
+	assert.Equal(t, []string{"k", "v", "PXAT", "1700000000500"}, byteArrArrToStrArr(params))
+	params = NewSetArgs().EXAT(at).getParams("k", "v")
+	assert.Equal(t, []string{"k", "v", "EXAT", "1700000000"}, byteArrArrToStrArr(params))
+}
+
+func TestGetExArgs_getParams(t *testing.T) {
+	assert.Equal(t, []string{"k"}, byteArrArrToStrArr((*GetExArgs)(nil).getParams("k")))
+	assert.Equal(t, []string{"k", "PX", "100"}, byteArrArrToStrArr(NewGetExArgs().PX(100*time.Millisecond).getParams("k")))
+	assert.Equal(t, []string{"k", "PERSIST"}, byteArrArrToStrArr(NewGetExArgs().EX(time.Second).PERSIST().getParams("k")))
+}
+
+func TestLPosArgs_getParams(t *testing.T) {
+	assert.Equal(t, []string{"l", "a"}, byteArrArrToStrArr((*LPosArgs)(nil).getParams("l", "a", -1)))
+	params := (&LPosArgs{Rank: -2, MaxLen: 10}).getParams("l", "a", 0)
+	assert.Equal(t, []string{"l", "a", "RANK", "-2", "COUNT", "0", "MAXLEN", "10"}, byteArrArrToStrArr(params))
+	params = lmpopParams(ListDirectionRight, 2, []string{"a", "b"})
+	assert.Equal(t, []string{"2", "a", "b", "RIGHT", "COUNT", "2"}, byteArrArrToStrArr(params))
+}
+
+func TestHSetExArgs_getParams(t *testing.T) {
+	hash := map[string]string{"b": "2", "a": "1"}
+	params := (*HSetExArgs)(nil).getParams("h", hash)
+	assert.Equal(t, []string{"h", "FIELDS", "2", "a", "1", "b", "2"}, byteArrArrToStrArr(params))
+	params = NewHSetExArgs().FXX().FNX().EX(1500*time.Millisecond).getParams("h", map[string]string{"a": "1"})
+	assert.Equal(t, []string{"h", "FNX", "EX", "1", "FIELDS", "1", "a", "1"}, byteArrArrToStrArr(params))
+	params = NewHSetExArgs().PX(time.Second).KEEPTTL().getParams("h", map[string]string{"a": "1"})
+	assert.Equal(t, []string{"h", "KEEPTTL", "FIELDS", "1", "a", "1"}, byteArrArrToStrArr(params))
+
+	params = hashExpireParams("h", 10, ExpireConditionNX, []string{"a", "b"})
+	assert.Equal(t, []string{"h", "10", "NX", "FIELDS", "2", "a", "b"}, byteArrArrToStrArr(params))
+	params = hashExpireParams("h", 10, nil, []string{"a"})
+	assert.Equal(t, []string{"h", "10", "FIELDS", "1", "a"}, byteArrArrToStrArr(params))
+}
+
+func TestZAddParams_getByteParams(t *testing.T) {
+	params := NewZAddParams().XX().GT().CH().INCR().getByteParams([]byte("z"), []byte("1"), []byte("a"))
+	assert.Equal(t, []string{"z", "XX", "GT", "CH", "INCR", "1", "a"}, byteArrArrToStrArr(params))
+	params = NewZAddParams().NX().LT().getByteParams([]byte("z"))
+	assert.Equal(t, []string{"z", "NX", "LT"}, byteArrArrToStrArr(params))
+}
+
+func TestZRangeParams_getParams(t *testing.T) {
+	assert.Equal(t, []string{"z", "0", "-1"}, byteArrArrToStrArr(NewZRangeParams(0, -1).getParams("z")))
+	params := NewZRangeByScoreParams("+inf", "(1").Rev().Limit(1, 2).getParams("z")
+	assert.Equal(t, []string{"z", "+inf", "(1", "BYSCORE", "REV", "LIMIT", "1", "2"}, byteArrArrToStrArr(params))
+	params = NewZRangeByLexParams("[a", "+").getParams("dst", "src")
+	assert.Equal(t, []string{"dst", "src", "[a", "+", "BYLEX"}, byteArrArrToStrArr(params))
+}
+
+func TestCopyArgs_getParams(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, byteArrArrToStrArr((*CopyArgs)(nil).getParams("a", "b")))
+	assert.Equal(t, []string{"a", "b", "DB", "0", "REPLACE"}, byteArrArrToStrArr(NewCopyArgs().DB(0).Replace().getParams("a", "b")))
+
+	params := (*RestoreArgs)(nil).getParams("k", 0, []byte("v"), false)
+	assert.Equal(t, []string{"k", "0", "v"}, byteArrArrToStrArr(params))
+	params = NewRestoreArgs().Replace().IdleTime(1500*time.Millisecond).getParams("k", 1700000000000, []byte("v"), true)
+	assert.Equal(t, []string{"k", "1700000000000", "v", "REPLACE", "ABSTTL", "IDLETIME", "1"}, byteArrArrToStrArr(params))
+	params = NewRestoreArgs().Freq(0).getParams("k", 10, []byte("v"), false)
+	assert.Equal(t, []string{"k", "10", "v", "FREQ", "0"}, byteArrArrToStrArr(params))
+
+	assert.Equal(t, []string{"k", "10", "GT"}, byteArrArrToStrArr(expireParams("k", 10, ExpireConditionGT)))
+	assert.Equal(t, []string{"k", "10"}, byteArrArrToStrArr(expireParams("k", 10, nil)))
+}
+
+func TestMigrateParams_getParams(t *testing.T) {
+	assert.Equal(t, []string{}, byteArrArrToStrArr((*MigrateParams)(nil).getParams()))
+	assert.Equal(t, []string{"COPY", "REPLACE", "AUTH", "p"}, byteArrArrToStrArr(NewMigrateParams().Copy().Replace().Auth("p").getParams()))
+	assert.Equal(t, []string{"AUTH2", "u", "p"}, byteArrArrToStrArr(NewMigrateParams().Auth2("u", "p").getParams()))
+}
+
+func TestGeoSearchQuery_getParams(t *testing.T) {
+	_, err := NewGeoSearchQuery().FromMember("a").getParams(true, "k")
+	assert.NotNil(t, err)
+	_, err = NewGeoSearchQuery().FromMember("a").ByRadius(1, GeoUnitKm).Any().getParams(true, "k")
+	assert.NotNil(t, err)
+	_, err = (*GeoSearchQuery)(nil).getParams(true, "k")
+	assert.NotNil(t, err)
+
+	query := NewGeoSearchQuery().FromMember("a").ByRadius(100, GeoUnitKm).Asc().Count(2).Any().WithCoord().WithDist().WithHash()
+	params, err := query.getParams(true, "k")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"k", "FROMMEMBER", "a", "BYRADIUS", "100", "km", "ASC", "COUNT", "2", "ANY",
+		"WITHCOORD", "WITHDIST", "WITHHASH"}, byteArrArrToStrArr(params))
+	params, err = query.FromLonLat(121, 37.5).ByBox(10, 20, GeoUnitM).Desc().getParams(false, "dst", "k")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"dst", "k", "FROMLONLAT", "121", "37.5", "BYBOX", "10", "20", "m", "DESC", "COUNT", "2", "ANY"},
+		byteArrArrToStrArr(params))
+
+	params = geoRadiusParams([][]byte{[]byte("k")}, &GeoRadiusStoreArgs{Key: "dst", StoreDist: true}, []*GeoRadiusParams{nil})
+	assert.Equal(t, []string{"k", "STOREDIST", "dst"}, byteArrArrToStrArr(params))
+	params = geoRadiusParams([][]byte{[]byte("k")}, &GeoRadiusStoreArgs{Key: "dst"}, []*GeoRadiusParams{NewGeoRadiusParam().Count(1)})
+	assert.Equal(t, []string{"k", "count", "1", "STORE", "dst"}, byteArrArrToStrArr(params))
+}
+
+func TestXAddArgs_getParams(t *testing.T) {
+	params, err := (&XAddArgs{Fields: map[string]string{"b": "2", "a": "1"}}).getParams("s")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"s", "*", "a", "1", "b", "2"}, byteArrArrToStrArr(params))
+
+	params, err = (&XAddArgs{
+		ID:          "1-1",
+		Fields:      map[string]string{"a": "1"},
+		NoMkStream:  true,
+		MaxLen:      100,
+		Approximate: true,
+		Limit:       10,
+	}).getParams("s")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"s", "NOMKSTREAM", "MAXLEN", "~", "100", "LIMIT", "10", "1-1", "a", "1"}, byteArrArrToStrArr(params))
+
+	params, err = (&XAddArgs{Fields: map[string]string{"a": "1"}, MaxLen: 100, MinID: "5-0"}).getParams("s")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"s", "MINID", "5-0", "*", "a", "1"}, byteArrArrToStrArr(params))
+
+	_, err = (&XAddArgs{}).getParams("s")
+	assert.NotNil(t, err)
+	_, err = (*XAddArgs)(nil).getParams("s")
+	assert.NotNil(t, err)
+}
+
+func TestXTrimArgs_getParams(t *testing.T) {
+	params, err := (&XTrimArgs{MaxLen: 0}).getParams("s")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"s", "MAXLEN", "0"}, byteArrArrToStrArr(params))
+
+	params, err = (&XTrimArgs{MinID: "5-0", Approximate: true}).getParams("s")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"s", "MINID", "~", "5-0"}, byteArrArrToStrArr(params))
+
+	_, err = (*XTrimArgs)(nil).getParams("s")
+	assert.NotNil(t, err)
+}
+
+func TestXReadArgs_getParams(t *testing.T) {
+	params, err := (&XReadArgs{Keys: []string{"a", "b"}, IDs: []string{"0", "$"}, Count: 10, Block: time.Second}).getParams()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"COUNT", "10", "BLOCK", "1000", "STREAMS", "a", "b", "0", "$"}, byteArrArrToStrArr(params))
+
+	params, err = (&XReadArgs{Keys: []string{"a"}, IDs: []string{"$"}, Block: -1}).getParams()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"BLOCK", "0", "STREAMS", "a", "$"}, byteArrArrToStrArr(params))
+
+	_, err = (&XReadArgs{Keys: []string{"a", "b"}, IDs: []string{"0"}}).getParams()
+	assert.NotNil(t, err)
+	_, err = (&XReadArgs{}).getParams()
+	assert.NotNil(t, err)
+}
+
+func TestXReadGroupArgs_getParams(t *testing.T) {
+	params, err := (&XReadGroupArgs{Group: "g", Consumer: "c", Keys: []string{"s"}, IDs: []string{">"}, Count: 5, Block: time.Second, NoAck: true}).getParams()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"GROUP", "g", "c", "NOACK", "COUNT", "5", "BLOCK", "1000", "STREAMS", "s", ">"}, byteArrArrToStrArr(params))
+
+	_, err = (&XReadGroupArgs{Keys: []string{"s"}, IDs: []string{">"}}).getParams()
+	assert.NotNil(t, err)
+	_, err = (*XReadGroupArgs)(nil).getParams()
+	assert.NotNil(t, err)
+}
+
+func TestXPendingArgs_getParams(t *testing.T) {
+	assert.Equal(t, []string{"s", "g", "-", "+", "10"}, byteArrArrToStrArr((*XPendingArgs)(nil).getParams("s", "g")))
+	params := (&XPendingArgs{Idle: time.Second, Start: "1-0", End: "2-0", Count: 1, Consumer: "c"}).getParams("s", "g")
+	assert.Equal(t, []string{"s", "g", "IDLE", "1000", "1-0", "2-0", "1", "c"}, byteArrArrToStrArr(params))
+}
+
+func TestXClaimArgs_getParams(t *testing.T) {
+	params, err := (&XClaimArgs{Group: "g", Consumer: "c", MinIdle: time.Second, IDs: []string{"1-0", "2-0"}, RetryCount: 3, Force: true}).getParams("s")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"s", "g", "c", "1000", "1-0", "2-0", "RETRYCOUNT", "3", "FORCE"}, byteArrArrToStrArr(params))
+
+	_, err = (&XClaimArgs{Group: "g", Consumer: "c"}).getParams("s")
+	assert.NotNil(t, err)
+}
+
+func TestXAutoClaimArgs_getParams(t *testing.T) {
+	params, err := (&XAutoClaimArgs{Group: "g", Consumer: "c", MinIdle: time.Second}).getParams("s")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"s", "g", "c", "1000", "0-0"}, byteArrArrToStrArr(params))
+	params, err = (&XAutoClaimArgs{Group: "g", Consumer: "c", Start: "5-0", Count: 2}).getParams("s")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"s", "g", "c", "0", "5-0", "COUNT", "2"}, byteArrArrToStrArr(params))
+
+	_, err = (&XAutoClaimArgs{Group: "g"}).getParams("s")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,661 @@
+package godis
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//StreamEntry entry of a stream
+type StreamEntry struct {
+	ID     string            //id of the entry, like 1526919030474-55
+	Fields map[string]string //field value pairs of the entry, nil if the entry is deleted
+}
+
+//XStream entries of a stream returned by XREAD
+type XStream struct {
+	Key     string //key of the stream
+	Entries []*StreamEntry
+}
+
+//XAddArgs arguments of XADD
+type XAddArgs struct {
+	ID         string            //id of the entry, empty means the id is generated by the server
+	Fields     map[string]string //field value pairs of the entry, at least one pair is required
+	NoMkStream bool              //don't create the stream if it doesn't exist, XAdd returns empty id then
+
+	MaxLen      int64  //trim the stream to MaxLen entries after adding, 0 means no trimming by length
+	MinID       string //trim the entries with ids lower than MinID after adding, MaxLen is ignored if MinID is set
+	Approximate bool   //trim with ~, the stream may keep a few more entries, which is much more efficient
+	Limit       int64  //max entries evicted by approximate trimming, 0 means the default of the server
+}
+
+//XTrimArgs arguments of XTRIM
+type XTrimArgs struct {
+	MaxLen      int64  //trim the stream to MaxLen entries
+	MinID       string //trim the entries with ids lower than MinID, MaxLen is ignored if MinID is set
+	Approximate bool   //trim with ~, the stream may keep a few more entries, which is much more efficient
+	Limit       int64  //max entries evicted by approximate trimming, 0 means the default of the server
+}
+
+//XReadArgs arguments of XREAD
+type XReadArgs struct {
+	Keys  []string //keys of the streams
+	IDs   []string //read the entries with ids greater than the id of the same index, $ means the entries added from now on
+	Count int64    //max entries returned per stream, 0 means no limit
+	//Block wait for the entries if none is available, 0 doesn't block, negative blocks forever.
+	// the socket timeout is extended by Block while waiting
+	Block time.Duration
+}
+
+//XInfoStream reply of XINFO STREAM
+type XInfoStream struct {
+	Length               int64
+	RadixTreeKeys        int64
+	RadixTreeNodes       int64
+	Groups               int64
+	LastGeneratedID      string
+	MaxDeletedEntryID    string //since redis 7.0
+	EntriesAdded         int64  //since redis 7.0
+	RecordedFirstEntryID string //since redis 7.0
+	FirstEntry           *StreamEntry
+	LastEntry            *StreamEntry
+}
+
+//XReadGroupArgs arguments of XREADGROUP
+type XReadGroupArgs struct {
+	Group    string   //name of the consumer group
+	Consumer string   //name of the consumer, it's created on the first read
+	Keys     []string //keys of the streams
+	//IDs > means the entries never delivered to other consumers,
+	// other ids read the pending entries of the consumer with ids greater than the id
+	IDs   []string
+	Count int64 //max entries returned per stream, 0 means no limit
+	//Block wait for the entries if none is available, 0 doesn't block, negative blocks forever.
+	// the socket timeout is extended by Block while waiting
+	Block time.Duration
+	NoAck bool //the entries are acknowledged when they are read
+}
+
+//XPendingSummary summary form reply of XPENDING
+type XPendingSummary struct {
+	Count     int64            //number of the pending entries of the group
+	Lowest    string           //lowest id of the pending entries
+	Highest   string           //highest id of the pending entries
+	Consumers map[string]int64 //number of the pending entries per consumer
+}
+
+//XPendingArgs arguments of the extended form of XPENDING
+type XPendingArgs struct {
+	Idle     time.Duration //only the entries idle for at least Idle, since redis 6.2
+	Start    string        //lowest id, default is -
+	End      string        //highest id, default is +
+	Count    int64         //max entries returned, default is 10
+	Consumer string        //only the entries of the consumer, empty means all the consumers
+}
+
+//XPendingEntry pending entry returned by the extended form of XPENDING
+type XPendingEntry struct {
+	ID            string        //id of the entry
+	Consumer      string        //the consumer the entry is delivered to
+	Idle          time.Duration //elapsed time since the entry was delivered last time
+	DeliveryCount int64         //number of times the entry was delivered
+}
+
+//XClaimArgs arguments of XCLAIM
+type XClaimArgs struct {
+	Group      string        //name of the consumer group
+	Consumer   string        //the consumer the entries are claimed to
+	MinIdle    time.Duration //only the entries idle for at least MinIdle are claimed
+	IDs        []string      //ids of the pending entries
+	Idle       time.Duration //set the idle time of the claimed entries, 0 means the idle time is reset
+	RetryCount int64         //set the delivery count of the claimed entries, 0 means the count is incremented
+	Force      bool          //create the pending entries of the ids which aren't pending yet
+}
+
+//XAutoClaimArgs arguments of XAUTOCLAIM
+type XAutoClaimArgs struct {
+	Group    string        //name of the consumer group
+	Consumer string        //the consumer the entries are claimed to
+	MinIdle  time.Duration //only the entries idle for at least MinIdle are claimed
+	Start    string        //scan the pending entries from the id, default is 0-0
+	Count    int64         //max entries claimed, 0 means the default of the server
+}
+
+//XAutoClaimResult reply of XAUTOCLAIM
+type XAutoClaimResult struct {
+	Next       string         //start id of the next XAUTOCLAIM, 0-0 means the pending entries are scanned completely
+	Entries    []*StreamEntry //the claimed entries
+	DeletedIDs []string       //ids of the pending entries removed because they are deleted from the stream, since redis 7.0
+}
+
+func (a *XAddArgs) getParams(key string) ([][]byte, error) {
+	if a == nil || len(a.Fields) == 0 {
+		return nil, newDataError("at least one field is required by XADD")
+	}
+	params := make([]string, 0, len(a.Fields)*2+8)
+	params = append(params, key)
+	if a.NoMkStream {
+		params = append(params, keywordNoMkStream.name)
+	}
+	if a.MinID != "" || a.MaxLen > 0 {
+		params = append(params, streamTrimParams(a.MaxLen, a.MinID, a.Approximate, a.Limit)...)
+	}
+	id := a.ID
+	if id == "" {
+		id = "*"
+	}
+	params = append(params, id)
+	fields := make([]string, 0, len(a.Fields))
+	for field := range a.Fields {
+		fields = append(fields, field)
+	}
+	//the fields are sorted to send the same command for the same args
+	sort.Strings(fields)
+	for _, field := range fields {
+		params = append(params, field, a.Fields[field])
+	}
+	return StrArrToByteArrArr(params), nil
+}
+
+func (a *XTrimArgs) getParams(key string) ([][]byte, error) {
+	if a == nil {
+		return nil, newDataError("trim strategy is required by XTRIM")
+	}
+	params := append([]string{key}, streamTrimParams(a.MaxLen, a.MinID, a.Approximate, a.Limit)...)
+	return StrArrToByteArrArr(params), nil
+}
+
+//streamTrimParams trim strategy of XADD and XTRIM
+func streamTrimParams(maxLen int64, minID string, approximate bool, limit int64) []string {
+	params := make([]string, 0, 5)
+	threshold := strconv.FormatInt(maxLen, 10)
+	if minID != "" {
+		params = append(params, keywordMinID.name)
+		threshold = minID
+	} else {
+		params = append(params, keywordMaxLen.name)
+	}
+	if approximate {
+		params = append(params, "~", threshold)
+		if limit > 0 {
+			params = append(params, keywordLimit.name, strconv.FormatInt(limit, 10))
+		}
+	} else {
+		params = append(params, threshold)
+	}
+	return params
+}
+
+func checkXReadArgs(args *XReadArgs) error {
+	if args == nil {
+		return newDataError("at least one stream is required by XREAD")
+	}
+	return checkStreamReadKeys(args.Keys, args.IDs)
+}
+
+func checkStreamReadKeys(keys, ids []string) error {
+	if len(keys) == 0 {
+		return newDataError("at least one stream is required")
+	}
+	if len(keys) != len(ids) {
+		return newDataError("every stream requires an id")
+	}
+	return nil
+}
+
+func (a *XReadArgs) getParams() ([][]byte, error) {
+	if err := checkXReadArgs(a); err != nil {
+		return nil, err
+	}
+	return StrArrToByteArrArr(streamReadParams(nil, a.Keys, a.IDs, a.Count, a.Block)), nil
+}
+
+//streamReadParams append COUNT, BLOCK and STREAMS of XREAD and XREADGROUP to params
+func streamReadParams(params []string, keys, ids []string, count int64, block time.Duration) []string {
+	if count > 0 {
+		params = append(params, keywordCount.name, strconv.FormatInt(count, 10))
+	}
+	if block != 0 {
+		params = append(params, keywordBlock.name, strconv.FormatInt(streamBlockMillis(block), 10))
+	}
+	params = append(params, keywordStreams.name)
+	params = append(params, keys...)
+	return append(params, ids...)
+}
+
+func checkXReadGroupArgs(args *XReadGroupArgs) error {
+	if args == nil || args.Group == "" || args.Consumer == "" {
+		return newDataError("group and consumer are required by XREADGROUP")
+	}
+	return checkStreamReadKeys(args.Keys, args.IDs)
+}
+
+func (a *XReadGroupArgs) getParams() ([][]byte, error) {
+	if err := checkXReadGroupArgs(a); err != nil {
+		return nil, err
+	}
+	params := []string{keywordGroup.name, a.Group, a.Consumer}
+	if a.NoAck {
+		params = append(params, keywordNoAck.name)
+	}
+	return StrArrToByteArrArr(streamReadParams(params, a.Keys, a.IDs, a.Count, a.Block)), nil
+}
+
+func (a *XPendingArgs) getParams(key, group string) [][]byte {
+	if a == nil {
+		a = &XPendingArgs{}
+	}
+	params := []string{key, group}
+	if a.Idle > 0 {
+		params = append(params, keywordIdle.name, strconv.FormatInt(int64(a.Idle/time.Millisecond), 10))
+	}
+	start, end, count := a.Start, a.End, a.Count
+	if start == "" {
+		start = "-"
+	}
+	if end == "" {
+		end = "+"
+	}
+	if count <= 0 {
+		count = 10
+	}
+	params = append(params, start, end, strconv.FormatInt(count, 10))
+	if a.Consumer != "" {
+		params = append(params, a.Consumer)
+	}
+	return StrArrToByteArrArr(params)
+}
+
+func (a *XClaimArgs) getParams(key string) ([][]byte, error) {
+	if a == nil || a.Group == "" || a.Consumer == "" || len(a.IDs) == 0 {
+		return nil, newDataError("group, consumer and ids are required by XCLAIM")
+	}
+	params := []string{key, a.Group, a.Consumer, strconv.FormatInt(int64(a.MinIdle/time.Millisecond), 10)}
+	params = append(params, a.IDs...)
+	if a.Idle > 0 {
+		params = append(params, keywordIdle.name, strconv.FormatInt(int64(a.Idle/time.Millisecond), 10))
+	}
+	if a.RetryCount > 0 {
+		params = append(params, keywordRetryCount.name, strconv.FormatInt(a.RetryCount, 10))
+	}
+	if a.Force {
+		params = append(params, keywordForce.name)
+	}
+	return StrArrToByteArrArr(params), nil
+}
+
+func (a *XAutoClaimArgs) getParams(key string) ([][]byte, error) {
+	if a == nil || a.Group == "" || a.Consumer == "" {
+		return nil, newDataError("group and consumer are required by XAUTOCLAIM")
+	}
+	start := a.Start
+	if start == "" {
+		start = "0-0"
+	}
+	params := []string{key, a.Group, a.Consumer, strconv.FormatInt(int64(a.MinIdle/time.Millisecond), 10), start}
+	if a.Count > 0 {
+		params = append(params, keywordCount.name, strconv.FormatInt(a.Count, 10))
+	}
+	return StrArrToByteArrArr(params), nil
+}
+
+//streamBlockMillis BLOCK argument in milliseconds, 0 means blocking forever
+func streamBlockMillis(block time.Duration) int64 {
+	if block < 0 {
+		return 0
+	}
+	millis := int64(block / time.Millisecond)
+	if millis == 0 {
+		//BLOCK 0 blocks forever, a positive duration shorter than 1ms waits 1ms
+		millis = 1
+	}
+	return millis
+}
+
+//parseStreamEntry parse an entry of the reply, it's an array of id and field value pairs
+func parseStreamEntry(reply interface{}) (*StreamEntry, error) {
+	if reply == nil {
+		return nil, nil
+	}
+	item, ok := reply.([]interface{})
+	if !ok || len(item) != 2 {
+		return nil, newDataError(fmt.Sprintf("malformed stream entry: %v", reply))
+	}
+	id, ok := item[0].([]byte)
+	if !ok {
+		return nil, newDataError(fmt.Sprintf("malformed stream entry id: %v", item[0]))
+	}
+	entry := &StreamEntry{ID: string(id)}
+	if item[1] == nil {
+		return entry, nil
+	}
+	pairs, ok := item[1].([]interface{})
+	if !ok || len(pairs)%2 != 0 {
+		return nil, newDataError(fmt.Sprintf("malformed stream entry fields: %v", item[1]))
+	}
+	entry.Fields = make(map[string]string, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		entry.Fields[replyToString(pairs[i])] = replyToString(pairs[i+1])
+	}
+	return entry, nil
+}
+
+//ObjArrToStreamEntryArrReply convert object array reply to stream entry array reply
+func ObjArrToStreamEntryArrReply(reply []interface{}, err error) ([]*StreamEntry, error) {
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*StreamEntry, 0, len(reply))
+	for _, item := range reply {
+		entry, err := parseStreamEntry(item)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+//ObjArrToXStreamArrReply convert object array reply to XStream array reply, the reply is an array of key and entries
+func ObjArrToXStreamArrReply(reply []interface{}, err error) ([]*XStream, error) {
+	if err != nil {
+		return nil, err
+	}
+	streams := make([]*XStream, 0, len(reply))
+	for _, item := range reply {
+		pair, ok := item.([]interface{})
+		if !ok || len(pair) != 2 {
+			return nil, newDataError(fmt.Sprintf("malformed stream: %v", item))
+		}
+		entries, _ := pair[1].([]interface{})
+		stream := &XStream{Key: replyToString(pair[0])}
+		stream.Entries, err = ObjArrToStreamEntryArrReply(entries, nil)
+		if err != nil {
+			return nil, err
+		}
+		streams = append(streams, stream)
+	}
+	return streams, nil
+}
+
+//ObjArrToXInfoStreamReply convert object array reply of XINFO STREAM to XInfoStream reply
+func ObjArrToXInfoStreamReply(reply []interface{}, err error) (*XInfoStream, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(reply)%2 != 0 {
+		return nil, newDataError("malformed XINFO STREAM reply")
+	}
+	info := &XInfoStream{}
+	for i := 0; i < len(reply); i += 2 {
+		value := reply[i+1]
+		switch replyToString(reply[i]) {
+		case "length":
+			info.Length, _ = value.(int64)
+		case "radix-tree-keys":
+			info.RadixTreeKeys, _ = value.(int64)
+		case "radix-tree-nodes":
+			info.RadixTreeNodes, _ = value.(int64)
+		case "groups":
+			info.Groups, _ = value.(int64)
+		case "entries-added":
+			info.EntriesAdded, _ = value.(int64)
+		case "last-generated-id":
+			info.LastGeneratedID = replyToString(value)
+		case "max-deleted-entry-id":
+			info.MaxDeletedEntryID = replyToString(value)
+		case "recorded-first-entry-id":
+			info.RecordedFirstEntryID = replyToString(value)
+		case "first-entry":
+			if info.FirstEntry, err = parseStreamEntry(value); err != nil {
+				return nil, err
+			}
+		case "last-entry":
+			if info.LastEntry, err = parseStreamEntry(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return info, nil
+}
+
+//ObjArrToXPendingSummaryReply convert object array reply of the summary form of XPENDING to XPendingSummary reply
+func ObjArrToXPendingSummaryReply(reply []interface{}, err error) (*XPendingSummary, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) != 4 {
+		return nil, newDataError(fmt.Sprintf("malformed XPENDING reply: %v", reply))
+	}
+	summary := &XPendingSummary{
+		Lowest:    replyToString(reply[1]),
+		Highest:   replyToString(reply[2]),
+		Consumers: make(map[string]int64),
+	}
+	summary.Count, _ = reply[0].(int64)
+	consumers, _ := reply[3].([]interface{})
+	for _, item := range consumers {
+		pair, ok := item.([]interface{})
+		if !ok || len(pair) != 2 {
+			return nil, newDataError(fmt.Sprintf("malformed consumer in XPENDING reply: %v", item))
+		}
+		count, err := strconv.ParseInt(replyToString(pair[1]), 10, 64)
+		if err != nil {
+			return nil, newDataError(fmt.Sprintf("malformed consumer in XPENDING reply: %v", item))
+		}
+		summary.Consumers[replyToString(pair[0])] = count
+	}
+	return summary, nil
+}
+
+//ObjArrToXPendingEntryArrReply convert object array reply of the extended form of XPENDING to XPendingEntry array reply
+func ObjArrToXPendingEntryArrReply(reply []interface{}, err error) ([]*XPendingEntry, error) {
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*XPendingEntry, 0, len(reply))
+	for _, item := range reply {
+		fields, ok := item.([]interface{})
+		if !ok || len(fields) != 4 {
+			return nil, newDataError(fmt.Sprintf("malformed pending entry: %v", item))
+		}
+		idle, ok1 := fields[2].(int64)
+		count, ok2 := fields[3].(int64)
+		if !ok1 || !ok2 {
+			return nil, newDataError(fmt.Sprintf("malformed pending entry: %v", item))
+		}
+		entries = append(entries, &XPendingEntry{
+			ID:            replyToString(fields[0]),
+			Consumer:      replyToString(fields[1]),
+			Idle:          time.Duration(idle) * time.Millisecond,
+			DeliveryCount: count,
+		})
+	}
+	return entries, nil
+}
+
+//ObjArrToXAutoClaimResultReply convert object array reply of XAUTOCLAIM to XAutoClaimResult reply
+func ObjArrToXAutoClaimResultReply(reply []interface{}, err error) (*XAutoClaimResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) < 2 {
+		return nil, newDataError(fmt.Sprintf("malformed XAUTOCLAIM reply: %v", reply))
+	}
+	result := &XAutoClaimResult{Next: replyToString(reply[0]), DeletedIDs: make([]string, 0)}
+	entries, _ := reply[1].([]interface{})
+	if result.Entries, err = ObjArrToStreamEntryArrReply(entries, nil); err != nil {
+		return nil, err
+	}
+	if len(reply) > 2 {
+		deleted, _ := reply[2].([]interface{})
+		for _, id := range deleted {
+			result.DeletedIDs = append(result.DeletedIDs, replyToString(id))
+		}
+	}
+	return result, nil
+}
+
+//ToStreamEntryArrReply convert object reply to stream entry array reply
+func ToStreamEntryArrReply(reply interface{}, err error) ([]*StreamEntry, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.([]*StreamEntry), nil
+}
+
+//ToXStreamArrReply convert object reply to XStream array reply
+func ToXStreamArrReply(reply interface{}, err error) ([]*XStream, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.([]*XStream), nil
+}
+
+//ToXInfoStreamReply convert object reply to XInfoStream reply
+func ToXInfoStreamReply(reply interface{}, err error) (*XInfoStream, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.(*XInfoStream), nil
+}
+
+//ToXPendingSummaryReply convert object reply to XPendingSummary reply
+func ToXPendingSummaryReply(reply interface{}, err error) (*XPendingSummary, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.(*XPendingSummary), nil
+}
+
+//ToXPendingEntryArrReply convert object reply to XPendingEntry array reply
+func ToXPendingEntryArrReply(reply interface{}, err error) ([]*XPendingEntry, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.([]*XPendingEntry), nil
+}
+
+//ToXAutoClaimResultReply convert object reply to XAutoClaimResult reply
+func ToXAutoClaimResultReply(reply interface{}, err error) (*XAutoClaimResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.(*XAutoClaimResult), nil
+}
+
+var (
+	//StreamEntryArrBuilder convert interface to stream entry array
+	StreamEntryArrBuilder = newStreamEntryArrBuilder()
+	//XStreamArrBuilder convert interface to XStream array
+	XStreamArrBuilder = newXStreamArrBuilder()
+	//XInfoStreamBuilder convert interface to XInfoStream
+	XInfoStreamBuilder = newXInfoStreamBuilder()
+	//XPendingSummaryBuilder convert interface to XPendingSummary
+	XPendingSummaryBuilder = newXPendingSummaryBuilder()
+	//XPendingEntryArrBuilder convert interface to XPendingEntry array
+	XPendingEntryArrBuilder = newXPendingEntryArrBuilder()
+	//XAutoClaimResultBuilder convert interface to XAutoClaimResult
+	XAutoClaimResultBuilder = newXAutoClaimResultBuilder()
+)
+
+type streamEntryArrBuilder struct {
+}
+
+func newStreamEntryArrBuilder() *streamEntryArrBuilder {
+	return &streamEntryArrBuilder{}
+}
+
+func (b *streamEntryArrBuilder) build(data interface{}) (interface{}, error) {
+	if data == nil {
+		return []*StreamEntry{}, nil
+	}
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToStreamEntryArrReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
+
+type xStreamArrBuilder struct {
+}
+
+func newXStreamArrBuilder() *xStreamArrBuilder {
+	return &xStreamArrBuilder{}
+}
+
+func (b *xStreamArrBuilder) build(data interface{}) (interface{}, error) {
+	if data == nil {
+		return []*XStream{}, nil
+	}
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToXStreamArrReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
+
+type xInfoStreamBuilder struct {
+}
+
+func newXInfoStreamBuilder() *xInfoStreamBuilder {
+	return &xInfoStreamBuilder{}
+}
+
+func (b *xInfoStreamBuilder) build(data interface{}) (interface{}, error) {
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToXInfoStreamReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
+
+type xPendingSummaryBuilder struct {
+}
+
+func newXPendingSummaryBuilder() *xPendingSummaryBuilder {
+	return &xPendingSummaryBuilder{}
+}
+
+func (b *xPendingSummaryBuilder) build(data interface{}) (interface{}, error) {
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToXPendingSummaryReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
+
+type xPendingEntryArrBuilder struct {
+}
+
+func newXPendingEntryArrBuilder() *xPendingEntryArrBuilder {
+	return &xPendingEntryArrBuilder{}
+}
+
+func (b *xPendingEntryArrBuilder) build(data interface{}) (interface{}, error) {
+	if data == nil {
+		return []*XPendingEntry{}, nil
+	}
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToXPendingEntryArrReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
+
+type xAutoClaimResultBuilder struct {
+}
+
+func newXAutoClaimResultBuilder() *xAutoClaimResultBuilder {
+	return &xAutoClaimResultBuilder{}
+}
+
+func (b *xAutoClaimResultBuilder) build(data interface{}) (interface{}, error) {
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToXAutoClaimResultReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
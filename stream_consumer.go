@@ -0,0 +1,338 @@
+package godis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	deadLetterSourceField     = "dead-letter-source-id"
+	deadLetterDeliveriesField = "dead-letter-deliveries"
+)
+
+//StreamConsumerOption option of StreamConsumer
+type StreamConsumerOption struct {
+	Stream        string                         //key of the stream
+	Group         string                         //name of the consumer group, it's created when the consumer starts if it doesn't exist
+	Consumer      string                         //name prefix of the consumers, the workers are named Consumer-0 to Consumer-(Concurrency-1)
+	StartID       string                         //the created group reads the entries after StartID, default is $
+	Concurrency   int                            //number of the workers, the pool must be able to provide a connection per worker, default is 1
+	Count         int64                          //max entries read or claimed at once by a worker, default is 10
+	Block         time.Duration                  //block time of XREADGROUP, Close waits for the blocking reads, default is 1 second
+	Handler       func(entry *StreamEntry) error //handle the entry, it's acknowledged if nil is returned, otherwise it stays pending, a panic is reported to OnError as a failure
+	RetryInterval time.Duration                  //the failed entries pending on a worker are handled again by the worker every RetryInterval, default is 5 seconds
+
+	ClaimMinIdle     time.Duration                              //the pending entries idle for at least ClaimMinIdle are claimed from other consumers, 0 disables claiming
+	ClaimInterval    time.Duration                              //interval of claiming by every worker, default is ClaimMinIdle
+	MaxDeliveries    int64                                      //the entries delivered more than MaxDeliveries times are moved to DeadLetterStream instead of being handled, 0 means no limit
+	DeadLetterStream string                                     //stream of the dead entries, default is Stream:dead
+	OnError          func(err error)                            //called when reading, handling or claiming fails, the workers keep running
+	OnDeadLetter     func(entry *StreamEntry, deliveries int64) //called after the entry is moved to DeadLetterStream
+}
+
+//StreamConsumer consume a stream as a consumer group with several workers,
+// the entries which are not acknowledged by dead or slow consumers are claimed by XAUTOCLAIM and handled again
+type StreamConsumer struct {
+	pool   *Pool
+	option *StreamConsumerOption
+
+	mu      sync.Mutex
+	started bool
+	closed  bool
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+//NewStreamConsumer create the stream consumer on the pool, it doesn't consume until Start is called
+func (p *Pool) NewStreamConsumer(option *StreamConsumerOption) (*StreamConsumer, error) {
+	if option == nil || option.Stream == "" || option.Group == "" || option.Consumer == "" || option.Handler == nil {
+		return nil, newDataError("stream, group, consumer and handler of the stream consumer are required")
+	}
+	if option.StartID == "" {
+		option.StartID = "$"
+	}
+	if option.Concurrency <= 0 {
+		option.Concurrency = 1
+	}
+	if option.Count <= 0 {
+		option.Count = 10
+	}
+	if option.Block <= 0 {
+		option.Block = time.Second
+	}
+	if option.RetryInterval <= 0 {
+		option.RetryInterval = 5 * time.Second
+	}
+	if option.ClaimInterval <= 0 {
+		option.ClaimInterval = option.ClaimMinIdle
+	}
+	if option.DeadLetterStream == "" {
+		option.DeadLetterStream = option.Stream + ":dead"
+	}
+	return &StreamConsumer{pool: p, option: option, done: make(chan struct{})}, nil
+}
+
+//Start create the consumer group if it doesn't exist and start the workers
+func (c *StreamConsumer) Start() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return newDataError("stream consumer is closed")
+	}
+	if c.started {
+		return nil
+	}
+	if err := c.createGroup(); err != nil {
+		return err
+	}
+	c.started = true
+	for i := 0; i < c.option.Concurrency; i++ {
+		c.wg.Add(1)
+		go c.work(c.option.Consumer + "-" + strconv.Itoa(i))
+	}
+	return nil
+}
+
+//Close stop the workers and wait for them to finish the entries being handled
+func (c *StreamConsumer) Close() {
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.done)
+	}
+	c.mu.Unlock()
+	c.wg.Wait()
+}
+
+func (c *StreamConsumer) createGroup() error {
+	redis, err := c.pool.GetResource()
+	if err != nil {
+		return err
+	}
+	defer redis.Close()
+	_, err = redis.XGroupCreate(c.option.Stream, c.option.Group, c.option.StartID, true)
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+//work read and handle the entries as the consumer until the StreamConsumer is closed,
+// the pending entries of the consumer left by the last run are handled first,
+// then the pending entries are handled again every RetryInterval
+func (c *StreamConsumer) work(consumer string) {
+	defer c.wg.Done()
+	pendingID := "0"
+	claimStart := "0-0"
+	var lastClaim time.Time
+	lastRetry := time.Now()
+	for retries := 0; !c.isClosed(); {
+		if c.option.ClaimMinIdle > 0 && time.Since(lastClaim) >= c.option.ClaimInterval {
+			lastClaim = time.Now()
+			claimStart = c.claim(consumer, claimStart)
+		}
+		if pendingID == "" && time.Since(lastRetry) >= c.option.RetryInterval {
+			lastRetry = time.Now()
+			pendingID = "0"
+		}
+		id := ">"
+		if pendingID != "" {
+			id = pendingID
+		}
+		entries, err := c.read(consumer, id)
+		if err != nil {
+			c.onError(err)
+			select {
+			case <-c.done:
+				return
+			case <-time.After(pubSubBackoff(retries)):
+			}
+			retries++
+			continue
+		}
+		retries = 0
+		if pendingID != "" {
+			if len(entries) == 0 {
+				pendingID = ""
+				continue
+			}
+			pendingID = entries[len(entries)-1].ID
+		}
+	}
+}
+
+//read the entries after id and handle them
+func (c *StreamConsumer) read(consumer, id string) ([]*StreamEntry, error) {
+	redis, err := c.pool.GetResource()
+	if err != nil {
+		return nil, err
+	}
+	defer redis.Close()
+	streams, err := redis.XReadGroup(&XReadGroupArgs{
+		Group:    c.option.Group,
+		Consumer: consumer,
+		Keys:     []string{c.option.Stream},
+		IDs:      []string{id},
+		Count:    c.option.Count,
+		Block:    c.option.Block,
+	})
+	if err != nil || len(streams) == 0 {
+		return nil, err
+	}
+	//the new entries are delivered for the first time, only the pending entries may exceed MaxDeliveries
+	c.process(redis, consumer, streams[0].Entries, id != ">")
+	return streams[0].Entries, nil
+}
+
+//claim the idle pending entries from start and handle them, the start id of the next claiming is returned
+func (c *StreamConsumer) claim(consumer, start string) string {
+	redis, err := c.pool.GetResource()
+	if err != nil {
+		c.onError(err)
+		return start
+	}
+	defer redis.Close()
+	result, err := redis.XAutoClaim(c.option.Stream, &XAutoClaimArgs{
+		Group:    c.option.Group,
+		Consumer: consumer,
+		MinIdle:  c.option.ClaimMinIdle,
+		Start:    start,
+		Count:    c.option.Count,
+	})
+	if err != nil {
+		c.onError(err)
+		return start
+	}
+	c.process(redis, consumer, result.Entries, true)
+	return result.Next
+}
+
+//process handle the entries, when redelivered is true, the entries delivered more than MaxDeliveries times
+// are moved to DeadLetterStream instead
+func (c *StreamConsumer) process(redis *Redis, consumer string, entries []*StreamEntry, redelivered bool) {
+	var deliveries map[string]int64
+	if redelivered && c.option.MaxDeliveries > 0 {
+		var err error
+		if deliveries, err = c.deliveries(redis, consumer, entries); err != nil {
+			c.onError(err)
+		}
+	}
+	for _, entry := range entries {
+		if count := deliveries[entry.ID]; c.option.MaxDeliveries > 0 && count > c.option.MaxDeliveries && entry.Fields != nil {
+			c.deadLetter(redis, entry, count)
+			continue
+		}
+		c.handle(redis, entry)
+	}
+}
+
+//deliveries return the delivery counts of the entries claimed by the consumer
+func (c *StreamConsumer) deliveries(redis *Redis, consumer string, entries []*StreamEntry) (map[string]int64, error) {
+	counts := make(map[string]int64, len(entries))
+	if len(entries) == 0 {
+		return counts, nil
+	}
+	p := redis.Pipelined()
+	responses := make([]*Response, 0, len(entries))
+	for _, entry := range entries {
+		response, err := p.XPendingRange(c.option.Stream, c.option.Group, &XPendingArgs{
+			Start:    entry.ID,
+			End:      entry.ID,
+			Count:    1,
+			Consumer: consumer,
+		})
+		if err != nil {
+			return counts, err
+		}
+		responses = append(responses, response)
+	}
+	if err := p.Sync(); err != nil {
+		return counts, err
+	}
+	for _, response := range responses {
+		pending, err := ToXPendingEntryArrReply(response.Get())
+		if err != nil {
+			return counts, err
+		}
+		for _, entry := range pending {
+			counts[entry.ID] = entry.DeliveryCount
+		}
+	}
+	return counts, nil
+}
+
+//handle the entry by the handler and acknowledge it on success, the deleted entry is acknowledged directly
+func (c *StreamConsumer) handle(redis *Redis, entry *StreamEntry) {
+	if entry.Fields != nil {
+		if err := c.callHandler(entry); err != nil {
+			c.onError(err)
+			return
+		}
+	}
+	if _, err := redis.XAck(c.option.Stream, c.option.Group, entry.ID); err != nil {
+		c.onError(err)
+	}
+}
+
+//callHandler call the handler, a panic is recovered as an error so the worker keeps running
+func (c *StreamConsumer) callHandler(entry *StreamEntry) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("stream consumer handler panics on entry %s: %v", entry.ID, e)
+		}
+	}()
+	return c.option.Handler(entry)
+}
+
+//deadLetter add the entry to the dead letter stream and acknowledge it in a transaction,
+// the id and the delivery count of the entry are added as dead-letter-source-id and dead-letter-deliveries
+func (c *StreamConsumer) deadLetter(redis *Redis, entry *StreamEntry, deliveries int64) {
+	fields := make(map[string]string, len(entry.Fields)+2)
+	for field, value := range entry.Fields {
+		fields[field] = value
+	}
+	fields[deadLetterSourceField] = entry.ID
+	fields[deadLetterDeliveriesField] = strconv.FormatInt(deliveries, 10)
+	t, err := redis.Multi()
+	if err != nil {
+		c.onError(err)
+		return
+	}
+	addResponse, err := t.XAdd(c.option.DeadLetterStream, &XAddArgs{Fields: fields})
+	if err == nil {
+		_, err = t.XAck(c.option.Stream, c.option.Group, entry.ID)
+	}
+	if err != nil {
+		_, _ = t.Discard()
+		c.onError(err)
+		return
+	}
+	if _, err = t.Exec(); err == nil {
+		_, err = addResponse.Get()
+	}
+	if err != nil {
+		c.onError(err)
+		return
+	}
+	if c.option.OnDeadLetter != nil {
+		c.option.OnDeadLetter(entry, deliveries)
+	}
+}
+
+func (c *StreamConsumer) onError(err error) {
+	if c.option.OnError != nil {
+		c.option.OnError(err)
+	}
+}
+
+func (c *StreamConsumer) isClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,199 @@
+package godis
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPool_NewStreamConsumer(t *testing.T) {
+	pool := NewPool(nil, option)
+	defer pool.Destroy()
+	_, err := pool.NewStreamConsumer(&StreamConsumerOption{Stream: "s", Group: "g"})
+	assert.NotNil(t, err)
+	consumer, err := pool.NewStreamConsumer(&StreamConsumerOption{
+		Stream:       "s",
+		Group:        "g",
+		Consumer:     "c",
+		Handler:      func(entry *StreamEntry) error { return nil },
+		ClaimMinIdle: time.Second,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "$", consumer.option.StartID)
+	assert.Equal(t, 1, consumer.option.Concurrency)
+	assert.Equal(t, time.Second, consumer.option.ClaimInterval)
+	assert.Equal(t, "s:dead", consumer.option.DeadLetterStream)
+	consumer.Close()
+	assert.NotNil(t, consumer.Start())
+}
+
+func TestStreamConsumer(t *testing.T) {
+	flushAll()
+	pool := NewPool(nil, option)
+	defer pool.Destroy()
+	var mu sync.Mutex
+	handled := make(map[string]int)
+	consumer, err := pool.NewStreamConsumer(&StreamConsumerOption{
+		Stream:      "godis",
+		Group:       "g",
+		Consumer:    "c",
+		StartID:     "0",
+		Concurrency: 2,
+		Block:       100 * time.Millisecond,
+		Handler: func(entry *StreamEntry) error {
+			mu.Lock()
+			defer mu.Unlock()
+			handled[entry.Fields["v"]]++
+			if entry.Fields["v"] == "bad" {
+				return errors.New("bad entry")
+			}
+			return nil
+		},
+		ClaimMinIdle:  50 * time.Millisecond,
+		MaxDeliveries: 2,
+	})
+	assert.Nil(t, err)
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err = redis.XAdd("godis", &XAddArgs{Fields: map[string]string{"v": "good"}})
+	assert.Nil(t, err)
+	_, err = redis.XAdd("godis", &XAddArgs{Fields: map[string]string{"v": "bad"}})
+	assert.Nil(t, err)
+	assert.Nil(t, consumer.Start())
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		length, _ := redis.XLen("godis:dead")
+		if length > 0 {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	consumer.Close()
+
+	mu.Lock()
+	assert.Equal(t, 1, handled["good"])
+	assert.Equal(t, 2, handled["bad"])
+	mu.Unlock()
+	dead, err := redis.XRange("godis:dead", "-", "+", 0)
+	assert.Nil(t, err)
+	assert.Len(t, dead, 1)
+	assert.Equal(t, "bad", dead[0].Fields["v"])
+	assert.Equal(t, "3", dead[0].Fields[deadLetterDeliveriesField])
+	summary, err := redis.XPending("godis", "g")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), summary.Count)
+}
+
+func TestStreamConsumer_callHandler(t *testing.T) {
+	consumer := &StreamConsumer{option: &StreamConsumerOption{Handler: func(entry *StreamEntry) error {
+		panic("boom")
+	}}}
+	err := consumer.callHandler(&StreamEntry{ID: "1-0", Fields: map[string]string{}})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "1-0")
+	assert.Contains(t, err.Error(), "boom")
+}
+
+func TestStreamConsumer_retry(t *testing.T) {
+	flushAll()
+	pool := NewPool(nil, option)
+	defer pool.Destroy()
+	var mu sync.Mutex
+	handled := 0
+	errs := make([]error, 0)
+	consumer, err := pool.NewStreamConsumer(&StreamConsumerOption{
+		Stream:        "godis",
+		Group:         "g",
+		Consumer:      "c",
+		StartID:       "0",
+		Block:         50 * time.Millisecond,
+		RetryInterval: 100 * time.Millisecond,
+		Handler: func(entry *StreamEntry) error {
+			mu.Lock()
+			defer mu.Unlock()
+			handled++
+			if handled == 1 {
+				panic("first delivery fails")
+			}
+			return nil
+		},
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		},
+	})
+	require.Nil(t, err)
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err = redis.XAdd("godis", &XAddArgs{Fields: map[string]string{"v": "flaky"}})
+	require.Nil(t, err)
+	require.Nil(t, consumer.Start())
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		retried := handled > 1
+		mu.Unlock()
+		summary, err := redis.XPending("godis", "g")
+		if err == nil && summary.Count == 0 && retried {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	consumer.Close()
+	mu.Lock()
+	defer mu.Unlock()
+	//the entry is handled again by the worker without claiming
+	assert.Equal(t, 2, handled)
+	assert.Len(t, errs, 1)
+	summary, err := redis.XPending("godis", "g")
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), summary.Count)
+}
+
+func TestStreamConsumer_maxDeliveriesOfPendingEntries(t *testing.T) {
+	flushAll()
+	redis := NewRedis(option)
+	defer redis.Close()
+	_, err := redis.XAdd("godis", &XAddArgs{Fields: map[string]string{"v": "bad"}})
+	require.Nil(t, err)
+	_, err = redis.XGroupCreate("godis", "g", "0", false)
+	require.Nil(t, err)
+	//the entry is delivered 3 times to the first worker before the consumer starts
+	for _, id := range []string{">", "0", "0"} {
+		_, err = redis.XReadGroup(&XReadGroupArgs{Group: "g", Consumer: "c-0", Keys: []string{"godis"}, IDs: []string{id}})
+		require.Nil(t, err)
+	}
+
+	pool := NewPool(nil, option)
+	defer pool.Destroy()
+	dead := make(chan int64, 1)
+	consumer, err := pool.NewStreamConsumer(&StreamConsumerOption{
+		Stream:        "godis",
+		Group:         "g",
+		Consumer:      "c",
+		Block:         50 * time.Millisecond,
+		MaxDeliveries: 2,
+		Handler: func(entry *StreamEntry) error {
+			t.Error("the entry delivered too many times is handled")
+			return nil
+		},
+		OnDeadLetter: func(entry *StreamEntry, deliveries int64) {
+			dead <- deliveries
+		},
+	})
+	require.Nil(t, err)
+	require.Nil(t, consumer.Start())
+	defer consumer.Close()
+	select {
+	case deliveries := <-dead:
+		assert.Equal(t, int64(4), deliveries)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the entry is not moved to the dead letter stream")
+	}
+}
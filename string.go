@@ -0,0 +1,259 @@
+package godis
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+//SetArgs the options of SET, the later condition or expiration replaces the former one,
+// so the conflicting options can't be sent
+type SetArgs struct {
+	condition       *keyword
+	expiration      *keyword
+	expirationValue int64
+	get             bool
+}
+
+//NewSetArgs create the options of SET, SET without any option is the same as Set()
+func NewSetArgs() *SetArgs {
+	return &SetArgs{}
+}
+
+//NX Only set the key if it does not already exist.
+func (a *SetArgs) NX() *SetArgs {
+	a.condition = keywordNx
+	return a
+}
+
+//XX Only set the key if it already exist.
+func (a *SetArgs) XX() *SetArgs {
+	a.condition = keywordXx
+	return a
+}
+
+//EX Set the specified expire time in seconds, the duration is truncated to seconds.
+func (a *SetArgs) EX(expiration time.Duration) *SetArgs {
+	a.expiration, a.expirationValue = keywordEx, int64(expiration/time.Second)
+	return a
+}
+
+//PX Set the specified expire time in milliseconds, the duration is truncated to milliseconds.
+func (a *SetArgs) PX(expiration time.Duration) *SetArgs {
+	a.expiration, a.expirationValue = keywordPx, int64(expiration/time.Millisecond)
+	return a
+}
+
+//EXAT Set the specified Unix time at which the key will expire, in seconds (redis 6.2+).
+func (a *SetArgs) EXAT(at time.Time) *SetArgs {
+	a.expiration, a.expirationValue = keywordExAt, at.Unix()
+	return a
+}
+
+//PXAT Set the specified Unix time at which the key will expire, in milliseconds (redis 6.2+).
+func (a *SetArgs) PXAT(at time.Time) *SetArgs {
+	a.expiration, a.expirationValue = keywordPxAt, at.UnixNano()/int64(time.Millisecond)
+	return a
+}
+
+//KEEPTTL Retain the time to live associated with the key (redis 6.0+).
+func (a *SetArgs) KEEPTTL() *SetArgs {
+	a.expiration, a.expirationValue = keywordKeepTTL, 0
+	return a
+}
+
+//GET Return the old string stored at key, or empty string if key did not exist (redis 6.2+).
+func (a *SetArgs) GET() *SetArgs {
+	a.get = true
+	return a
+}
+
+//getParams the key and the value followed by the options
+func (a *SetArgs) getParams(key, value string) [][]byte {
+	params := []string{key, value}
+	if a == nil {
+		return StrArrToByteArrArr(params)
+	}
+	if a.condition != nil {
+		params = append(params, a.condition.name)
+	}
+	if a.get {
+		params = append(params, keywordGet.name)
+	}
+	params = append(params, expirationParams(a.expiration, a.expirationValue)...)
+	return StrArrToByteArrArr(params)
+}
+
+//GetExArgs the options of GETEX, the later option replaces the former one
+type GetExArgs struct {
+	expiration      *keyword
+	expirationValue int64
+}
+
+//NewGetExArgs create the options of GETEX, GETEX without any option is the same as Get()
+func NewGetExArgs() *GetExArgs {
+	return &GetExArgs{}
+}
+
+//EX Set the specified expire time in seconds, the duration is truncated to seconds.
+func (a *GetExArgs) EX(expiration time.Duration) *GetExArgs {
+	a.expiration, a.expirationValue = keywordEx, int64(expiration/time.Second)
+	return a
+}
+
+//PX Set the specified expire time in milliseconds, the duration is truncated to milliseconds.
+func (a *GetExArgs) PX(expiration time.Duration) *GetExArgs {
+	a.expiration, a.expirationValue = keywordPx, int64(expiration/time.Millisecond)
+	return a
+}
+
+//EXAT Set the specified Unix time at which the key will expire, in seconds.
+func (a *GetExArgs) EXAT(at time.Time) *GetExArgs {
+	a.expiration, a.expirationValue = keywordExAt, at.Unix()
+	return a
+}
+
+//PXAT Set the specified Unix time at which the key will expire, in milliseconds.
+func (a *GetExArgs) PXAT(at time.Time) *GetExArgs {
+	a.expiration, a.expirationValue = keywordPxAt, at.UnixNano()/int64(time.Millisecond)
+	return a
+}
+
+//PERSIST Remove the time to live associated with the key.
+func (a *GetExArgs) PERSIST() *GetExArgs {
+	a.expiration, a.expirationValue = keywordPersist, 0
+	return a
+}
+
+//getParams the key followed by the options
+func (a *GetExArgs) getParams(key string) [][]byte {
+	params := []string{key}
+	if a != nil {
+		params = append(params, expirationParams(a.expiration, a.expirationValue)...)
+	}
+	return StrArrToByteArrArr(params)
+}
+
+//expirationParams the expiration option of SET and GETEX, KEEPTTL and PERSIST take no value
+func expirationParams(expiration *keyword, value int64) []string {
+	switch expiration {
+	case nil:
+		return nil
+	case keywordKeepTTL, keywordPersist:
+		return []string{expiration.name}
+	}
+	return []string{expiration.name, strconv.FormatInt(value, 10)}
+}
+
+//LCSIdxArgs the options of LCS with IDX
+type LCSIdxArgs struct {
+	MinMatchLen  int64 //only return the matches whose length is at least MinMatchLen, 0 returns all the matches
+	WithMatchLen bool  //return the length of every match
+}
+
+//LCSRange the inclusive range of a match in a string
+type LCSRange struct {
+	Start int64
+	End   int64
+}
+
+//LCSMatch a match of the longest common subsequence, Len is only set with WithMatchLen
+type LCSMatch struct {
+	Key1 LCSRange
+	Key2 LCSRange
+	Len  int64
+}
+
+//LCSResult the matches of the longest common subsequence and its length
+type LCSResult struct {
+	Matches []*LCSMatch
+	Len     int64
+}
+
+//getParams the keys followed by IDX and the options
+func (a *LCSIdxArgs) getParams(key1, key2 string) [][]byte {
+	params := []string{key1, key2, keywordIdx.name}
+	if a != nil && a.MinMatchLen > 0 {
+		params = append(params, keywordMinMatchLen.name, strconv.FormatInt(a.MinMatchLen, 10))
+	}
+	if a != nil && a.WithMatchLen {
+		params = append(params, keywordWithMatchLen.name)
+	}
+	return StrArrToByteArrArr(params)
+}
+
+//parseLCSRange parse the range of a match, it's an array of start and end
+func parseLCSRange(reply interface{}) (LCSRange, error) {
+	pair, ok := reply.([]interface{})
+	if !ok || len(pair) != 2 {
+		return LCSRange{}, newDataError(fmt.Sprintf("malformed LCS range: %v", reply))
+	}
+	start, ok1 := pair[0].(int64)
+	end, ok2 := pair[1].(int64)
+	if !ok1 || !ok2 {
+		return LCSRange{}, newDataError(fmt.Sprintf("malformed LCS range: %v", reply))
+	}
+	return LCSRange{Start: start, End: end}, nil
+}
+
+//ObjArrToLCSResultReply convert object array reply of LCS with IDX to LCSResult reply
+func ObjArrToLCSResultReply(reply []interface{}, err error) (*LCSResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	result := &LCSResult{Matches: make([]*LCSMatch, 0)}
+	for i := 0; i+1 < len(reply); i += 2 {
+		switch replyToString(reply[i]) {
+		case "len":
+			result.Len, _ = reply[i+1].(int64)
+		case "matches":
+			matches, _ := reply[i+1].([]interface{})
+			for _, item := range matches {
+				fields, ok := item.([]interface{})
+				if !ok || len(fields) < 2 {
+					return nil, newDataError(fmt.Sprintf("malformed LCS match: %v", item))
+				}
+				match := &LCSMatch{}
+				if match.Key1, err = parseLCSRange(fields[0]); err != nil {
+					return nil, err
+				}
+				if match.Key2, err = parseLCSRange(fields[1]); err != nil {
+					return nil, err
+				}
+				if len(fields) > 2 {
+					match.Len, _ = fields[2].(int64)
+				}
+				result.Matches = append(result.Matches, match)
+			}
+		}
+	}
+	return result, nil
+}
+
+//ToLCSResultReply convert object reply to LCSResult reply
+func ToLCSResultReply(reply interface{}, err error) (*LCSResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.(*LCSResult), nil
+}
+
+var (
+	//LCSResultBuilder convert interface to LCSResult
+	LCSResultBuilder = newLCSResultBuilder()
+)
+
+type lcsResultBuilder struct {
+}
+
+func newLCSResultBuilder() *lcsResultBuilder {
+	return &lcsResultBuilder{}
+}
+
+func (b *lcsResultBuilder) build(data interface{}) (interface{}, error) {
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToLCSResultReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
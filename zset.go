@@ -0,0 +1,299 @@
+package godis
+
+import (
+	"fmt"
+	"strconv"
+)
+
+//SortedSetOption pop the members with the lowest or the highest scores by ZMPOP and BZMPOP
+type SortedSetOption struct {
+	name string // name of SortedSetOption
+}
+
+//getRaw get the name byte array
+func (o *SortedSetOption) getRaw() []byte {
+	return []byte(o.name)
+}
+
+func newSortedSetOption(name string) *SortedSetOption {
+	return &SortedSetOption{name}
+}
+
+var (
+	//SortedSetOptionMin pop the members with the lowest scores
+	SortedSetOptionMin = newSortedSetOption("MIN")
+	//SortedSetOptionMax pop the members with the highest scores
+	SortedSetOptionMax = newSortedSetOption("MAX")
+)
+
+//KeyedTuple the tuple popped by BZPOPMIN or BZPOPMAX with the key it's popped from
+type KeyedTuple struct {
+	Key string
+	Tuple
+}
+
+//ZMPopResult the tuples popped by ZMPOP or BZMPOP with the key they're popped from
+type ZMPopResult struct {
+	Key    string
+	Tuples []Tuple
+}
+
+//ZRangeParams the unified range params of ZRANGE and ZRANGESTORE (redis 6.2+),
+// replacing ZRANGEBYSCORE, ZREVRANGEBYSCORE, ZRANGEBYLEX, ZREVRANGEBYLEX and their LIMIT variants
+type ZRangeParams struct {
+	min    string
+	max    string
+	by     *keyword
+	rev    bool
+	limit  bool
+	offset int64
+	count  int64
+}
+
+//NewZRangeParams range by the indexes, start and stop are inclusive and can be negative like ZRANGE
+func NewZRangeParams(start, stop int64) *ZRangeParams {
+	return &ZRangeParams{min: strconv.FormatInt(start, 10), max: strconv.FormatInt(stop, 10)}
+}
+
+//NewZRangeByScoreParams range by the scores, min and max can be exclusive like (1, or -inf and +inf
+func NewZRangeByScoreParams(min, max string) *ZRangeParams {
+	return &ZRangeParams{min: min, max: max, by: keywordByScore}
+}
+
+//NewZRangeByLexParams range by the members lexicographically, min and max are like [a, (a, - and +
+func NewZRangeByLexParams(min, max string) *ZRangeParams {
+	return &ZRangeParams{min: min, max: max, by: keywordByLex}
+}
+
+//Rev reverse the order, the elements are ordered from the highest to the lowest,
+// the range of the scores or the members must be given from max to min like ZREVRANGEBYSCORE
+func (p *ZRangeParams) Rev() *ZRangeParams {
+	p.rev = true
+	return p
+}
+
+//Limit return count elements after skipping offset elements, it's only accepted by the range of the scores or the members,
+// negative count returns all the elements after offset
+func (p *ZRangeParams) Limit(offset, count int64) *ZRangeParams {
+	p.limit = true
+	p.offset = offset
+	p.count = count
+	return p
+}
+
+//getParams the keys followed by the range params
+func (p *ZRangeParams) getParams(keys ...string) [][]byte {
+	params := make([]string, 0, len(keys)+7)
+	params = append(params, keys...)
+	params = append(params, p.min, p.max)
+	if p.by != nil {
+		params = append(params, p.by.name)
+	}
+	if p.rev {
+		params = append(params, keywordRev.name)
+	}
+	if p.limit {
+		params = append(params, keywordLimit.name, strconv.FormatInt(p.offset, 10), strconv.FormatInt(p.count, 10))
+	}
+	return StrArrToByteArrArr(params)
+}
+
+//numKeysParams numkeys followed by the keys, like ZDIFF, ZINTER, ZUNION and ZMPOP
+func numKeysParams(keys []string) [][]byte {
+	params := make([][]byte, 0, len(keys)+1)
+	params = append(params, IntToByteArr(len(keys)))
+	return append(params, StrArrToByteArrArr(keys)...)
+}
+
+//ObjArrToTupleArrReply convert object array reply of members and scores to tuple array reply
+func ObjArrToTupleArrReply(reply []interface{}, err error) ([]Tuple, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(reply)%2 != 0 {
+		return nil, newDataError(fmt.Sprintf("malformed tuple reply: %v", reply))
+	}
+	tuples := make([]Tuple, 0, len(reply)/2)
+	for i := 0; i < len(reply); i += 2 {
+		score, err := strconv.ParseFloat(replyToString(reply[i+1]), 64)
+		if err != nil {
+			return nil, err
+		}
+		tuples = append(tuples, Tuple{element: replyToString(reply[i]), score: score})
+	}
+	return tuples, nil
+}
+
+//ObjArrToFloat64PtrArrReply convert object array reply of ZMSCORE to float64 pointer array reply,
+// the pointer is nil if the member doesn't exist
+func ObjArrToFloat64PtrArrReply(reply []interface{}, err error) ([]*float64, error) {
+	if err != nil {
+		return nil, err
+	}
+	scores := make([]*float64, 0, len(reply))
+	for _, item := range reply {
+		if item == nil {
+			scores = append(scores, nil)
+			continue
+		}
+		score, err := strconv.ParseFloat(replyToString(item), 64)
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, &score)
+	}
+	return scores, nil
+}
+
+//ObjArrToKeyedTupleReply convert object array reply of BZPOPMIN and BZPOPMAX to KeyedTuple reply, nil means timeout
+func ObjArrToKeyedTupleReply(reply []interface{}, err error) (*KeyedTuple, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) == 0 {
+		return nil, nil
+	}
+	if len(reply) != 3 {
+		return nil, newDataError(fmt.Sprintf("malformed keyed tuple reply: %v", reply))
+	}
+	tuples, err := ObjArrToTupleArrReply(reply[1:], nil)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyedTuple{Key: replyToString(reply[0]), Tuple: tuples[0]}, nil
+}
+
+//ObjArrToZMPopResultReply convert object array reply of ZMPOP and BZMPOP to ZMPopResult reply,
+// nil means no member is popped
+func ObjArrToZMPopResultReply(reply []interface{}, err error) (*ZMPopResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) == 0 {
+		return nil, nil
+	}
+	items, ok := reply[1].([]interface{})
+	if len(reply) != 2 || !ok {
+		return nil, newDataError(fmt.Sprintf("malformed ZMPOP reply: %v", reply))
+	}
+	result := &ZMPopResult{Key: replyToString(reply[0]), Tuples: make([]Tuple, 0, len(items))}
+	for _, item := range items {
+		pair, ok := item.([]interface{})
+		if !ok {
+			return nil, newDataError(fmt.Sprintf("malformed tuple in ZMPOP reply: %v", item))
+		}
+		tuples, err := ObjArrToTupleArrReply(pair, nil)
+		if err != nil {
+			return nil, err
+		}
+		result.Tuples = append(result.Tuples, tuples...)
+	}
+	return result, nil
+}
+
+//ToFloat64PtrArrReply convert object reply to float64 pointer array reply
+func ToFloat64PtrArrReply(reply interface{}, err error) ([]*float64, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.([]*float64), nil
+}
+
+//ToKeyedTupleReply convert object reply to KeyedTuple reply
+func ToKeyedTupleReply(reply interface{}, err error) (*KeyedTuple, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.(*KeyedTuple), nil
+}
+
+//ToZMPopResultReply convert object reply to ZMPopResult reply
+func ToZMPopResultReply(reply interface{}, err error) (*ZMPopResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	return reply.(*ZMPopResult), nil
+}
+
+var (
+	//TupleArrBuilder convert interface to tuple array
+	TupleArrBuilder = newTupleArrBuilder()
+	//Float64PtrArrBuilder convert interface to float64 pointer array
+	Float64PtrArrBuilder = newFloat64PtrArrBuilder()
+	//KeyedTupleBuilder convert interface to KeyedTuple
+	KeyedTupleBuilder = newKeyedTupleBuilder()
+	//ZMPopResultBuilder convert interface to ZMPopResult
+	ZMPopResultBuilder = newZMPopResultBuilder()
+)
+
+type tupleArrBuilder struct {
+}
+
+func newTupleArrBuilder() *tupleArrBuilder {
+	return &tupleArrBuilder{}
+}
+
+func (b *tupleArrBuilder) build(data interface{}) (interface{}, error) {
+	if data == nil {
+		return []Tuple{}, nil
+	}
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToTupleArrReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
+
+type float64PtrArrBuilder struct {
+}
+
+func newFloat64PtrArrBuilder() *float64PtrArrBuilder {
+	return &float64PtrArrBuilder{}
+}
+
+func (b *float64PtrArrBuilder) build(data interface{}) (interface{}, error) {
+	if data == nil {
+		return []*float64{}, nil
+	}
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToFloat64PtrArrReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
+
+type keyedTupleBuilder struct {
+}
+
+func newKeyedTupleBuilder() *keyedTupleBuilder {
+	return &keyedTupleBuilder{}
+}
+
+func (b *keyedTupleBuilder) build(data interface{}) (interface{}, error) {
+	if data == nil {
+		return (*KeyedTuple)(nil), nil
+	}
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToKeyedTupleReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}
+
+type zmPopResultBuilder struct {
+}
+
+func newZMPopResultBuilder() *zmPopResultBuilder {
+	return &zmPopResultBuilder{}
+}
+
+func (b *zmPopResultBuilder) build(data interface{}) (interface{}, error) {
+	if data == nil {
+		return (*ZMPopResult)(nil), nil
+	}
+	switch data.(type) {
+	case []interface{}:
+		return ObjArrToZMPopResultReply(data.([]interface{}), nil)
+	}
+	return nil, fmt.Errorf("unexpected type:%T", data)
+}